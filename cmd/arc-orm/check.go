@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xhd2015/less-gen/flags"
+)
+
+const checkHelp = `
+Usage: arc-orm check [options] [packages...]
+
+Check that models are in sync with their table definitions
+without writing anything. Exits non-zero with a diff
+if 'arc-orm gen' would modify any file.
+
+Options:
+  --dir DIR   directory to load packages from
+  -h, --help  show help
+`
+
+func check(args []string) error {
+	var dir string
+	remainArgs, err := flags.String("--dir", &dir).
+		Help("-h,--help", checkHelp).
+		Parse(args)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
+	changes, err := syncModels(dir, remainArgs, cfg)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	printChanges(changes, true)
+	files := make([]string, 0, len(changes))
+	for _, change := range changes {
+		files = append(files, change.File)
+	}
+	return fmt.Errorf("%d file(s) out of sync, run `arc-orm gen`:\n  %s", len(files), strings.Join(files, "\n  "))
+}
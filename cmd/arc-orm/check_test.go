@@ -0,0 +1,64 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/xhd2015/xgo/support/assert"
+)
+
+// TestCheck tests that check reports out of sync models without writing
+func TestCheck(t *testing.T) {
+	tmpDir, file := setupTestDir(t, FullDefiniton)
+	defer os.RemoveAll(tmpDir)
+
+	err := gen([]string{"--dir=" + tmpDir})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	err = check([]string{"--dir=" + tmpDir})
+	if err != nil {
+		t.Fatalf("Expected models in sync, got: %v", err)
+	}
+
+	outOfSync := strings.Replace(FullDefiniton, "\tEmail      string\n", "", 1)
+	outOfSync = base + strings.TrimPrefix(outOfSync, "\n")
+	err = os.WriteFile(file, []byte(outOfSync), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	err = check([]string{"--dir=" + tmpDir})
+	if err == nil || !strings.Contains(err.Error(), "out of sync") {
+		t.Fatalf("Expected out of sync error, got: %v", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if diff := assert.Diff(outOfSync, string(content)); diff != "" {
+		t.Errorf("Expected file unchanged: %s", diff)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	oldCode := "a\nb\nc\nd\ne\nf\ng\nh\ni\n"
+	newCode := "a\nb\nc\nd\nE\nf\ng\nh\ni\nj\n"
+	expected := `--- x.go
++++ x.go
+@@ -2,8 +2,9 @@
+ b
+ c
+ d
+-e
++E
+ f
+ g
+ h
+ i
++j
+`
+	if diff := assert.Diff(expected, unifiedDiff("x.go", []byte(oldCode), []byte(newCode))); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/cmd/arc-orm/parse"
+	"github.com/xhd2015/less-gen/strcase"
+)
+
+// columnsFile returns the file holding column constants of a table file,
+// e.g. table.go -> table_columns.go
+func columnsFile(file string) string {
+	return strings.TrimSuffix(file, ".go") + "_columns.go"
+}
+
+// columnsChange generates the column name constants and
+// AllColumns() of the tables defined in file
+func columnsChange(file *parse.File) (*fileChange, error) {
+	var b strings.Builder
+	b.WriteString("// Code generated by arc-orm. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n", file.AST.Name.Name)
+	for _, table := range file.Tables {
+		// tables other than `Table` are prefixed by their var name
+		prefix := ""
+		if table.TablVarName != "Table" {
+			prefix = strings.TrimSuffix(table.TablVarName, "Table")
+		}
+		names := make([]string, 0, len(table.Fields))
+		fmt.Fprintf(&b, "\n// Column names of table %s\nconst (\n", table.TableName)
+		for _, f := range table.Fields {
+			name := prefix + "Col" + strcase.SnakeToCamel(f.ColumnName)
+			names = append(names, name)
+			fmt.Fprintf(&b, "\t%s = %q\n", name, f.ColumnName)
+		}
+		b.WriteString(")\n")
+		fmt.Fprintf(&b, "\n// %sAllColumns returns all column names of table %s\n", prefix, table.TableName)
+		fmt.Fprintf(&b, "func %sAllColumns() []string {\n\treturn []string{%s}\n}\n", prefix, strings.Join(names, ", "))
+	}
+
+	target := columnsFile(file.AbsFile)
+	oldCode, err := os.ReadFile(target)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	change, err := formatChange(target, oldCode, []byte(b.String()))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filepath.Base(target), err)
+	}
+	return change, nil
+}
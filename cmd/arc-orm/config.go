@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configFile is the project configuration file at module root
+const configFile = "arc-orm.yaml"
+
+// config is the generator configuration, e.g.
+//
+//	types:
+//	  Time: "*time.Time"
+//	exclude:
+//	  - example.com/app/legacy/...
+//	out: dao
+//	tags: [json, db]
+//	tag_case: snake
+//	columns: true
+//	mocks: true
+//	mapping: true
+//	repository: true
+//	models:
+//	  singular: true
+//	  suffix: PO
+//	naming:
+//	  initialisms: true
+type config struct {
+	// Types maps table field types (Int64, Int32, Float64, String, Bool, Time)
+	// to the Go types of generated model fields
+	Types map[string]string `yaml:"types"`
+	// Exclude lists package patterns skipped by the generator,
+	// either a path.Match pattern or a prefix ending with /...
+	Exclude []string `yaml:"exclude"`
+	// Out is the directory relative to module root where new
+	// table packages are written, default: the --dir directory
+	Out string `yaml:"out"`
+	// Tags lists struct tags emitted on model fields, e.g. json, db, xorm
+	Tags []string `yaml:"tags"`
+	// TagCase is the naming case of tag values:
+	// snake (default), camel or lower_camel
+	TagCase string `yaml:"tag_case"`
+	// Columns generates column name constants and AllColumns()
+	// into <file>_columns.go next to each table definition file
+	Columns bool `yaml:"columns"`
+	// Mocks generates in-memory fake ORMs keyed by id
+	// into <file>_fake.go next to each table definition file
+	Mocks bool `yaml:"mocks"`
+	// Mapping generates Columns(), Values() and ScanRow() of models
+	// into <file>_mapping.go, used by the ORM instead of reflection
+	Mapping bool `yaml:"mapping"`
+	// Repository scaffolds a repository interface and implementation
+	// into <file>_repository.go once, the file is user-owned afterwards
+	Repository bool `yaml:"repository"`
+	// Models configures the names of models created for
+	// tables without an orm.Bind call
+	Models modelNaming `yaml:"models"`
+	// Naming is the naming strategy of model fields,
+	// see orm.WithNamingStrategy
+	Naming fieldNaming `yaml:"naming"`
+
+	root string
+}
+
+// loadConfig loads the configuration from the root of the module
+// containing dir, an empty configuration is returned if absent
+func loadConfig(dir string) (*config, error) {
+	root, err := resolveModuleRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &config{root: root}
+	file := filepath.Join(root, configFile)
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	err = dec.Decode(cfg)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	for fieldType := range cfg.Types {
+		if getStructType(fieldType) == "any" {
+			return nil, fmt.Errorf("%s: unknown field type in types: %s", file, fieldType)
+		}
+	}
+	err = checkTagCase(cfg.TagCase)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return cfg, nil
+}
+
+// goType returns the Go type of a model field for the table field type
+func (c *config) goType(fieldType string) string {
+	if t := c.Types[fieldType]; t != "" {
+		return t
+	}
+	return getStructType(fieldType)
+}
+
+// excluded reports whether the package is excluded from generation
+func (c *config) excluded(pkgPath string) bool {
+	for _, pattern := range c.Exclude {
+		if strings.HasSuffix(pattern, "/...") {
+			prefix := strings.TrimSuffix(pattern, "/...")
+			if pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/") {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, pkgPath); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// outDir returns the directory to write new table packages into,
+// dir takes precedence over the configured out
+func (c *config) outDir(dir string) string {
+	if dir != "" {
+		return dir
+	}
+	if c.Out != "" {
+		return filepath.Join(c.root, c.Out)
+	}
+	return "."
+}
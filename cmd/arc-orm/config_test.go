@@ -0,0 +1,188 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xhd2015/xgo/support/assert"
+)
+
+// TestGen_Config tests type mappings and excluded packages from arc-orm.yaml
+func TestGen_Config(t *testing.T) {
+	tmpDir, file := setupTestDir(t, FullDefiniton)
+	defer os.RemoveAll(tmpDir)
+
+	err := os.WriteFile(filepath.Join(tmpDir, configFile), []byte(`types:
+  Int64: int
+  Time: "*time.Time"
+`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	err = gen([]string{"--dir=" + tmpDir})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	want := base + `
+var ORM = orm.Bind[User, UserOptional](nil, Table)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+
+type User struct {
+	Id         int
+	Name       string
+	Email      string
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+
+type UserOptional struct {
+	Id         *int
+	Name       *string
+	Email      *string
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+`
+	if diff := assert.Diff(want, string(content)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// excluded packages are left untouched
+	outOfSync := strings.Replace(want, "\tEmail      string\n", "", 1)
+	err = os.WriteFile(file, []byte(outOfSync), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(tmpDir, configFile), []byte(`exclude:
+  - testormx/...
+`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	err = gen([]string{"--dir=" + tmpDir})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	content, err = os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if diff := assert.Diff(outOfSync, string(content)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestLoadConfig_UnknownField(t *testing.T) {
+	tmpDir, _ := setupTestDir(t, FullDefiniton)
+	defer os.RemoveAll(tmpDir)
+
+	err := os.WriteFile(filepath.Join(tmpDir, configFile), []byte("typo: 1\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	_, err = loadConfig(tmpDir)
+	if err == nil {
+		t.Errorf("Expected error for unknown config field")
+	}
+}
+
+// TestGen_Tags tests struct tag generation, keeping existing tags
+func TestGen_Tags(t *testing.T) {
+	inputCode := strings.Replace(FullDefiniton, "\tName       string\n", "\tName       string `json:\"full_name\" validate:\"required\"`\n", 1)
+	tmpDir, file := setupTestDir(t, inputCode)
+	defer os.RemoveAll(tmpDir)
+
+	err := os.WriteFile(filepath.Join(tmpDir, configFile), []byte(`tags: [json, db]
+tag_case: lower_camel
+`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	err = gen([]string{"--dir=" + tmpDir})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	want := base + `
+var ORM = orm.Bind[User, UserOptional](nil, Table)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+
+type User struct {
+	Id         int64     ` + "`json:\"id\" db:\"id\"`" + `
+	Name       string    ` + "`json:\"full_name\" validate:\"required\" db:\"name\"`" + `
+	Email      string    ` + "`json:\"email\" db:\"email\"`" + `
+	CreateTime time.Time ` + "`json:\"createTime\" db:\"createTime\"`" + `
+	UpdateTime time.Time ` + "`json:\"updateTime\" db:\"updateTime\"`" + `
+}
+
+type UserOptional struct {
+	Id         *int64     ` + "`json:\"id\" db:\"id\"`" + `
+	Name       *string    ` + "`json:\"name\" db:\"name\"`" + `
+	Email      *string    ` + "`json:\"email\" db:\"email\"`" + `
+	CreateTime *time.Time ` + "`json:\"createTime\" db:\"createTime\"`" + `
+	UpdateTime *time.Time ` + "`json:\"updateTime\" db:\"updateTime\"`" + `
+}
+`
+	if diff := assert.Diff(want, string(content)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+// TestGen_Columns tests generating column name constants
+func TestGen_Columns(t *testing.T) {
+	tmpDir, file := setupTestDir(t, FullDefiniton)
+	defer os.RemoveAll(tmpDir)
+
+	err := os.WriteFile(filepath.Join(tmpDir, configFile), []byte("columns: true\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	err = gen([]string{"--dir=" + tmpDir})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	content, err := os.ReadFile(columnsFile(file))
+	if err != nil {
+		t.Fatalf("Failed to read columns file: %v", err)
+	}
+	want := `// Code generated by arc-orm. DO NOT EDIT.
+
+package testorm
+
+// Column names of table test_users
+const (
+	ColId         = "id"
+	ColName       = "name"
+	ColEmail      = "email"
+	ColCreateTime = "create_time"
+	ColUpdateTime = "update_time"
+)
+
+// AllColumns returns all column names of table test_users
+func AllColumns() []string {
+	return []string{ColId, ColName, ColEmail, ColCreateTime, ColUpdateTime}
+}
+`
+	if diff := assert.Diff(want, string(content)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// a second run has nothing to change
+	err = check([]string{"--dir=" + tmpDir})
+	if err != nil {
+		t.Errorf("Expected no change after gen, got: %v", err)
+	}
+}
@@ -0,0 +1,701 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/cmd/arc-orm/parse"
+)
+
+// fakeFile returns the file holding the fake ORMs of a table file,
+// e.g. table.go -> table_fake.go
+func fakeFile(file string) string {
+	return strings.TrimSuffix(file, ".go") + "_fake.go"
+}
+
+// fakeField is a model field of the fake ORM
+type fakeField struct {
+	Name    string
+	Column  string
+	Type    string
+	Pointer bool
+}
+
+// fakeChange generates in-memory fakes keyed by id of the ORMs
+// defined in file, tables without an id column are skipped
+func fakeChange(file *parse.File, cfg *config) (*fileChange, error) {
+	var b strings.Builder
+	var needTime bool
+	for _, table := range file.Tables {
+		fields, ok := fakeFields(table, cfg)
+		if !ok {
+			continue
+		}
+		if writeFake(&b, table, fields) {
+			needTime = true
+		}
+	}
+	if b.Len() == 0 {
+		return nil, nil
+	}
+
+	imports := []string{`"context"`, `"errors"`, `"fmt"`, `"reflect"`, `"sort"`, `"sync"`}
+	if needTime {
+		imports = append(imports, `"time"`)
+	}
+	imports = append(imports, "", `"github.com/xhd2015/arc-orm/field"`, `"github.com/xhd2015/arc-orm/orm"`)
+	code := fmt.Sprintf("// Code generated by arc-orm. DO NOT EDIT.\n\npackage %s\n\nimport (\n\t%s\n)\n%s",
+		file.AST.Name.Name, strings.Join(imports, "\n\t"), b.String())
+
+	target := fakeFile(file.AbsFile)
+	oldCode, err := os.ReadFile(target)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return formatChange(target, oldCode, []byte(code))
+}
+
+// fakeFields resolves the model fields of the table columns,
+// reporting false if the table has no id column
+func fakeFields(table *parse.TableRelation, cfg *config) ([]fakeField, bool) {
+	modelFields := make(map[string]parse.FieldInfo, len(table.Model.Fields))
+	columnFields := make(map[string]string)
+	for _, f := range table.Model.Fields {
+		modelFields[f.Name] = f
+		if column := tagColumn(f.Tags); column != "" {
+			columnFields[column] = f.Name
+		}
+	}
+
+	var hasID bool
+	fields := make([]fakeField, 0, len(table.Fields))
+	for _, tableField := range table.Fields {
+		name := columnFields[tableField.ColumnName]
+		if name == "" {
+			name = cfg.fieldName(tableField.ColumnName)
+		}
+		goType := cfg.goType(tableField.Type)
+		if f, ok := modelFields[name]; ok && f.Type != "" {
+			goType = f.Type
+			if f.Pointer {
+				goType = "*" + goType
+			}
+		}
+		if tableField.ColumnName == "id" {
+			hasID = true
+		}
+		fields = append(fields, fakeField{
+			Name:    name,
+			Column:  tableField.ColumnName,
+			Type:    strings.TrimPrefix(goType, "*"),
+			Pointer: strings.HasPrefix(goType, "*"),
+		})
+	}
+	return fields, hasID
+}
+
+// writeFake writes the fake of one table, reporting whether the time package is used
+func writeFake(b *strings.Builder, table *parse.TableRelation, fields []fakeField) bool {
+	model := table.Model.Name
+	optional := table.OptionalModel.Name
+	fake := "Fake" + model + "ORM"
+
+	var idField string
+	var idType string
+	var createTime string
+	var updateTime string
+	var autoTimes []string
+	for _, f := range fields {
+		switch f.Column {
+		case "id":
+			idField = f.Name
+			idType = f.Type
+		case "create_time", "update_time":
+			if f.Type == "time.Time" && !f.Pointer {
+				autoTimes = append(autoTimes, f.Name)
+				if f.Column == "create_time" {
+					createTime = f.Name
+				} else {
+					updateTime = f.Name
+				}
+			}
+		}
+	}
+
+	// the arguments referenced by index in the templates
+	args := []interface{}{fake, model, optional, table.TableName, idField, idType}
+	fmt.Fprintf(b, `
+// %[1]s is an in-memory fake of the %[4]s table ORM keyed by id,
+// implementing orm.Interface for tests without a database. Methods
+// evaluating SQL or filters, like QuerySQL and Sum, return an error,
+// embed the fake to override them.
+type %[1]s struct {
+	mutex  sync.Mutex
+	nextID int64
+	rows   map[int64]*%[2]s
+}
+
+var _ orm.Interface[%[2]s, %[3]s] = (*%[1]s)(nil)
+
+// New%[1]s creates an empty %[1]s
+func New%[1]s() *%[1]s {
+	return &%[1]s{rows: make(map[int64]*%[2]s)}
+}
+
+// Insert adds a copy of the model, assigning the next id if it is zero,
+// and fills the id back into the model
+func (f *%[1]s) Insert(ctx context.Context, model *%[2]s) (int64, error) {
+	if model == nil {
+		return 0, errors.New("model cannot be nil")
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.insert(model)
+}
+
+// InsertMany inserts the models one by one like Insert,
+// stopping at the first error
+func (f *%[1]s) InsertMany(ctx context.Context, models []*%[2]s) error {
+	for _, model := range models {
+		if _, err := f.Insert(ctx, model); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertManyChunked inserts the models by InsertMany in chunks,
+// Inserted reports the models of a failed chunk inserted before the error
+func (f *%[1]s) InsertManyChunked(ctx context.Context, models []*%[2]s, chunkSize int, opts orm.ChunkOptions) ([]orm.ChunkResult, error) {
+	if chunkSize <= 0 {
+		return nil, errors.New("chunk size must be positive")
+	}
+	var results []orm.ChunkResult
+	var failed int
+	var firstErr error
+	for offset := 0; offset < len(models); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(models) {
+			end = len(models)
+		}
+		result := orm.ChunkResult{Offset: offset, Count: end - offset}
+		for _, model := range models[offset:end] {
+			if _, err := f.Insert(ctx, model); err != nil {
+				result.Err = err
+				break
+			}
+			result.Inserted++
+		}
+		results = append(results, result)
+		if result.Err == nil {
+			continue
+		}
+		failed++
+		if firstErr == nil {
+			firstErr = fmt.Errorf("chunk at offset %%d: %%w", offset, result.Err)
+		}
+		if !opts.ContinueOnError {
+			break
+		}
+	}
+	if firstErr != nil {
+		return results, fmt.Errorf("failed to insert %%d of %%d chunks, %%w", failed, len(results), firstErr)
+	}
+	return results, nil
+}
+
+// InsertStream inserts the models received from the channel one by one
+// until it is closed, returning a *orm.StreamError with the model not
+// inserted if an insert fails or ctx is done
+func (f *%[1]s) InsertStream(ctx context.Context, models <-chan *%[2]s, opts orm.StreamOptions) (int, error) {
+	var inserted int
+	for {
+		select {
+		case <-ctx.Done():
+			return inserted, &orm.StreamError[%[2]s]{Err: ctx.Err()}
+		case model, ok := <-models:
+			if !ok {
+				return inserted, nil
+			}
+			if _, err := f.Insert(ctx, model); err != nil {
+				return inserted, &orm.StreamError[%[2]s]{Pending: []*%[2]s{model}, Err: err}
+			}
+			inserted++
+		}
+	}
+}
+
+// LoadData inserts the models by InsertMany
+func (f *%[1]s) LoadData(ctx context.Context, models []*%[2]s) error {
+	return f.InsertMany(ctx, models)
+}
+
+// InsertIgnore inserts the model like Insert, reporting false
+// without error if a row of the same id exists
+func (f *%[1]s) InsertIgnore(ctx context.Context, model *%[2]s) (bool, error) {
+	if model == nil {
+		return false, errors.New("model cannot be nil")
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if _, ok := f.rows[int64(model.%[5]s)]; ok {
+		return false, nil
+	}
+	if _, err := f.insert(model); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// InsertOrUpdate inserts the model like Insert, or if a row of the same
+// id exists, sets the non-nil fields of updateOnConflict on it.
+// Conflicts are detected on the id only.
+func (f *%[1]s) InsertOrUpdate(ctx context.Context, model *%[2]s, updateOnConflict *%[3]s) (int64, error) {
+	if model == nil {
+		return 0, errors.New("model cannot be nil")
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	id := int64(model.%[5]s)
+	if row, ok := f.rows[id]; ok {
+		if updateOnConflict != nil {
+			f.update(row, updateOnConflict)
+		}
+		return id, nil
+	}
+	return f.insert(model)
+}
+
+// GetOrCreate returns a copy of the first row matching the condition by
+// ascending id, inserting create if there is none
+func (f *%[1]s) GetOrCreate(ctx context.Context, condition *%[3]s, create *%[2]s) (*%[2]s, bool, error) {
+	if condition == nil {
+		return nil, false, errors.New("requires condition")
+	}
+	if create == nil {
+		return nil, false, errors.New("model cannot be nil")
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if found := f.first(condition); found != nil {
+		copied := *found
+		return &copied, false, nil
+	}
+	id, err := f.insert(create)
+	if err != nil {
+		return nil, false, err
+	}
+	copied := *f.rows[id]
+	return &copied, true, nil
+}
+
+// GetByID returns a copy of the row, the row must exist
+func (f *%[1]s) GetByID(ctx context.Context, id int64) (*%[2]s, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	row, ok := f.rows[id]
+	if !ok {
+		return nil, fmt.Errorf("%%w: %[4]s id=%%d", orm.ErrNotFound, id)
+	}
+	copied := *row
+	return &copied, nil
+}
+
+// FindByID returns a copy of the row, nil if it does not exist
+func (f *%[1]s) FindByID(ctx context.Context, id int64) (*%[2]s, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	row, ok := f.rows[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *row
+	return &copied, nil
+}
+
+// GetByKey returns a copy of the row of an integer key by GetByID
+func (f *%[1]s) GetByKey(ctx context.Context, key interface{}) (*%[2]s, error) {
+	id, err := f.key(key)
+	if err != nil {
+		return nil, err
+	}
+	return f.GetByID(ctx, id)
+}
+
+// GetBy returns a copy of the first row matching the condition by ascending id
+func (f *%[1]s) GetBy(ctx context.Context, condition *%[3]s) (*%[2]s, error) {
+	if condition == nil {
+		return nil, errors.New("requires condition")
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	found := f.first(condition)
+	if found == nil {
+		return nil, fmt.Errorf("%%w: %[4]s", orm.ErrNotFound)
+	}
+	copied := *found
+	return &copied, nil
+}
+
+// GetByIDs returns copies of the existing rows keyed by id
+func (f *%[1]s) GetByIDs(ctx context.Context, ids []int64) (map[int64]*%[2]s, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	result := make(map[int64]*%[2]s, len(ids))
+	for _, id := range ids {
+		if row, ok := f.rows[id]; ok {
+			copied := *row
+			result[id] = &copied
+		}
+	}
+	return result, nil
+}
+
+// ListByIDs returns copies of the existing rows in the order of ids
+func (f *%[1]s) ListByIDs(ctx context.Context, ids []int64) ([]*%[2]s, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	list := make([]*%[2]s, 0, len(ids))
+	for _, id := range ids {
+		if row, ok := f.rows[id]; ok {
+			copied := *row
+			list = append(list, &copied)
+		}
+	}
+	return list, nil
+}
+
+// FindByExample returns copies of the rows equal to the example on all
+// its non-zero fields and the include fields, by ascending id
+func (f *%[1]s) FindByExample(ctx context.Context, example *%[2]s, include ...field.Field) ([]*%[2]s, error) {
+	if example == nil {
+		return nil, errors.New("requires example")
+	}
+	included := make(map[string]bool, len(include))
+	for _, fd := range include {
+		included[fd.Name()] = true
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	var list []*%[2]s
+	for _, id := range f.sortedIDs() {
+		row := f.rows[id]
+		if f.matchExample(row, example, included) {
+			copied := *row
+			list = append(list, &copied)
+		}
+	}
+	return list, nil
+}
+
+// QuerySQL is not supported by the fake
+func (f *%[1]s) QuerySQL(ctx context.Context, sql string, args []interface{}) ([]*%[2]s, error) {
+	return nil, f.unsupported("QuerySQL")
+}
+
+// QueryByFilter is not supported by the fake
+func (f *%[1]s) QueryByFilter(ctx context.Context, filter interface{}) ([]*%[2]s, error) {
+	return nil, f.unsupported("QueryByFilter")
+}
+
+// GetByFilter is not supported by the fake
+func (f *%[1]s) GetByFilter(ctx context.Context, filter interface{}) (*%[2]s, error) {
+	return nil, f.unsupported("GetByFilter")
+}
+
+// UpdateByID sets the non-nil fields of data on the row
+func (f *%[1]s) UpdateByID(ctx context.Context, id int64, data *%[3]s) error {
+	if data == nil {
+		return errors.New("requires data, got nil")
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if row, ok := f.rows[id]; ok {
+		f.update(row, data)
+	}
+	return nil
+}
+
+// UpdateByKey sets the non-nil fields of data on the row of an integer key by UpdateByID
+func (f *%[1]s) UpdateByKey(ctx context.Context, key interface{}, data *%[3]s) error {
+	id, err := f.key(key)
+	if err != nil {
+		return err
+	}
+	return f.UpdateByID(ctx, id, data)
+}
+`, args...)
+
+	fmt.Fprintf(b, `
+// UpdateModelByID replaces the row by a copy of the model, keeping its id
+func (f *%[1]s) UpdateModelByID(ctx context.Context, id int64, model *%[2]s, opts ...orm.UpdateOption) error {
+	if model == nil {
+		return errors.New("requires model, got nil")
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	row, ok := f.rows[id]
+	if !ok {
+		return nil
+	}
+	updated := *model
+	updated.%[5]s = row.%[5]s
+`, args...)
+	if createTime != "" {
+		fmt.Fprintf(b, "\tupdated.%s = row.%s\n", createTime, createTime)
+	}
+	if updateTime != "" {
+		fmt.Fprintf(b, "\tif updated.%s.IsZero() {\n\t\tupdated.%s = time.Now()\n\t}\n", updateTime, updateTime)
+	}
+	fmt.Fprintf(b, `	*row = updated
+	return nil
+}
+
+// UpdateBy sets the non-nil fields of data on all rows matching the condition
+func (f *%[1]s) UpdateBy(ctx context.Context, condition *%[3]s, data *%[3]s) error {
+	if condition == nil {
+		return errors.New("requires condition")
+	}
+	if data == nil {
+		return errors.New("requires data, got nil")
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for _, row := range f.rows {
+		if f.match(row, condition) {
+			f.update(row, data)
+		}
+	}
+	return nil
+}
+
+// UpdateManyByID sets the non-nil fields of each data on the row of its id
+func (f *%[1]s) UpdateManyByID(ctx context.Context, updates map[int64]*%[3]s) error {
+	for id, data := range updates {
+		if data == nil {
+			return fmt.Errorf("requires data of id %%d, got nil", id)
+		}
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for id, data := range updates {
+		if row, ok := f.rows[id]; ok {
+			f.update(row, data)
+		}
+	}
+	return nil
+}
+
+// UpdateByFilter is not supported by the fake
+func (f *%[1]s) UpdateByFilter(ctx context.Context, filter interface{}, data *%[3]s) error {
+	return f.unsupported("UpdateByFilter")
+}
+
+// DeleteByID deletes the row, the row must exist
+func (f *%[1]s) DeleteByID(ctx context.Context, id int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if _, ok := f.rows[id]; !ok {
+		return fmt.Errorf("%%w: %[4]s id=%%d", orm.ErrNoRowsAffected, id)
+	}
+	delete(f.rows, id)
+	return nil
+}
+
+// DeleteByKey deletes the row of an integer key by DeleteByID
+func (f *%[1]s) DeleteByKey(ctx context.Context, key interface{}) error {
+	id, err := f.key(key)
+	if err != nil {
+		return err
+	}
+	return f.DeleteByID(ctx, id)
+}
+
+// DeleteBy deletes all rows matching the condition
+func (f *%[1]s) DeleteBy(ctx context.Context, condition *%[3]s) error {
+	if condition == nil {
+		return errors.New("requires condition")
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for id, row := range f.rows {
+		if f.match(row, condition) {
+			delete(f.rows, id)
+		}
+	}
+	return nil
+}
+
+// DeleteWhere is not supported by the fake
+func (f *%[1]s) DeleteWhere(ctx context.Context, conditions ...field.Expr) error {
+	return f.unsupported("DeleteWhere")
+}
+
+// DeleteByIDs deletes the existing rows, returning the number deleted
+func (f *%[1]s) DeleteByIDs(ctx context.Context, ids []int64) (int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	var deleted int64
+	for _, id := range ids {
+		if _, ok := f.rows[id]; ok {
+			delete(f.rows, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// DeleteByFilter is not supported by the fake
+func (f *%[1]s) DeleteByFilter(ctx context.Context, filter interface{}) error {
+	return f.unsupported("DeleteByFilter")
+}
+
+// Sum is not supported by the fake
+func (f *%[1]s) Sum(ctx context.Context, fd field.Field, conditions ...field.Expr) (float64, error) {
+	return 0, f.unsupported("Sum")
+}
+
+// Avg is not supported by the fake
+func (f *%[1]s) Avg(ctx context.Context, fd field.Field, conditions ...field.Expr) (float64, error) {
+	return 0, f.unsupported("Avg")
+}
+
+// Min is not supported by the fake
+func (f *%[1]s) Min(ctx context.Context, fd field.Field, conditions ...field.Expr) (float64, error) {
+	return 0, f.unsupported("Min")
+}
+
+// Max is not supported by the fake
+func (f *%[1]s) Max(ctx context.Context, fd field.Field, conditions ...field.Expr) (float64, error) {
+	return 0, f.unsupported("Max")
+}
+
+func (f *%[1]s) unsupported(method string) error {
+	return fmt.Errorf("%%s is not supported by %[1]s", method)
+}
+
+// key converts an integer primary key to the id keying the rows
+func (f *%[1]s) key(key interface{}) (int64, error) {
+	v := reflect.ValueOf(key)
+	switch {
+	case v.CanInt():
+		return v.Int(), nil
+	case v.CanUint():
+		return int64(v.Uint()), nil
+	}
+	return 0, fmt.Errorf("%[1]s requires an integer key, got %%T", key)
+}
+
+// insert adds a copy of the model like Insert, the mutex must be held
+func (f *%[1]s) insert(model *%[2]s) (int64, error) {
+	row := *model
+	if row.%[5]s == 0 {
+		f.nextID++
+		row.%[5]s = %[6]s(f.nextID)
+	} else if int64(row.%[5]s) > f.nextID {
+		f.nextID = int64(row.%[5]s)
+	}
+	id := int64(row.%[5]s)
+	if _, ok := f.rows[id]; ok {
+		return 0, errors.New("duplicate id")
+	}
+	model.%[5]s = row.%[5]s
+`, args...)
+	if len(autoTimes) > 0 {
+		b.WriteString("\tnow := time.Now()\n")
+		for _, name := range autoTimes {
+			fmt.Fprintf(b, "\tif row.%s.IsZero() {\n\t\trow.%s = now\n\t}\n", name, name)
+		}
+	}
+	fmt.Fprintf(b, `	f.rows[id] = &row
+	return id, nil
+}
+
+// sortedIDs returns the ids of the rows in ascending order
+func (f *%[1]s) sortedIDs() []int64 {
+	ids := make([]int64, 0, len(f.rows))
+	for id := range f.rows {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// first returns the first row matching the condition by ascending id
+func (f *%[1]s) first(condition *%[3]s) *%[2]s {
+	var found *%[2]s
+	for _, row := range f.rows {
+		if f.match(row, condition) && (found == nil || row.%[5]s < found.%[5]s) {
+			found = row
+		}
+	}
+	return found
+}
+
+func (f *%[1]s) match(row *%[2]s, condition *%[3]s) bool {
+`, args...)
+	for _, f := range fields {
+		fmt.Fprintf(b, "\tif condition.%s != nil && %s {\n\t\treturn false\n\t}\n", f.Name, fakeNotEqual(f, "condition."+f.Name, "row."+f.Name))
+	}
+	fmt.Fprintf(b, "\treturn true\n}\n\nfunc (f *%s) matchExample(row *%s, example *%s, included map[string]bool) bool {\n", fake, model, model)
+	for _, f := range fields {
+		fmt.Fprintf(b, "\tif (%s || included[%q]) && %s {\n\t\treturn false\n\t}\n", fakeNonZero(f, "example."+f.Name), f.Column, fakeExampleNotEqual(f, "example."+f.Name, "row."+f.Name))
+	}
+	fmt.Fprintf(b, "\treturn true\n}\n\nfunc (f *%s) update(row *%s, data *%s) {\n", fake, model, optional)
+	for _, f := range fields {
+		if f.Column == "id" {
+			// the id keys the row
+			continue
+		}
+		if f.Pointer {
+			fmt.Fprintf(b, "\tif data.%s != nil {\n\t\tvalue := *data.%s\n\t\trow.%s = &value\n\t}\n", f.Name, f.Name, f.Name)
+		} else {
+			fmt.Fprintf(b, "\tif data.%s != nil {\n\t\trow.%s = *data.%s\n\t}\n", f.Name, f.Name, f.Name)
+		}
+	}
+	if updateTime != "" {
+		fmt.Fprintf(b, "\tif data.%s == nil {\n\t\trow.%s = time.Now()\n\t}\n", updateTime, updateTime)
+	}
+	b.WriteString("}\n")
+	return len(autoTimes) > 0
+}
+
+// fakeNotEqual formats the expression comparing a non-nil
+// condition field to the row field
+func fakeNotEqual(f fakeField, cond string, row string) string {
+	if f.Pointer {
+		if f.Type == "time.Time" {
+			return fmt.Sprintf("(%s == nil || !%s.Equal(*%s))", row, cond, row)
+		}
+		return fmt.Sprintf("(%s == nil || *%s != *%s)", row, cond, row)
+	}
+	if f.Type == "time.Time" {
+		return fmt.Sprintf("!%s.Equal(%s)", cond, row)
+	}
+	return fmt.Sprintf("*%s != %s", cond, row)
+}
+
+// fakeNonZero formats the expression reporting whether
+// an example field is set, like FindByExample
+func fakeNonZero(f fakeField, example string) string {
+	if f.Pointer {
+		return example + " != nil"
+	}
+	return fmt.Sprintf("!reflect.ValueOf(%s).IsZero()", example)
+}
+
+// fakeExampleNotEqual formats the expression comparing
+// an example field to the row field, nil matching nil
+func fakeExampleNotEqual(f fakeField, example string, row string) string {
+	if f.Pointer {
+		if f.Type == "time.Time" {
+			return fmt.Sprintf("((%s == nil) != (%s == nil) || (%s != nil && !%s.Equal(*%s)))", example, row, example, example, row)
+		}
+		return fmt.Sprintf("((%s == nil) != (%s == nil) || (%s != nil && *%s != *%s))", example, row, example, example, row)
+	}
+	if f.Type == "time.Time" {
+		return fmt.Sprintf("!%s.Equal(%s)", example, row)
+	}
+	return fmt.Sprintf("%s != %s", example, row)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xhd2015/xgo/support/cmd"
+)
+
+const fakeUsageTest = `package testorm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/orm"
+)
+
+func TestFakeUserORM(t *testing.T) {
+	ctx := context.Background()
+	fake := NewFakeUserORM()
+	alice := &User{Name: "alice", Email: "a@x"}
+	id, err := fake.Insert(ctx, alice)
+	if err != nil || id != 1 || alice.Id != 1 {
+		t.Fatalf("insert: %d %v", id, err)
+	}
+	_, err = fake.Insert(ctx, &User{Name: "bob", Email: "b@x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := fake.GetByID(ctx, id)
+	if err != nil || user.Name != "alice" || user.CreateTime.IsZero() {
+		t.Fatalf("get: %+v %v", user, err)
+	}
+
+	name := "carol"
+	err = fake.UpdateByID(ctx, id, &UserOptional{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	email := "b@x"
+	user, err = fake.GetBy(ctx, &UserOptional{Email: &email})
+	if err != nil || user.Name != "bob" {
+		t.Fatalf("get by: %+v %v", user, err)
+	}
+	err = fake.DeleteBy(ctx, &UserOptional{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = fake.GetByID(ctx, id)
+	if !errors.Is(err, orm.ErrNotFound) {
+		t.Fatalf("expected deleted: %v", err)
+	}
+	user, err = fake.FindByID(ctx, id)
+	if err != nil || user != nil {
+		t.Fatalf("find deleted: %+v %v", user, err)
+	}
+	err = fake.DeleteByID(ctx, id)
+	if !errors.Is(err, orm.ErrNoRowsAffected) {
+		t.Fatalf("expected no rows affected: %v", err)
+	}
+}
+
+func TestFakeUserORM_Interface(t *testing.T) {
+	ctx := context.Background()
+	var users orm.Interface[User, UserOptional] = NewFakeUserORM()
+	err := users.InsertMany(ctx, []*User{{Name: "alice", Email: "a@x"}, {Name: "bob", Email: "b@x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	inserted, err := users.InsertIgnore(ctx, &User{Id: 1, Name: "dup"})
+	if err != nil || inserted {
+		t.Fatalf("insert ignore: %v %v", inserted, err)
+	}
+	name := "carol"
+	id, err := users.InsertOrUpdate(ctx, &User{Id: 2}, &UserOptional{Name: &name})
+	if err != nil || id != 2 {
+		t.Fatalf("insert or update: %d %v", id, err)
+	}
+	email := "c@x"
+	created, ok, err := users.GetOrCreate(ctx, &UserOptional{Email: &email}, &User{Name: "carol", Email: email})
+	if err != nil || !ok || created.Id != 3 {
+		t.Fatalf("get or create: %+v %v %v", created, ok, err)
+	}
+	list, err := users.ListByIDs(ctx, []int64{3, 9, 2})
+	if err != nil || len(list) != 2 || list[0].Id != 3 || list[1].Name != "carol" {
+		t.Fatalf("list by ids: %+v %v", list, err)
+	}
+	list, err = users.FindByExample(ctx, &User{Name: "carol"})
+	if err != nil || len(list) != 2 || list[0].Id != 2 {
+		t.Fatalf("find by example: %+v %v", list, err)
+	}
+	user, err := users.GetByKey(ctx, int32(1))
+	if err != nil || user.Name != "alice" {
+		t.Fatalf("get by key: %+v %v", user, err)
+	}
+	user.Name = "dave"
+	user.CreateTime = user.CreateTime.AddDate(1, 0, 0)
+	err = users.UpdateModelByID(ctx, 1, user)
+	if err != nil {
+		t.Fatal(err)
+	}
+	updated, err := users.GetByID(ctx, 1)
+	if err != nil || updated.Name != "dave" || updated.CreateTime.Equal(user.CreateTime) {
+		t.Fatalf("update model: %+v %v", updated, err)
+	}
+	n, err := users.DeleteByIDs(ctx, []int64{1, 2, 9})
+	if err != nil || n != 2 {
+		t.Fatalf("delete by ids: %d %v", n, err)
+	}
+	_, err = users.QuerySQL(ctx, "SELECT 1", nil)
+	if err == nil {
+		t.Fatalf("expected QuerySQL not supported")
+	}
+}
+`
+
+// TestGen_Mocks tests that the generated fake compiles and behaves like the ORM
+func TestGen_Mocks(t *testing.T) {
+	tmpDir, file := setupTestDir(t, FullDefiniton)
+	defer os.RemoveAll(tmpDir)
+
+	err := gen([]string{"--dir=" + tmpDir, "--mocks"})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	if _, err := os.Stat(fakeFile(file)); err != nil {
+		t.Fatalf("Expected fake file: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(tmpDir, "fake_usage_test.go"), []byte(fakeUsageTest), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write test: %v", err)
+	}
+	output, err := cmd.Dir(tmpDir).Output("go", "test", "./...")
+	if err != nil {
+		t.Fatalf("Failed to run generated fake tests: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/ddl"
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/table"
+	"github.com/xhd2015/less-gen/strcase"
+	"github.com/xhd2015/xgo/support/edit/goedit"
+)
+
+// genFromSQL computes one table package per CREATE TABLE statement
+// found in the schema file, at outDir/<table_name>/table.go.
+// Models and ORM bindings are then filled by the regular gen.
+func genFromSQL(schemaFile string, outDir string) ([]*fileChange, error) {
+	schema, err := os.ReadFile(schemaFile)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := ddl.ParseCreateTables(string(schema))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", schemaFile, err)
+	}
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no CREATE TABLE statement found in %s", schemaFile)
+	}
+	var changes []*fileChange
+	for _, t := range tables {
+		pkgName := strings.ToLower(t.Name())
+		file := filepath.Join(outDir, pkgName, "table.go")
+		change, err := tableFileChange(file, pkgName, t)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", t.Name(), err)
+		}
+		if change != nil {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// tableFileChange creates the table definition file, or replaces
+// the field definitions of an existing one while keeping the rest.
+// It returns nil if the file is unchanged.
+func tableFileChange(file string, pkgName string, t table.Table) (*fileChange, error) {
+	code, err := os.ReadFile(file)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+		content := fmt.Sprintf(`package %s
+
+import (
+	"github.com/xhd2015/arc-orm/orm"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// Table is the %s table
+var Table = table.New(%q)
+
+%s
+`, pkgName, t.Name(), t.Name(), formatFieldDefs("Table", t, nil))
+		return formatChange(file, nil, []byte(content))
+	}
+
+	fset := token.NewFileSet()
+	astFile, err := parser.ParseFile(fset, file, code, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	tableVar := findTableVarName(astFile)
+	if tableVar == "" {
+		return nil, fmt.Errorf("%s: table.New definition not found", file)
+	}
+
+	// collect existing field declarations and keep their
+	// variable names and modifiers
+	existing := make(map[string]*fieldDef)
+	var fieldDecls []*ast.GenDecl
+	for _, decl := range astFile.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR || !isFieldDecl(fset, code, genDecl, tableVar, existing) {
+			continue
+		}
+		fieldDecls = append(fieldDecls, genDecl)
+	}
+
+	edit := goedit.NewWithBytes(fset, code)
+	newDefs := formatFieldDefs(tableVar, t, existing)
+	if len(fieldDecls) == 0 {
+		edit.Insert(astFile.End(), "\n"+newDefs+"\n")
+	} else {
+		for i, decl := range fieldDecls {
+			start := decl.Pos()
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+			if i == 0 {
+				edit.Replace(start, decl.End(), newDefs)
+			} else {
+				edit.Delete(start, decl.End())
+			}
+		}
+	}
+	return formatChange(file, code, edit.Buffer().Bytes())
+}
+
+// formatChange formats the new code, imports are kept as is
+// because the orm import is used only after models are generated
+func formatChange(file string, oldCode []byte, newCode []byte) (*fileChange, error) {
+	formatted, err := format.Source(newCode)
+	if err != nil {
+		return nil, fmt.Errorf("format %s: %w", file, err)
+	}
+	if oldCode != nil && string(formatted) == string(oldCode) {
+		return nil, nil
+	}
+	return &fileChange{
+		File:    file,
+		OldCode: oldCode,
+		NewCode: formatted,
+	}, nil
+}
+
+// fieldTypeName returns the table method name creating the field, e.g. Int64
+func fieldTypeName(f field.Field) string {
+	switch f.(type) {
+	case field.Int64Field:
+		return "Int64"
+	case field.Int32Field:
+		return "Int32"
+	case field.Float64Field:
+		return "Float64"
+	case field.StringField:
+		return "String"
+	case field.UuidField:
+		return "UUID"
+	case field.TimeField:
+		return "Time"
+	case field.BoolField:
+		return "Bool"
+	}
+	return ""
+}
+
+// fieldDef is an existing field definition like
+// Name = Table.String("name").MaxLen(64)
+type fieldDef struct {
+	name      string // variable name, e.g. Name
+	typeName  string // table method name, e.g. String
+	modifiers string // chained calls, e.g. .MaxLen(64)
+}
+
+// formatFieldDefs formats the field definition block of a table,
+// reusing existing variable names by column when available.
+// Modifiers are kept if the field type is unchanged.
+func formatFieldDefs(tableVar string, t table.Table, existing map[string]*fieldDef) string {
+	var b strings.Builder
+	b.WriteString("// Field definitions\nvar (\n")
+	for _, f := range t.Fields() {
+		typeName := fieldTypeName(f)
+		name := strcase.SnakeToCamel(f.Name())
+		var modifiers string
+		if def := existing[f.Name()]; def != nil {
+			name = def.name
+			if def.typeName == typeName {
+				modifiers = def.modifiers
+			}
+		}
+		fmt.Fprintf(&b, "\t%s = %s.%s(%q)%s\n", name, tableVar, typeName, f.Name(), modifiers)
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// findTableVarName finds the variable defined by table.New(...)
+func findTableVarName(file *ast.File) string {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, value := range valueSpec.Values {
+				call, ok := value.(*ast.CallExpr)
+				if !ok || i >= len(valueSpec.Names) {
+					continue
+				}
+				sel, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok || sel.Sel.Name != "New" {
+					continue
+				}
+				if x, ok := sel.X.(*ast.Ident); ok && x.Name == "table" {
+					return valueSpec.Names[i].Name
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// isFieldDecl reports whether all values of the var declaration
+// are field definitions like Table.Int64("id"), optionally followed
+// by modifiers like .NotNull(), recording them by column into existing
+func isFieldDecl(fset *token.FileSet, code []byte, genDecl *ast.GenDecl, tableVar string, existing map[string]*fieldDef) bool {
+	defs := make(map[string]*fieldDef)
+	for _, spec := range genDecl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok || len(valueSpec.Values) != len(valueSpec.Names) {
+			return false
+		}
+		for i, value := range valueSpec.Values {
+			call := fieldDefCall(value, tableVar)
+			if call == nil || len(call.Args) == 0 {
+				return false
+			}
+			lit, ok := call.Args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return false
+			}
+			column, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return false
+			}
+			defs[column] = &fieldDef{
+				name:      valueSpec.Names[i].Name,
+				typeName:  call.Fun.(*ast.SelectorExpr).Sel.Name,
+				modifiers: string(code[fset.Position(call.End()).Offset:fset.Position(value.End()).Offset]),
+			}
+		}
+	}
+	if len(defs) == 0 {
+		return false
+	}
+	for column, def := range defs {
+		existing[column] = def
+	}
+	return true
+}
+
+// fieldDefCall walks the chained calls of expr down to
+// the tableVar.X(...) call at its root, or returns nil
+func fieldDefCall(expr ast.Expr, tableVar string) *ast.CallExpr {
+	for {
+		call, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return nil
+		}
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+		if x, ok := sel.X.(*ast.Ident); ok {
+			if x.Name != tableVar {
+				return nil
+			}
+			return call
+		}
+		expr = sel.X
+	}
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xhd2015/xgo/support/assert"
+)
+
+const usersSchema = `
+-- users of the app
+CREATE TABLE IF NOT EXISTS ` + "`users`" + ` (
+  ` + "`id`" + ` BIGINT NOT NULL AUTO_INCREMENT,
+  ` + "`name`" + ` VARCHAR(255) NOT NULL DEFAULT '',
+  ` + "`active`" + ` TINYINT(1) NOT NULL DEFAULT 0,
+  ` + "`create_time`" + ` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (` + "`id`" + `),
+  KEY ` + "`idx_name`" + ` (` + "`name`" + `)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='users (all)';
+`
+
+// TestGen_FromSQL tests creating and updating table packages from a schema file
+func TestGen_FromSQL(t *testing.T) {
+	tmpDir, _ := setupTestDir(t, FullDefiniton)
+	defer os.RemoveAll(tmpDir)
+
+	schemaFile := filepath.Join(tmpDir, "schema.sql")
+	err := os.WriteFile(schemaFile, []byte(usersSchema), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+
+	err = gen([]string{"--dir=" + tmpDir, "--from-sql=" + schemaFile})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+
+	tableFile := filepath.Join(tmpDir, "users", "table.go")
+	content, err := os.ReadFile(tableFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	expectCode := `package users
+
+import (
+	"time"
+
+	"github.com/xhd2015/arc-orm/orm"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// Table is the users table
+var Table = table.New("users")
+
+// Field definitions
+var (
+	Id         = Table.Int64("id")
+	Name       = Table.String("name")
+	Active     = Table.Bool("active")
+	CreateTime = Table.Time("create_time")
+)
+var ORM = orm.Bind[Users, UsersOptional](nil, Table)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+type Users struct {
+	Id         int64
+	Name       string
+	Active     bool
+	CreateTime time.Time
+}
+type UsersOptional struct {
+	Id         *int64
+	Name       *string
+	Active     *bool
+	CreateTime *time.Time
+}
+`
+	if diff := assert.Diff(expectCode, string(content)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// rename a field variable, then add a column to the schema
+	renamed := []byte(strings.Replace(expectCode, "Id         = Table.Int64", "ID         = Table.Int64", 1))
+	err = os.WriteFile(tableFile, renamed, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write table file: %v", err)
+	}
+	updatedSchema := strings.Replace(usersSchema, "  `active`", "  `score` DOUBLE NOT NULL DEFAULT 0,\n  `active`", 1)
+	err = os.WriteFile(schemaFile, []byte(updatedSchema), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+
+	err = gen([]string{"--dir=" + tmpDir, "--from-sql=" + schemaFile})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	content, err = os.ReadFile(tableFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	expectUpdated := `package users
+
+import (
+	"time"
+
+	"github.com/xhd2015/arc-orm/orm"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// Table is the users table
+var Table = table.New("users")
+
+// Field definitions
+var (
+	ID         = Table.Int64("id")
+	Name       = Table.String("name")
+	Score      = Table.Float64("score")
+	Active     = Table.Bool("active")
+	CreateTime = Table.Time("create_time")
+)
+var ORM = orm.Bind[Users, UsersOptional](nil, Table)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+type Users struct {
+	Id         int64
+	Name       string
+	Score      float64
+	Active     bool
+	CreateTime time.Time
+}
+type UsersOptional struct {
+	Id         *int64
+	Name       *string
+	Score      *float64
+	Active     *bool
+	CreateTime *time.Time
+}
+`
+	if diff := assert.Diff(expectUpdated, string(content)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+// TestGen_FromSQL_Modifiers tests that regenerating keeps the
+// modifiers of existing fields unless their type changed
+func TestGen_FromSQL_Modifiers(t *testing.T) {
+	tmpDir, _ := setupTestDir(t, FullDefiniton)
+	defer os.RemoveAll(tmpDir)
+
+	schemaFile := filepath.Join(tmpDir, "schema.sql")
+	err := os.WriteFile(schemaFile, []byte(usersSchema), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+	err = gen([]string{"--dir=" + tmpDir, "--from-sql=" + schemaFile})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+
+	tableFile := filepath.Join(tmpDir, "users", "table.go")
+	content, err := os.ReadFile(tableFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	modified := strings.NewReplacer(
+		`Table.String("name")`, `Table.String("name").MaxLen(64).NotNull()`,
+		`Table.Bool("active")`, `Table.Bool("active").Default(true)`,
+	).Replace(string(content))
+	err = os.WriteFile(tableFile, []byte(modified), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write table file: %v", err)
+	}
+
+	// add a column and change the type of active
+	updatedSchema := strings.NewReplacer(
+		"  `active` TINYINT(1)", "  `score` DOUBLE NOT NULL DEFAULT 0,\n  `active` BIGINT",
+	).Replace(usersSchema)
+	err = os.WriteFile(schemaFile, []byte(updatedSchema), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+	err = gen([]string{"--dir=" + tmpDir, "--from-sql=" + schemaFile})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	content, err = os.ReadFile(tableFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	expectDefs := `// Field definitions
+var (
+	Id         = Table.Int64("id")
+	Name       = Table.String("name").MaxLen(64).NotNull()
+	Score      = Table.Float64("score")
+	Active     = Table.Int64("active")
+	CreateTime = Table.Time("create_time")
+)
+`
+	if !strings.Contains(string(content), expectDefs) {
+		t.Fatalf("expect field definitions:\n%s\nactual:\n%s", expectDefs, content)
+	}
+	if n := strings.Count(string(content), "// Field definitions"); n != 1 {
+		t.Errorf("expect 1 field definition block, actual: %d", n)
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+
+	"github.com/xhd2015/arc-orm/cmd/arc-orm/parse"
+	"github.com/xhd2015/arc-orm/orm"
+	"github.com/xhd2015/less-gen/flags"
+)
+
+const lintHelp = `
+Usage: arc-orm lint [options] [packages...]
+
+Check models and tables against the naming rules enforced
+by orm.Bind, without running them:
+  - model fields must be strict CamelCase (Id, not ID),
+    unless the column is declared by an orm:"column:..." tag,
+    or follow the naming strategy configured in arc-orm.yaml
+  - tables must not declare a 'count' column, and a model
+    Count field must be int64
+  - create_time and update_time columns must be Time fields,
+    CreateTime and UpdateTime must be time.Time, or *time.Time
+    in optional models
+
+Findings are printed as file:line:column: message, and the
+command exits non-zero if there are any.
+
+Options:
+  --dir DIR   directory to load packages from
+  -h, --help  show help
+`
+
+// lintFinding is a naming rule violation found by lint
+type lintFinding struct {
+	Position token.Position
+	Message  string
+}
+
+func (f lintFinding) String() string {
+	return fmt.Sprintf("%s: %s", f.Position, f.Message)
+}
+
+func lint(args []string) error {
+	var dir string
+	remainArgs, err := flags.String("--dir", &dir).
+		Help("-h,--help", lintHelp).
+		Parse(args)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
+	findings, err := lintModels(dir, remainArgs, cfg)
+	if err != nil {
+		return err
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+	for _, finding := range findings {
+		fmt.Println(finding)
+	}
+	return fmt.Errorf("%d naming violation(s) found", len(findings))
+}
+
+// lintModels checks the tables and models of the loaded packages,
+// sorted by position
+func lintModels(dir string, args []string, cfg *config) ([]lintFinding, error) {
+	loadDir, loadArgs, err := resolveLoadArgs(dir, args)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	pkgs, err := parse.ScanRelations(fset, loadDir, loadArgs, cfg.modelNames)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []lintFinding
+	// a table bound in several files is reported once
+	seen := make(map[string]bool)
+	report := func(node ast.Node, format string, args ...interface{}) {
+		finding := lintFinding{
+			Position: fset.Position(node.Pos()),
+			Message:  fmt.Sprintf(format, args...),
+		}
+		if key := finding.String(); !seen[key] {
+			seen[key] = true
+			findings = append(findings, finding)
+		}
+	}
+	for _, pkg := range pkgs {
+		if cfg.excluded(pkg.PkgPath) {
+			continue
+		}
+		for _, file := range pkg.Files {
+			for _, table := range file.Tables {
+				lintTable(table, cfg.strategy(), report)
+			}
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i].Position, findings[j].Position
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return findings, nil
+}
+
+// lintTable applies the rules of orm's model validation to a table and its models
+func lintTable(table *parse.TableRelation, naming orm.NamingStrategy, report func(node ast.Node, format string, args ...interface{})) {
+	for _, f := range table.Fields {
+		if f.Node == nil {
+			continue
+		}
+		switch f.ColumnName {
+		case "count":
+			report(f.Node, "table %s must not declare a 'count' column, it is reserved for query operations", table.TableName)
+		case "create_time", "update_time":
+			if f.Type != "Time" {
+				report(f.Node, "column %s of table %s must be declared by Time, got %s", f.ColumnName, table.TableName, f.Type)
+			}
+		}
+	}
+
+	for _, f := range table.Model.Fields {
+		if f.Node == nil || !ast.IsExported(f.Name) || tagIgnored(f.Tags) {
+			continue
+		}
+		if expected := naming.StrictFieldName(f.Name); tagColumn(f.Tags) == "" && expected != f.Name {
+			if naming.Initialisms {
+				report(f.Node, "field %s.%s must spell initialisms uppercase, use '%s' instead", table.Model.Name, f.Name, expected)
+			} else {
+				report(f.Node, "field %s.%s has consecutive uppercase letters, use '%s' instead", table.Model.Name, f.Name, expected)
+			}
+		}
+		switch f.Name {
+		case "Count":
+			if f.Pointer || f.Type != "int64" {
+				report(f.Node, "field %s.Count must be of type int64, got %s", table.Model.Name, lintTypeString(f))
+			}
+		case "CreateTime", "UpdateTime":
+			if f.Pointer || f.Type != "time.Time" {
+				report(f.Node, "field %s.%s must be of type time.Time, got %s", table.Model.Name, f.Name, lintTypeString(f))
+			}
+		}
+	}
+
+	for _, f := range table.OptionalModel.Fields {
+		if f.Node == nil || tagIgnored(f.Tags) {
+			continue
+		}
+		switch f.Name {
+		case "CreateTime", "UpdateTime":
+			if !f.Pointer || f.Type != "time.Time" {
+				report(f.Node, "field %s.%s must be of type *time.Time, got %s", table.OptionalModel.Name, f.Name, lintTypeString(f))
+			}
+		}
+	}
+}
+
+// lintTypeString formats the type of a field as declared
+func lintTypeString(f parse.FieldInfo) string {
+	typ := f.Type
+	if typ == "" {
+		typ = "?"
+	}
+	if f.Pointer {
+		return "*" + typ
+	}
+	return typ
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLint tests that lint reports naming violations with their positions
+func TestLint(t *testing.T) {
+	tmpDir, _ := setupTestDir(t, FullDefiniton)
+	defer os.RemoveAll(tmpDir)
+
+	findings, err := lintModels(tmpDir, nil, &config{})
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Fatalf("Expected no findings, got %v", findings)
+	}
+
+	code := `package badorm
+
+import (
+	"time"
+
+	"github.com/xhd2015/arc-orm/orm"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+var Table = table.New("bad_users")
+
+var (
+	ID         = Table.Int64("id")
+	UserID     = Table.Int64("user_id")
+	Count      = Table.Int64("count")
+	CreateTime = Table.String("create_time")
+)
+
+var ORM = orm.Bind[BadUser, BadUserOptional](nil, Table)
+
+type BadUser struct {
+	ID         int64
+	UserID     int64 ` + "`orm:\"column:user_id\"`" + `
+	Count      int
+	CreateTime *time.Time
+	HTMLCache  string ` + "`orm:\"-\"`" + `
+}
+
+type BadUserOptional struct {
+	ID         *int64
+	UserID     *int64
+	Count      *int
+	CreateTime time.Time
+}
+`
+	err = os.MkdirAll(filepath.Join(tmpDir, "badorm"), 0755)
+	if err != nil {
+		t.Fatalf("Failed to create dir: %v", err)
+	}
+	file := filepath.Join(tmpDir, "badorm", "bad.go")
+	err = os.WriteFile(file, []byte(code), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	findings, err = lintModels(tmpDir, nil, &config{})
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	var got []string
+	for _, finding := range findings {
+		if finding.Position.Filename != file {
+			t.Errorf("Expected finding in %s, got %s", file, finding)
+		}
+		got = append(got, strings.TrimPrefix(finding.String(), file+":"))
+	}
+	expected := []string{
+		"15:15: table bad_users must not declare a 'count' column, it is reserved for query operations",
+		"16:15: column create_time of table bad_users must be declared by Time, got String",
+		"22:2: field BadUser.ID has consecutive uppercase letters, use 'Id' instead",
+		"24:2: field BadUser.Count must be of type int64, got int",
+		"25:2: field BadUser.CreateTime must be of type time.Time, got *time.Time",
+		"33:2: field BadUserOptional.CreateTime must be of type *time.Time, got time.Time",
+	}
+	if strings.Join(got, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected findings:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(got, "\n"))
+	}
+
+	err = lint([]string{"--dir=" + tmpDir})
+	if err == nil || !strings.Contains(err.Error(), "6 naming violation(s) found") {
+		t.Errorf("Expected lint to fail with 6 violations, got: %v", err)
+	}
+}
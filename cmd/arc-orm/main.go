@@ -9,9 +9,9 @@ import (
 	"strings"
 
 	"github.com/xhd2015/arc-orm/cmd/arc-orm/parse"
+	"github.com/xhd2015/less-gen/flags"
 	"github.com/xhd2015/less-gen/go/gofmt"
 	"github.com/xhd2015/less-gen/go/gostruct"
-	"github.com/xhd2015/less-gen/strcase"
 	"github.com/xhd2015/xgo/support/edit/goedit"
 	"github.com/xhd2015/xgo/support/goinfo"
 )
@@ -20,8 +20,22 @@ const help = `
 Usage: ormx <command>
 
 Commands:
-  gen     generate models
-  sync    sync models, same as gen
+  gen       generate models
+              --dir DIR          directory to load packages from
+              --from-sql FILE    create or update table packages from CREATE TABLE statements
+              --out DIR          directory to write table packages into, default: --dir
+              --dry-run          list files that would be modified, writes nothing
+              --diff             print unified diffs of files that would be modified, writes nothing
+              --mocks            generate in-memory fake ORMs into <file>_fake.go
+              --mapping          generate reflection-free column mapping into <file>_mapping.go
+              --repository       scaffold a repository layer into <file>_repository.go once
+  sync      sync models, same as gen
+  check     check models are in sync with table definitions, writes nothing
+  lint      check models against the naming rules of orm.Bind, run 'arc-orm lint --help' for details
+  new       create a new table package, run 'arc-orm new --help' for details
+  migrate   generate migration files, run 'arc-orm migrate --help' for details
+
+Configuration is read from arc-orm.yaml at the module root if present.
 
 `
 
@@ -44,6 +58,14 @@ func handle(args []string) error {
 		return nil
 	case "gen", "sync":
 		return gen(args[1:])
+	case "check":
+		return check(args[1:])
+	case "lint":
+		return lint(args[1:])
+	case "new":
+		return newTable(args[1:])
+	case "migrate":
+		return migrate(args[1:])
 	}
 
 	return fmt.Errorf("unknown command, run `arc-orm help`")
@@ -51,81 +73,138 @@ func handle(args []string) error {
 
 func gen(args []string) error {
 	var dir string
-	var remainArgs []string
-	n := len(args)
-	for i := 0; i < n; i++ {
-		arg := args[i]
-		if arg == "--dir" {
-			if i+1 >= n {
-				return fmt.Errorf("%s requires argument", arg)
-			}
-			dir = args[i+1]
-			remainArgs = args[i+2:]
-			continue
-		} else if strings.HasPrefix(arg, "--dir=") {
-			dir = arg[len("--dir="):]
-			continue
-		}
-		if strings.HasPrefix(arg, "-") {
-			return fmt.Errorf("unrecognized flag: %s", arg)
-		}
-		remainArgs = append(remainArgs, arg)
+	var fromSQL string
+	var outDir string
+	var dryRun bool
+	var showDiff bool
+	var mocks bool
+	var mapping bool
+	var repository bool
+	remainArgs, err := flags.String("--dir", &dir).
+		String("--from-sql", &fromSQL).
+		String("--out", &outDir).
+		Bool("--dry-run", &dryRun).
+		Bool("--diff", &showDiff).
+		Bool("--mocks", &mocks).
+		Bool("--mapping", &mapping).
+		Bool("--repository", &repository).
+		Help("-h,--help", help).
+		Parse(args)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
+	if mocks {
+		cfg.Mocks = true
+	}
+	if mapping {
+		cfg.Mapping = true
+	}
+	if repository {
+		cfg.Repository = true
 	}
 
-	var loadDir string
-	var loadArgs []string
-	if len(remainArgs) == 0 {
-		resolveDir := dir
-		if dir == "" {
-			wd, err := os.Getwd()
+	if fromSQL != "" {
+		if outDir == "" {
+			outDir = cfg.outDir(dir)
+		}
+		tableChanges, err := genFromSQL(fromSQL, outDir)
+		if err != nil {
+			return err
+		}
+		if dryRun || showDiff {
+			// models of new table files cannot be previewed
+			// before the table files are written
+			printChanges(tableChanges, showDiff)
+		} else {
+			err = writeChanges(tableChanges)
 			if err != nil {
 				return err
 			}
-			resolveDir = wd
 		}
+	}
 
-		absWd, err := filepath.Abs(resolveDir)
+	changes, err := syncModels(dir, remainArgs, cfg)
+	if err != nil {
+		return err
+	}
+	if dryRun || showDiff {
+		printChanges(changes, showDiff)
+		return nil
+	}
+	return writeChanges(changes)
+}
+
+func writeChanges(changes []*fileChange) error {
+	for _, change := range changes {
+		err := os.MkdirAll(filepath.Dir(change.File), 0755)
 		if err != nil {
 			return err
 		}
-
-		subPaths, mainModule, err := goinfo.ResolveMainModule(absWd)
+		err = os.WriteFile(change.File, change.NewCode, 0644)
 		if err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		_ = mainModule
-
-		mainDir := absWd
-		for i, n := 0, len(subPaths); i < n; i++ {
-			mainDir = filepath.Dir(mainDir)
+// printChanges prints the files that would be modified,
+// or their unified diffs if showDiff is set
+func printChanges(changes []*fileChange, showDiff bool) {
+	for _, change := range changes {
+		if showDiff {
+			fmt.Print(unifiedDiff(change.File, change.OldCode, change.NewCode))
+		} else {
+			fmt.Println(change.File)
 		}
+	}
+}
 
-		loadDir = mainDir
-		loadArgs = []string{"./..."}
-	} else {
-		loadDir = dir
-		loadArgs = remainArgs
+// fileChange is a file whose content changes after generation
+type fileChange struct {
+	File    string
+	OldCode []byte
+	NewCode []byte
+}
+
+// syncModels computes the model and ORM updates of the loaded packages
+// without writing them
+func syncModels(dir string, args []string, cfg *config) ([]*fileChange, error) {
+	loadDir, loadArgs, err := resolveLoadArgs(dir, args)
+	if err != nil {
+		return nil, err
 	}
 
 	// Load the packages and extract table relations
 	fset := token.NewFileSet()
-	pkgs, err := parse.ScanRelations(fset, loadDir, loadArgs)
+	pkgs, err := parse.ScanRelations(fset, loadDir, loadArgs, cfg.modelNames)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var changes []*fileChange
 	for _, pkg := range pkgs {
+		if cfg.excluded(pkg.PkgPath) {
+			continue
+		}
+		for _, diagnostic := range pkg.Diagnostics {
+			fmt.Fprintf(os.Stderr, "warning: %s\n", diagnostic)
+		}
 		for _, file := range pkg.Files {
 			code, err := os.ReadFile(file.AbsFile)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			edit := goedit.NewWithBytes(fset, code)
 			for i, table := range file.Tables {
 				if table.NeedCreateORM {
 					// var ORM = orm.Bind[table.Model, table.OptionalModel](nil, table.TableName)
-					declare := fmt.Sprintf("\nvar ORM = orm.Bind[%s, %s](nil, %s)", table.Model.Name, table.OptionalModel.Name, table.TablVarName)
+					declare := fmt.Sprintf("\nvar %s = orm.Bind[%s, %s](nil, %s%s)", table.ORMVarName, table.Model.Name, table.OptionalModel.Name, table.TablVarName, cfg.bindOptions())
 					pos, newLine := getMinAppendPos(file, table)
 					if newLine {
 						declare += "\n"
@@ -140,21 +219,102 @@ func gen(args []string) error {
 					}
 					edit.Insert(pos, declare)
 				}
-				amendModels(edit, file, code, table)
+				amendModels(edit, file, code, table, cfg)
+			}
+			if cfg.Columns {
+				change, err := columnsChange(file)
+				if err != nil {
+					return nil, err
+				}
+				if change != nil {
+					changes = append(changes, change)
+				}
+			}
+			if cfg.Mocks {
+				change, err := fakeChange(file, cfg)
+				if err != nil {
+					return nil, err
+				}
+				if change != nil {
+					changes = append(changes, change)
+				}
+			}
+			if cfg.Mapping {
+				change, err := mappingChange(file, cfg)
+				if err != nil {
+					return nil, err
+				}
+				if change != nil {
+					changes = append(changes, change)
+				}
+			}
+			if cfg.Repository {
+				change, err := repositoryChange(file)
+				if err != nil {
+					return nil, err
+				}
+				if change != nil {
+					changes = append(changes, change)
+				}
 			}
 			if !edit.HasEdit() {
 				continue
 			}
 			newCode := edit.Buffer().Bytes()
 			newCode = []byte(gofmt.TryFormatCode(string(newCode)))
-			err = os.WriteFile(file.AbsFile, newCode, 0644)
-			if err != nil {
-				return err
+			if string(newCode) == string(code) {
+				continue
 			}
+			changes = append(changes, &fileChange{
+				File:    file.AbsFile,
+				OldCode: code,
+				NewCode: newCode,
+			})
 		}
 	}
+	return changes, nil
+}
 
-	return nil
+// resolveLoadArgs resolves the directory and package patterns to load,
+// defaults to all packages of the main module containing dir
+func resolveLoadArgs(dir string, args []string) (string, []string, error) {
+	if len(args) > 0 {
+		return dir, args, nil
+	}
+	mainDir, err := resolveModuleRoot(dir)
+	if err != nil {
+		return "", nil, err
+	}
+	return mainDir, []string{"./..."}, nil
+}
+
+// resolveModuleRoot returns the root directory of the main module
+// containing dir, defaults to the working directory
+func resolveModuleRoot(dir string) (string, error) {
+	resolveDir := dir
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", err
+		}
+		resolveDir = wd
+	}
+
+	absWd, err := filepath.Abs(resolveDir)
+	if err != nil {
+		return "", err
+	}
+
+	subPaths, _, err := goinfo.ResolveMainModule(absWd)
+	if err != nil {
+		return "", err
+	}
+
+	mainDir := absWd
+	for i, n := 0, len(subPaths); i < n; i++ {
+		mainDir = filepath.Dir(mainDir)
+	}
+	return mainDir, nil
 }
 
 func getMinAppendPos(file *parse.File, table *parse.TableRelation) (token.Pos, bool) {
@@ -185,13 +345,23 @@ func minPos(a token.Pos, b token.Pos) token.Pos {
 	return b
 }
 
-func amendModels(edit *goedit.Edit, file *parse.File, code []byte, table *parse.TableRelation) {
-	updateStructFields(edit, file, code, table, table.Model, table.Fields, table.Model.Fields, false)
-	updateStructFields(edit, file, code, table, table.OptionalModel, table.Fields, table.OptionalModel.Fields, true)
+func amendModels(edit *goedit.Edit, file *parse.File, code []byte, table *parse.TableRelation, cfg *config) {
+	// field names of columns declared by `orm:"column:..."` tags,
+	// the model takes precedence over the optional model
+	columnFields := make(map[string]string)
+	for _, model := range []parse.ModelInfo{table.OptionalModel, table.Model} {
+		for _, f := range model.Fields {
+			if column := tagColumn(f.Tags); column != "" {
+				columnFields[column] = f.Name
+			}
+		}
+	}
+	updateStructFields(edit, file, code, table, table.Model, table.Fields, table.Model.Fields, false, cfg, columnFields)
+	updateStructFields(edit, file, code, table, table.OptionalModel, table.Fields, table.OptionalModel.Fields, true, cfg, columnFields)
 }
 
 // updateStructFields checks and updates struct fields to match the table field definitions
-func updateStructFields(edit *goedit.Edit, file *parse.File, code []byte, table *parse.TableRelation, model parse.ModelInfo, tableFields []parse.FieldRelation, structFields []parse.FieldInfo, asPointer bool) {
+func updateStructFields(edit *goedit.Edit, file *parse.File, code []byte, table *parse.TableRelation, model parse.ModelInfo, tableFields []parse.FieldRelation, structFields []parse.FieldInfo, asPointer bool, cfg *config, columnFields map[string]string) {
 	var structTypeName string
 	var structType *ast.StructType
 	if model.TypeSpec != nil && model.TypeSpec.Name != nil {
@@ -201,16 +371,26 @@ func updateStructFields(edit *goedit.Edit, file *parse.File, code []byte, table
 
 	current := gostruct.ParseStruct(edit.Fset(), structType, structTypeName)
 
+	currentTags := make(map[string]string, len(current.Fields))
+	for _, field := range current.Fields {
+		currentTags[field.Name] = field.Tag
+	}
+
 	// Create desired fields from table fields
 	var desiredFields []gostruct.FieldDef
 	for _, tableField := range tableFields {
-		structType := getStructType(tableField.Type)
-		if asPointer {
+		structType := cfg.goType(tableField.Type)
+		if asPointer && !strings.HasPrefix(structType, "*") {
 			structType = "*" + structType
 		}
+		name := columnFields[tableField.ColumnName]
+		if name == "" {
+			name = cfg.fieldName(tableField.ColumnName)
+		}
 		desiredFields = append(desiredFields, gostruct.FieldDef{
-			Name: strcase.SnakeToCamel(tableField.ColumnName),
+			Name: name,
 			Type: structType,
+			Tag:  cfg.fieldTag(currentTags[name], tableField.ColumnName),
 		})
 	}
 
@@ -227,13 +407,69 @@ func updateStructFields(edit *goedit.Edit, file *parse.File, code []byte, table
 	// Merge the structs
 	result := gostruct.MergeStructs(current, desired, reserveFields)
 
+	// fields tagged `orm:"-"` map to no column, they are kept
+	// after the column fields in their declaration order
+	merged := make(map[string]bool, len(result.Fields))
+	for _, f := range result.Fields {
+		merged[f.Name] = true
+	}
+	for _, f := range current.Fields {
+		if tagIgnored(f.Tag) && !merged[f.Name] {
+			result.Fields = append(result.Fields, f)
+		}
+	}
+
 	if model.TypeSpec != nil {
-		edit.Replace(model.TypeSpec.Pos(), model.TypeSpec.End(), result.Format(gostruct.FormatOptions{
-			NoPrefixType: true,
-		}))
+		edit.Replace(model.TypeSpec.Pos(), model.TypeSpec.End(), formatStruct(result, structType, false))
 	} else {
-		edit.Insert(file.AST.End(), "\n"+result.Format(gostruct.FormatOptions{}))
+		edit.Insert(file.AST.End(), "\n"+formatStruct(result, nil, true))
+	}
+}
+
+// formatStruct formats the merged struct, keeping the doc
+// and line comments of fields existing in the current struct
+func formatStruct(def gostruct.StructDef, current *ast.StructType, prefixType bool) string {
+	docs := make(map[string][]string)
+	comments := make(map[string][]string)
+	if current != nil {
+		for _, field := range current.Fields.List {
+			for _, name := range field.Names {
+				if field.Doc != nil {
+					for _, c := range field.Doc.List {
+						docs[name.Name] = append(docs[name.Name], c.Text)
+					}
+				}
+				if field.Comment != nil {
+					for _, c := range field.Comment.List {
+						comments[name.Name] = append(comments[name.Name], c.Text)
+					}
+				}
+			}
+		}
+	}
+
+	var b strings.Builder
+	if prefixType {
+		b.WriteString("type ")
+	}
+	b.WriteString(def.Name + " struct {\n")
+	for _, field := range def.Fields {
+		for _, doc := range docs[field.Name] {
+			b.WriteString("\t" + doc + "\n")
+		}
+		b.WriteString("\t" + field.Name + " " + field.Type)
+		if field.Tag != "" {
+			b.WriteString(" `" + field.Tag + "`")
+		}
+		if len(comments[field.Name]) > 0 {
+			b.WriteString(" " + strings.Join(comments[field.Name], " "))
+		} else if field.Comment != "" {
+			b.WriteString(" // " + field.Comment)
+		}
+		b.WriteString("\n")
 	}
+	b.WriteString("}")
+	return b.String()
 }
 
 func getStructType(name string) string {
@@ -244,7 +480,7 @@ func getStructType(name string) string {
 		return "int32"
 	case "Time":
 		return "time.Time"
-	case "String":
+	case "String", "UUID":
 		return "string"
 	case "Bool":
 		return "bool"
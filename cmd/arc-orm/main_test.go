@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/xhd2015/xgo/support/assert"
@@ -219,13 +220,15 @@ type UserOptional struct {
 		t.Fatalf("Failed to run gen: %v", err)
 	}
 
+	// The comment stays attached to CreateTime
 	want := base + `var ORM = orm.Bind[User, UserOptional](nil, Table)
 
 //go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
 type User struct {
-	Id         int64
-	Name       string
-	Email      string
+	Id    int64
+	Name  string
+	Email string
+	// Email field is missing
 	CreateTime time.Time
 	UpdateTime time.Time
 }
@@ -298,3 +301,361 @@ type UserOptional struct {
 		t.Error(diff)
 	}
 }
+
+// TestGen_DryRun tests that --dry-run and --diff leave files unchanged
+func TestGen_DryRun(t *testing.T) {
+	inputCode := strings.Replace(FullDefiniton, "\tEmail      string\n", "", 1)
+	tmpDir, file := setupTestDir(t, inputCode)
+	defer os.RemoveAll(tmpDir)
+
+	before, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	for _, flag := range []string{"--dry-run", "--diff"} {
+		err := gen([]string{"--dir=" + tmpDir, flag})
+		if err != nil {
+			t.Fatalf("Failed to run gen %s: %v", flag, err)
+		}
+		after, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read file: %v", err)
+		}
+		if diff := assert.Diff(string(before), string(after)); diff != "" {
+			t.Errorf("Expected %s to write nothing: %s", flag, diff)
+		}
+	}
+}
+
+// TestGen_PreserveComments tests that tags, field comments and doc comments survive regeneration
+func TestGen_PreserveComments(t *testing.T) {
+	inputCode := `var ORM = orm.Bind[User, UserOptional](nil, Table)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+
+// User is a registered user
+type User struct {
+	// Id is the primary key
+	Id   int64  ` + "`json:\"id\"`" + ` // auto increment
+	Name string /* display name */
+	// Age is removed
+	Age        int
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+type UserOptional struct {
+	Id         *int64
+	Name       *string
+	Email      *string
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+`
+	code, err := runGen(t, inputCode)
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+
+	want := base + `var ORM = orm.Bind[User, UserOptional](nil, Table)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+
+// User is a registered user
+type User struct {
+	// Id is the primary key
+	Id         int64  ` + "`json:\"id\"`" + ` // auto increment
+	Name       string /* display name */
+	Email      string
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+type UserOptional struct {
+	Id         *int64
+	Name       *string
+	Email      *string
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+`
+	if diff := assert.Diff(want, code); diff != "" {
+		t.Error(diff)
+	}
+}
+
+// TestGen_ColumnTag tests that fields mapped by `orm:"column:..."` keep their names
+func TestGen_ColumnTag(t *testing.T) {
+	inputCode := strings.Replace(FullDefiniton, "\tEmail      string\n", "\tMail       string `orm:\"column:email\"`\n", 1)
+	code, err := runGen(t, inputCode)
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+
+	want := base + `
+var ORM = orm.Bind[User, UserOptional](nil, Table)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+
+type User struct {
+	Id         int64
+	Name       string
+	Mail       string ` + "`orm:\"column:email\"`" + `
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+type UserOptional struct {
+	Id         *int64
+	Name       *string
+	Mail       *string
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+`
+	if diff := assert.Diff(want, code); diff != "" {
+		t.Error(diff)
+	}
+}
+
+// TestGen_IgnoredField tests that fields tagged `orm:"-"` are kept
+func TestGen_IgnoredField(t *testing.T) {
+	inputCode := strings.Replace(FullDefiniton, "\tName       string\n", "\tLabel      string `orm:\"-\"`\n\tName       string\n", 1)
+	code, err := runGen(t, inputCode)
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+
+	want := base + `
+var ORM = orm.Bind[User, UserOptional](nil, Table)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+
+type User struct {
+	Id         int64
+	Name       string
+	Email      string
+	CreateTime time.Time
+	UpdateTime time.Time
+	Label      string ` + "`orm:\"-\"`" + `
+}
+
+type UserOptional struct {
+	Id         *int64
+	Name       *string
+	Email      *string
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+`
+	if diff := assert.Diff(want, code); diff != "" {
+		t.Error(diff)
+	}
+}
+
+// TestGen_UnsuffixedTableVar tests that models of a table variable
+// without the Table suffix do not collide with the variable, and that
+// a table bound in another file is left alone
+func TestGen_UnsuffixedTableVar(t *testing.T) {
+	inputCode := `
+var Orders = table.New("orders")
+
+var (
+	OrderId     = Orders.Int64("id")
+	OrderAmount = Orders.Float64("amount")
+)
+`
+	tmpDir, file := setupTestDir(t, inputCode)
+	defer os.RemoveAll(tmpDir)
+
+	err := os.WriteFile(filepath.Join(tmpDir, "user_orm.go"), []byte(`package testorm
+
+import (
+	"time"
+
+	"github.com/xhd2015/arc-orm/orm"
+)
+
+var UserORM = orm.Bind[User, UserOptional](nil, Table)
+
+type User struct {
+	Id         int64
+	Name       string
+	Email      string
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+type UserOptional struct {
+	Id         *int64
+	Name       *string
+	Email      *string
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	err = gen([]string{"--dir=" + tmpDir})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	// the time import of base is unused without the User models
+	want := strings.Replace(base, "\t\"time\"\n\n", "", 1) + inputCode + `var OrdersORM = orm.Bind[OrdersModel, OrdersOptional](nil, Orders)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+type OrdersModel struct {
+	Id     int64
+	Amount float64
+}
+type OrdersOptional struct {
+	Id     *int64
+	Amount *float64
+}
+`
+	if diff := assert.Diff(want, string(content)); diff != "" {
+		t.Fatal(diff)
+	}
+	err = cmd.Dir(tmpDir).Run("go", "build", "./...")
+	if err != nil {
+		t.Fatalf("Expected generated package to build: %v", err)
+	}
+
+	changes, err := syncModels(tmpDir, nil, &config{})
+	if err != nil {
+		t.Fatalf("Failed to sync: %v", err)
+	}
+	if len(changes) != 0 {
+		t.Errorf("Expected no changes on second run, got %d", len(changes))
+	}
+}
+
+// TestGen_CrossPackage tests that gen over the whole module leaves
+// a shared schema package alone when its tables are bound elsewhere
+func TestGen_CrossPackage(t *testing.T) {
+	tmpDir, _ := setupTestDir(t, FullDefiniton)
+	defer os.RemoveAll(tmpDir)
+
+	schemaCode := `package schema
+
+import "github.com/xhd2015/arc-orm/table"
+
+var Orders = table.New("orders")
+
+var (
+	OrderId     = Orders.Int64("id")
+	OrderAmount = Orders.Float64("amount")
+)
+`
+	orderCode := `package order
+
+import (
+	"testormx/schema"
+
+	"github.com/xhd2015/arc-orm/orm"
+)
+
+var ORM = orm.Bind[Order, OrderOptional](nil, schema.Orders)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+type Order struct {
+	Id     int64
+	Amount float64
+}
+
+type OrderOptional struct {
+	Id     *int64
+	Amount *float64
+}
+`
+	files := map[string]string{
+		filepath.Join(tmpDir, "schema", "schema.go"): schemaCode,
+		filepath.Join(tmpDir, "order", "order.go"):   orderCode,
+	}
+	for file, code := range files {
+		err := os.MkdirAll(filepath.Dir(file), 0755)
+		if err != nil {
+			t.Fatalf("Failed to create dir: %v", err)
+		}
+		err = os.WriteFile(file, []byte(code), 0644)
+		if err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	err := gen([]string{"--dir=" + tmpDir, "./..."})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	for file, code := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("Failed to read file: %v", err)
+		}
+		if diff := assert.Diff(code, string(content)); diff != "" {
+			t.Errorf("Expected %s unchanged: %s", file, diff)
+		}
+	}
+}
+
+// TestGen_MultipleTables tests that each unbound table gets its own models and ORM
+func TestGen_MultipleTables(t *testing.T) {
+	inputCode := `
+var OrderItemTable = table.New("order_items")
+
+var (
+	OrderItemId     = OrderItemTable.Int64("id")
+	OrderItemAmount = OrderItemTable.Float64("amount")
+)
+`
+	code, err := runGen(t, inputCode)
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+
+	want := base + `
+var OrderItemTable = table.New("order_items")
+
+var (
+	OrderItemId     = OrderItemTable.Int64("id")
+	OrderItemAmount = OrderItemTable.Float64("amount")
+)
+var ORM = orm.Bind[Testorm, TestormOptional](nil, Table)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+type Testorm struct {
+	Id         int64
+	Name       string
+	Email      string
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+type TestormOptional struct {
+	Id         *int64
+	Name       *string
+	Email      *string
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+
+var OrderItemORM = orm.Bind[OrderItem, OrderItemOptional](nil, OrderItemTable)
+
+type OrderItem struct {
+	Id     int64
+	Amount float64
+}
+type OrderItemOptional struct {
+	Id     *int64
+	Amount *float64
+}
+`
+	if diff := assert.Diff(want, code); diff != "" {
+		t.Error(diff)
+	}
+}
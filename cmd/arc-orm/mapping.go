@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/cmd/arc-orm/parse"
+)
+
+// mappingFile returns the file holding the column mapping of a table file,
+// e.g. table.go -> table_mapping.go
+func mappingFile(file string) string {
+	return strings.TrimSuffix(file, ".go") + "_mapping.go"
+}
+
+// mappingChange generates Columns(), Values() and ScanRow() of the
+// models defined in file, which the ORM uses instead of reflection
+func mappingChange(file *parse.File, cfg *config) (*fileChange, error) {
+	var b strings.Builder
+	for _, table := range file.Tables {
+		fields, _ := fakeFields(table, cfg)
+		writeMapping(&b, table, fields)
+	}
+	if b.Len() == 0 {
+		return nil, nil
+	}
+	code := fmt.Sprintf("// Code generated by arc-orm. DO NOT EDIT.\n\npackage %s\n%s", file.AST.Name.Name, b.String())
+
+	target := mappingFile(file.AbsFile)
+	oldCode, err := os.ReadFile(target)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return formatChange(target, oldCode, []byte(code))
+}
+
+// writeMapping writes the column mapping methods of one model
+func writeMapping(b *strings.Builder, table *parse.TableRelation, fields []fakeField) {
+	model := table.Model.Name
+	columns := make([]string, 0, len(fields))
+	values := make([]string, 0, len(fields))
+	for _, f := range fields {
+		columns = append(columns, fmt.Sprintf("%q", f.Column))
+		values = append(values, "m."+f.Name)
+	}
+
+	fmt.Fprintf(b, `
+// Columns returns the columns of %s, in the order of Values
+func (m *%s) Columns() []string {
+	return []string{%s}
+}
+
+// Values returns the field values of %s, in the order of Columns
+func (m *%s) Values() []interface{} {
+	return []interface{}{%s}
+}
+
+// ScanRow returns pointers to the fields of %s receiving
+// the columns, nil for unknown columns
+func (m *%s) ScanRow(columns []string) []interface{} {
+	dests := make([]interface{}, len(columns))
+	for i, column := range columns {
+		switch column {
+`, model, model, strings.Join(columns, ", "), model, model, strings.Join(values, ", "), model, model)
+	for _, f := range fields {
+		fmt.Fprintf(b, "\t\tcase %q:\n\t\t\tdests[i] = &m.%s\n", f.Column, f.Name)
+	}
+	b.WriteString("\t\t}\n\t}\n\treturn dests\n}\n")
+}
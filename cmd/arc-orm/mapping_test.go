@@ -0,0 +1,67 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xhd2015/xgo/support/cmd"
+)
+
+const mappingUsageTest = `package testorm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/orm"
+)
+
+func TestUserMapping(t *testing.T) {
+	user := &User{Id: 1, Name: "alice"}
+	var _ orm.ColumnMapper = user
+	var _ orm.RowScanner = user
+
+	columns := user.Columns()
+	if !reflect.DeepEqual(columns, []string{"id", "name", "email", "create_time", "update_time"}) {
+		t.Fatalf("columns: %v", columns)
+	}
+	values := user.Values()
+	if len(values) != len(columns) || values[1] != "alice" {
+		t.Fatalf("values: %v", values)
+	}
+
+	var scanned User
+	dests := scanned.ScanRow([]string{"name", "unknown"})
+	if dests[1] != nil {
+		t.Fatalf("expected nil dest for unknown column")
+	}
+	*dests[0].(*string) = "bob"
+	if scanned.Name != "bob" {
+		t.Fatalf("scan: %+v", scanned)
+	}
+}
+`
+
+// TestGen_Mapping tests that the generated column mapping compiles and matches the model
+func TestGen_Mapping(t *testing.T) {
+	tmpDir, file := setupTestDir(t, FullDefiniton)
+	defer os.RemoveAll(tmpDir)
+
+	err := gen([]string{"--dir=" + tmpDir, "--mapping"})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	if _, err := os.Stat(mappingFile(file)); err != nil {
+		t.Fatalf("Expected mapping file: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(tmpDir, "mapping_usage_test.go"), []byte(mappingUsageTest), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write test: %v", err)
+	}
+	output, err := cmd.Dir(tmpDir).Output("go", "test", "./...")
+	if err != nil {
+		t.Fatalf("Failed to run generated mapping tests: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/xhd2015/arc-orm/cmd/arc-orm/parse"
+	"github.com/xhd2015/arc-orm/ddl"
+	"github.com/xhd2015/arc-orm/engine/sqldb"
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/table"
+	"github.com/xhd2015/less-gen/flags"
+)
+
+const migrateHelp = `
+Usage: arc-orm migrate diff --dsn=DSN [options] [packages...]
+
+Compare table definitions against a live MySQL database
+and write ALTER TABLE migration files for the differences.
+Type changes that may lose data and undeclared columns are
+printed as skipped, to be migrated by hand.
+
+Options:
+  --dsn DSN       MySQL data source name, e.g. user:pass@tcp(127.0.0.1:3306)/db
+  --dir DIR       directory to load packages from
+  --out DIR       directory to write migration files into, default: migrations
+  --drop-columns  drop the columns not declared by the tables
+  -h, --help      show help
+`
+
+func migrate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("requires subcommand, run `arc-orm migrate --help`")
+	}
+	switch args[0] {
+	case "-h", "--help", "help":
+		fmt.Println(strings.TrimPrefix(migrateHelp, "\n"))
+		return nil
+	case "diff":
+		return migrateDiff(args[1:])
+	}
+	return fmt.Errorf("unknown migrate subcommand: %s", args[0])
+}
+
+func migrateDiff(args []string) error {
+	var dsn string
+	var dir string
+	var dropColumns bool
+	outDir := "migrations"
+	remainArgs, err := flags.String("--dsn", &dsn).
+		String("--dir", &dir).
+		String("--out", &outDir).
+		Bool("--drop-columns", &dropColumns).
+		Help("-h,--help", migrateHelp).
+		Parse(args)
+	if err != nil {
+		return err
+	}
+	if dsn == "" {
+		return fmt.Errorf("requires --dsn")
+	}
+
+	loadDir, loadArgs, err := resolveLoadArgs(dir, remainArgs)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
+	tables, err := loadTables(loadDir, loadArgs, cfg)
+	if err != nil {
+		return err
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	eng := sqldb.New(db)
+
+	ctx := context.Background()
+	var diffs []*ddl.Diff
+	for _, t := range tables {
+		columns, err := ddl.QueryColumns(ctx, eng, t.Name())
+		if err != nil {
+			return err
+		}
+		diff := ddl.Compare(t, columns)
+		diff.DropColumns = dropColumns
+		if !diff.Missing && len(t.Indexes()) > 0 {
+			indexes, err := ddl.QueryIndexes(ctx, eng, t.Name())
+			if err != nil {
+				return err
+			}
+			diff.CompareIndexes(indexes)
+		}
+		if diff.Empty() {
+			continue
+		}
+		for _, skipped := range diff.Skipped() {
+			fmt.Fprintf(os.Stderr, "skipped %s %s\n", t.Name(), skipped)
+		}
+		diffs = append(diffs, diff)
+	}
+	if len(diffs) == 0 {
+		fmt.Println("schema is up to date")
+		return nil
+	}
+	files, err := writeMigrations(outDir, time.Now(), diffs)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		fmt.Println("no migration written")
+	}
+	for _, file := range files {
+		fmt.Println(file)
+	}
+	return nil
+}
+
+// writeMigrations writes one migration file per table diff,
+// named as <timestamp>_<table>.sql, and returns the written files.
+// Diffs without statements, e.g. only skipped changes, are not written.
+func writeMigrations(outDir string, now time.Time, diffs []*ddl.Diff) ([]string, error) {
+	err := os.MkdirAll(outDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	version := now.Format("20060102150405")
+	var files []string
+	for _, diff := range diffs {
+		stmts, err := diff.Statements()
+		if err != nil {
+			return nil, err
+		}
+		if len(stmts) == 0 {
+			continue
+		}
+		file := filepath.Join(outDir, version+"_"+diff.Table.Name()+".sql")
+		err = os.WriteFile(file, []byte(strings.Join(stmts, "\n")+"\n"), 0644)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// loadTables loads the packages and reconstructs table definitions
+// from the table relations found in them
+func loadTables(dir string, args []string, cfg *config) ([]table.Table, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parse.ScanRelations(fset, dir, args, cfg.modelNames)
+	if err != nil {
+		return nil, err
+	}
+	var tables []table.Table
+	seen := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if cfg.excluded(pkg.PkgPath) {
+			continue
+		}
+		for _, file := range pkg.Files {
+			for _, rel := range file.Tables {
+				if seen[rel.TableName] {
+					continue
+				}
+				seen[rel.TableName] = true
+				tables = append(tables, toTable(rel))
+			}
+		}
+	}
+	return tables, nil
+}
+
+// toTable builds a table.Table from a parsed table relation
+func toTable(rel *parse.TableRelation) table.Table {
+	t := table.New(rel.TableName)
+	for _, f := range rel.Fields {
+		switch f.Type {
+		case "Int64":
+			t.Int64(f.ColumnName)
+		case "Int32":
+			t.Int32(f.ColumnName)
+		case "Float64":
+			t.Float64(f.ColumnName)
+		case "String":
+			t.String(f.ColumnName)
+		case "UUID":
+			t.UUID(f.ColumnName)
+		case "Time":
+			t.Time(f.ColumnName)
+		case "Bool":
+			t.Bool(f.ColumnName)
+		}
+	}
+	fields := make(map[string]field.Field, len(t.Fields()))
+	for _, f := range t.Fields() {
+		fields[f.Name()] = f
+	}
+	for _, idx := range rel.Indexes {
+		var idxFields []field.Field
+		for _, column := range idx.Columns {
+			if f, ok := fields[column]; ok {
+				idxFields = append(idxFields, f)
+			}
+		}
+		switch idx.Kind {
+		case "Index":
+			t.Index(idx.Name, idxFields...)
+		case "Unique":
+			t.Unique(idx.Name, idxFields...)
+		case "PrimaryKey":
+			t.PrimaryKey(idxFields...)
+		}
+	}
+	return t
+}
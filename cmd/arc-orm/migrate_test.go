@@ -0,0 +1,50 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/ddl"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// TestWriteMigrations tests that diffs with only skipped changes write no file
+func TestWriteMigrations(t *testing.T) {
+	users := table.New("users")
+	users.Int64("id")
+	users.Int32("age")
+	orders := table.New("orders")
+	orders.Int64("id")
+	orders.String("note")
+
+	diffs := []*ddl.Diff{
+		ddl.Compare(users, []*ddl.Column{
+			{ColumnName: "id", DataType: "bigint"},
+			{ColumnName: "age", DataType: "bigint"},
+			{ColumnName: "legacy", DataType: "text"},
+		}),
+		ddl.Compare(orders, []*ddl.Column{
+			{ColumnName: "id", DataType: "bigint"},
+		}),
+	}
+	outDir := t.TempDir()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	files, err := writeMigrations(outDir, now, diffs)
+	if err != nil {
+		t.Fatalf("Failed to write migrations: %v", err)
+	}
+	expectedFile := filepath.Join(outDir, "20240102030405_orders.sql")
+	if len(files) != 1 || files[0] != expectedFile {
+		t.Fatalf("Expected only %s, got %v", expectedFile, files)
+	}
+	content, err := os.ReadFile(expectedFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "ADD COLUMN `note`") || strings.Contains(string(content), "DROP") {
+		t.Errorf("Unexpected migration: %s", content)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/cmd/arc-orm/parse"
+	"github.com/xhd2015/arc-orm/orm"
+	"github.com/xhd2015/less-gen/strcase"
+)
+
+// modelNaming configures model names, e.g.
+//
+//	models:
+//	  singular: true          # users -> User
+//	  suffix: PO              # User -> UserPO
+//	  optional_suffix: Update # UserPO -> UserPOUpdate, default: Optional
+//	  names:                  # by table name, suffixes are not applied
+//	    user_accounts: Account
+type modelNaming struct {
+	Singular       bool              `yaml:"singular"`
+	Suffix         string            `yaml:"suffix"`
+	OptionalSuffix string            `yaml:"optional_suffix"`
+	Names          map[string]string `yaml:"names"`
+}
+
+// modelNames names the model and optional model of a table, see parse.ModelNamer
+func (c *config) modelNames(baseName string, tableName string) (string, string) {
+	naming := c.Models
+	optionalSuffix := naming.OptionalSuffix
+	if optionalSuffix == "" {
+		optionalSuffix = "Optional"
+	}
+	if name := naming.Names[tableName]; name != "" {
+		return name, name + optionalSuffix
+	}
+
+	model, _ := parse.DefaultModelNamer(baseName, tableName)
+	if naming.Singular {
+		model = strcase.SnakeToCamel(singularize(baseName))
+	}
+	model += naming.Suffix
+	return model, model + optionalSuffix
+}
+
+// fieldNaming configures the naming strategy of model fields, e.g.
+//
+//	naming:
+//	  initialisms: true # user_id -> UserID, default: UserId
+//	  no_strict: true   # do not check field names
+//
+// ORMs created by gen and new are bound with the same strategy by
+// orm.WithNamingStrategy, existing orm.Bind calls must pass it too.
+type fieldNaming struct {
+	Initialisms bool `yaml:"initialisms"`
+	NoStrict    bool `yaml:"no_strict"`
+}
+
+// strategy returns the orm naming strategy of the configuration
+func (c *config) strategy() orm.NamingStrategy {
+	return orm.NamingStrategy{
+		Initialisms: c.Naming.Initialisms,
+		NoStrict:    c.Naming.NoStrict,
+	}
+}
+
+// fieldName names the model field of a column
+func (c *config) fieldName(column string) string {
+	return c.strategy().FieldName(column)
+}
+
+// bindOptions returns the options appended to the orm.Bind
+// calls created by gen and new, empty for the default strategy
+func (c *config) bindOptions() string {
+	if c.strategy() == (orm.NamingStrategy{}) {
+		return ""
+	}
+	var fields []string
+	if c.Naming.Initialisms {
+		fields = append(fields, "Initialisms: true")
+	}
+	if c.Naming.NoStrict {
+		fields = append(fields, "NoStrict: true")
+	}
+	return fmt.Sprintf(", orm.WithNamingStrategy(orm.NamingStrategy{%s})", strings.Join(fields, ", "))
+}
+
+// singularize converts the last word of a snake_case name
+// to its singular form with common English rules
+func singularize(name string) string {
+	prefix := ""
+	word := name
+	if i := strings.LastIndex(name, "_"); i >= 0 {
+		prefix, word = name[:i+1], name[i+1:]
+	}
+	lower := strings.ToLower(word)
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(word) > 3:
+		word = word[:len(word)-3] + "y"
+	case strings.HasSuffix(lower, "sses"), strings.HasSuffix(lower, "xes"),
+		strings.HasSuffix(lower, "ches"), strings.HasSuffix(lower, "shes"):
+		word = word[:len(word)-2]
+	case strings.HasSuffix(lower, "ss"), strings.HasSuffix(lower, "us"), strings.HasSuffix(lower, "is"):
+		// class, status, analysis
+	case strings.HasSuffix(lower, "s") && len(word) > 1:
+		word = word[:len(word)-1]
+	}
+	return prefix + word
+}
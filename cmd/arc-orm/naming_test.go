@@ -0,0 +1,165 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xhd2015/xgo/support/assert"
+)
+
+func TestSingularize(t *testing.T) {
+	tests := map[string]string{
+		"users":       "user",
+		"order_items": "order_item",
+		"categories":  "category",
+		"boxes":       "box",
+		"addresses":   "address",
+		"status":      "status",
+		"user":        "user",
+	}
+	for name, want := range tests {
+		if got := singularize(name); got != want {
+			t.Errorf("singularize(%q): want %q, got %q", name, want, got)
+		}
+	}
+}
+
+func TestModelNames(t *testing.T) {
+	cfg := &config{Models: modelNaming{
+		Singular: true,
+		Suffix:   "PO",
+		Names:    map[string]string{"user_accounts": "Account"},
+	}}
+	model, optional := cfg.modelNames("order_items", "order_items")
+	if model != "OrderItemPO" || optional != "OrderItemPOOptional" {
+		t.Errorf("Unexpected names: %s, %s", model, optional)
+	}
+	model, optional = cfg.modelNames("accounts", "user_accounts")
+	if model != "Account" || optional != "AccountOptional" {
+		t.Errorf("Unexpected names: %s, %s", model, optional)
+	}
+}
+
+// TestGen_ModelNaming tests creating models named by the configured strategy
+func TestGen_ModelNaming(t *testing.T) {
+	tmpDir, file := setupTestDir(t, "")
+	defer os.RemoveAll(tmpDir)
+
+	err := os.WriteFile(filepath.Join(tmpDir, configFile), []byte(`models:
+  suffix: PO
+  optional_suffix: Update
+`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	err = gen([]string{"--dir=" + tmpDir})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	want := base + `var ORM = orm.Bind[TestormPO, TestormPOUpdate](nil, Table)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+type TestormPO struct {
+	Id         int64
+	Name       string
+	Email      string
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+type TestormPOUpdate struct {
+	Id         *int64
+	Name       *string
+	Email      *string
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+`
+	if diff := assert.Diff(want, string(content)); diff != "" {
+		t.Error(diff)
+	}
+}
+
+// TestGen_FieldNaming tests that gen, new and lint follow the configured naming strategy
+func TestGen_FieldNaming(t *testing.T) {
+	tmpDir, file := setupTestDir(t, "")
+	defer os.RemoveAll(tmpDir)
+
+	err := os.WriteFile(filepath.Join(tmpDir, configFile), []byte(`naming:
+  initialisms: true
+`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	err = gen([]string{"--dir=" + tmpDir})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	want := base + `var ORM = orm.Bind[Testorm, TestormOptional](nil, Table, orm.WithNamingStrategy(orm.NamingStrategy{Initialisms: true}))
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+type Testorm struct {
+	ID         int64
+	Name       string
+	Email      string
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+type TestormOptional struct {
+	ID         *int64
+	Name       *string
+	Email      *string
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+`
+	if diff := assert.Diff(want, string(content)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	err = newTable([]string{"--dir=" + tmpDir, "order_item"})
+	if err != nil {
+		t.Fatalf("Failed to run new: %v", err)
+	}
+	content, err = os.ReadFile(filepath.Join(tmpDir, "order_item", "table.go"))
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	for _, expect := range []string{
+		"orm.Bind[OrderItem, OrderItemOptional](nil, Table, orm.WithNamingStrategy(orm.NamingStrategy{Initialisms: true}))",
+		"\tID         int64\n",
+		"\tID         *int64\n",
+	} {
+		if !strings.Contains(string(content), expect) {
+			t.Errorf("Expected new table to contain %q, got:\n%s", expect, content)
+		}
+	}
+
+	cfg, err := loadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to load config: %v", err)
+	}
+	findings, err := lintModels(tmpDir, nil, cfg)
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("Expected no findings, got %v", findings)
+	}
+	findings, err = lintModels(tmpDir, nil, &config{})
+	if err != nil {
+		t.Fatalf("Failed to lint: %v", err)
+	}
+	if len(findings) != 2 || !strings.Contains(findings[0].Message, "use 'Id' instead") {
+		t.Errorf("Expected ID findings by the default strategy, got %v", findings)
+	}
+}
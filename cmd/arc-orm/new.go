@@ -0,0 +1,106 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xhd2015/less-gen/flags"
+)
+
+const newHelp = `
+Usage: arc-orm new <table_name> [options]
+
+Create a new table package with placeholder field definitions,
+model structs and the ORM binding.
+
+Options:
+  --dir DIR   parent directory of the package, default: the configured out
+              or the current directory
+  -h, --help  show help
+`
+
+func newTable(args []string) error {
+	var dir string
+	remainArgs, err := flags.String("--dir", &dir).
+		Help("-h,--help", newHelp).
+		Parse(args)
+	if err != nil {
+		return err
+	}
+	if len(remainArgs) == 0 {
+		return fmt.Errorf("requires table name, run `arc-orm new --help`")
+	}
+	if len(remainArgs) > 1 {
+		return fmt.Errorf("unrecognized extra args: %s", strings.Join(remainArgs[1:], " "))
+	}
+	tableName := remainArgs[0]
+	cfg, err := loadConfig(dir)
+	if err != nil {
+		return err
+	}
+	dir = cfg.outDir(dir)
+
+	pkgName := strings.ToLower(tableName)
+	file := filepath.Join(dir, pkgName, "table.go")
+	_, statErr := os.Stat(file)
+	if statErr == nil {
+		return fmt.Errorf("%s already exists", file)
+	}
+	if !os.IsNotExist(statErr) {
+		return statErr
+	}
+	model, optionalModel := cfg.modelNames(pkgName, tableName)
+	change, err := formatChange(file, nil, []byte(formatNewTable(pkgName, tableName, model, optionalModel, cfg)))
+	if err != nil {
+		return err
+	}
+	err = writeChanges([]*fileChange{change})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "created %s\n", file)
+	return nil
+}
+
+// formatNewTable formats a table package with the common
+// id, create_time and update_time columns
+func formatNewTable(pkgName string, tableName string, model string, optionalModel string, cfg *config) string {
+	id := cfg.fieldName("id")
+	return fmt.Sprintf(`package %s
+
+import (
+	"time"
+
+	"github.com/xhd2015/arc-orm/orm"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// Table is the %s table
+var Table = table.New(%q)
+
+// Field definitions
+var (
+	Id         = Table.Int64("id")
+	CreateTime = Table.Time("create_time")
+	UpdateTime = Table.Time("update_time")
+)
+
+var ORM = orm.Bind[%s, %s](nil, Table%s)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+
+type %s struct {
+	%s         int64
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+type %s struct {
+	%s         *int64
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+`, pkgName, tableName, tableName, model, optionalModel, cfg.bindOptions(), model, id, optionalModel, id)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xhd2015/xgo/support/assert"
+)
+
+// TestNew tests scaffolding a table package that gen keeps unchanged
+func TestNew(t *testing.T) {
+	tmpDir, _ := setupTestDir(t, FullDefiniton)
+	defer os.RemoveAll(tmpDir)
+
+	err := newTable([]string{"--dir=" + tmpDir, "order_item"})
+	if err != nil {
+		t.Fatalf("Failed to run new: %v", err)
+	}
+	tableFile := filepath.Join(tmpDir, "order_item", "table.go")
+	content, err := os.ReadFile(tableFile)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	expectCode := `package order_item
+
+import (
+	"time"
+
+	"github.com/xhd2015/arc-orm/orm"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// Table is the order_item table
+var Table = table.New("order_item")
+
+// Field definitions
+var (
+	Id         = Table.Int64("id")
+	CreateTime = Table.Time("create_time")
+	UpdateTime = Table.Time("update_time")
+)
+
+var ORM = orm.Bind[OrderItem, OrderItemOptional](nil, Table)
+
+//go:generate go run github.com/xhd2015/arc-orm/cmd/arc-orm@latest sync
+
+type OrderItem struct {
+	Id         int64
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+type OrderItemOptional struct {
+	Id         *int64
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+`
+	if diff := assert.Diff(expectCode, string(content)); diff != "" {
+		t.Fatal(diff)
+	}
+
+	err = gen([]string{"--dir=" + tmpDir})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	content, err = os.ReadFile(tableFile)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if diff := assert.Diff(expectCode, string(content)); diff != "" {
+		t.Error(diff)
+	}
+
+	err = newTable([]string{"--dir=" + tmpDir, "order_item"})
+	if err == nil {
+		t.Errorf("Expected error creating an existing table package")
+	}
+}
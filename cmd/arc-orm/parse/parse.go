@@ -1,6 +1,8 @@
 package parse
 
 import (
+	"errors"
+	"fmt"
 	"go/ast"
 	"go/token"
 	"go/types"
@@ -39,22 +41,46 @@ type FieldRelation struct {
 	IsPrimary  bool
 	IsIndex    bool
 	IsUnique   bool
+	Node       ast.Node `json:"-"`
 }
 
 // TableRelation represents a relation between a table and its models
 type TableRelation struct {
 	TablVarName   string
+	ORMVarName    string
 	TableName     string
 	NeedCreateORM bool
 	Model         ModelInfo
 	OptionalModel ModelInfo
 	Fields        []FieldRelation
+	Indexes       []IndexRelation
+}
+
+// IndexRelation represents an index declared on the table,
+// like Table.Index("idx_name", Name)
+type IndexRelation struct {
+	// Kind is the declaring method: Index, Unique or PrimaryKey
+	Kind    string
+	Name    string
+	Columns []string
 }
 
 // Package represents a Go package containing files with ORM table relations
 type Package struct {
-	PkgPath string
-	Files   []*File
+	PkgPath     string
+	Files       []*File
+	Diagnostics []Diagnostic
+}
+
+// Diagnostic reports an orm.Bind call that was not processed
+type Diagnostic struct {
+	Position token.Position
+	Reason   string
+}
+
+// String formats the diagnostic as file:line:column: reason
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s", d.Position, d.Reason)
 }
 
 // File represents a Go file containing ORM table relations
@@ -65,10 +91,42 @@ type File struct {
 	AST         *ast.File `json:"-"`
 }
 
+// ModelNamer names the model and optional model of a table
+// defined without an orm.Bind call. The base name is the package name
+// for the `Table` variable, otherwise the snake_case of the table
+// variable name without the Table suffix, e.g. OrderItemTable -> order_item.
+type ModelNamer func(baseName string, tableName string) (model string, optionalModel string)
+
+// DefaultModelNamer names the models by the CamelCase of the base name,
+// e.g. user -> User, UserOptional
+func DefaultModelNamer(baseName string, tableName string) (string, string) {
+	model := strcase.SnakeToCamel(baseName)
+	return model, model + "Optional"
+}
+
+// unboundNames returns the model base name and ORM variable name
+// of a table variable without an orm.Bind call
+func unboundNames(pkgName string, tableVarName string) (baseName string, ormVarName string) {
+	if tableVarName == "Table" {
+		return pkgName, "ORM"
+	}
+	prefix := strings.TrimSuffix(tableVarName, "Table")
+	if prefix == "" {
+		prefix = tableVarName
+	}
+	return strcase.CamelToSnake(prefix), prefix + "ORM"
+}
+
 // ScanRelations loads Go packages from the specified directory with the given arguments
 // and extracts table relations from orm.Bind calls.
+// Models of tables without orm.Bind are named by namer, defaults to DefaultModelNamer.
+// A table bound in any of the loaded packages is not considered unbound, and
+// names of the created models and ORMs never collide with declarations of the package.
 // Returns a slice of packages containing files with table relations.
-func ScanRelations(fset *token.FileSet, dir string, args []string) ([]*Package, error) {
+func ScanRelations(fset *token.FileSet, dir string, args []string, namer ModelNamer) ([]*Package, error) {
+	if namer == nil {
+		namer = DefaultModelNamer
+	}
 	// Load packages
 	pkgs, err := packages.Load(&packages.Config{
 		Fset: fset,
@@ -79,18 +137,30 @@ func ScanRelations(fset *token.FileSet, dir string, args []string) ([]*Package,
 		return nil, err
 	}
 
-	// Create the result structure
-	var result []*Package
+	// tables bound by orm.Bind in any loaded package, by tableKey
+	bound := make(map[string]bool)
 
-	// Process each package
+	type scannedFile struct {
+		file        *ast.File
+		hasGenerate bool
+		tables      []*TableRelation
+	}
+	type scannedPackage struct {
+		pkg         *packages.Package
+		files       []*scannedFile
+		diagnostics []Diagnostic
+	}
+
+	// First pass: extract the orm.Bind calls of all packages
+	var scanned []*scannedPackage
 	for _, pkg := range pkgs {
-		var files []*File
+		sp := &scannedPackage{pkg: pkg}
+		scanned = append(scanned, sp)
 
 		// Process each file in the package
 		for _, file := range pkg.Syntax {
-			filePath := pkg.Fset.Position(file.Pos()).Filename
-			var tables []*TableRelation
-			var hasGenerate bool
+			sf := &scannedFile{file: file}
+			sp.files = append(sp.files, sf)
 
 			// check go:generate
 			genCmds := gogen.FindGoGenerate(file.Comments, []string{
@@ -100,7 +170,7 @@ func ScanRelations(fset *token.FileSet, dir string, args []string) ([]*Package,
 				"go run github.com/xhd2015/arc-orm/cmd/arc-orm sync", "arc-orm sync",
 			})
 			if len(genCmds) > 0 {
-				hasGenerate = true
+				sf.hasGenerate = true
 			}
 
 			// Process each declaration in the file
@@ -118,79 +188,92 @@ func ScanRelations(fset *token.FileSet, dir string, args []string) ([]*Package,
 					}
 
 					// Process each value in the variable declaration
-					for _, value := range varDecl.Values {
+					for i, value := range varDecl.Values {
 						callExpr, ok := value.(*ast.CallExpr)
 						if !ok {
 							continue
 						}
 
 						// Extract table relation from the call expression
-						relation, err := tryExtractORMTableRelation(callExpr, pkg)
+						relation, tableVar, err := tryExtractORMTableRelation(callExpr, pkg)
 						if err != nil {
-							// Skip this call if there was an error
+							// Skip this call, reporting why
+							sp.diagnostics = append(sp.diagnostics, Diagnostic{
+								Position: pkg.Fset.Position(callExpr.Pos()),
+								Reason:   err.Error(),
+							})
 							continue
 						}
 
 						// Add the relation to our collection if it was extracted successfully
 						if relation != nil {
-							tables = append(tables, relation)
+							if i < len(varDecl.Names) {
+								relation.ORMVarName = varDecl.Names[i].Name
+							}
+							sf.tables = append(sf.tables, relation)
+							bound[tableKey(tableVar)] = true
 						}
 					}
 				}
 			}
+		}
+	}
+
+	// Second pass: create the relations of tables bound nowhere
+	var result []*Package
+	for _, sp := range scanned {
+		pkg := sp.pkg
+		// names declared by the package or taken by created models and ORMs
+		taken := make(map[string]bool)
+		var files []*File
+		for _, sf := range sp.files {
+			tables := sf.tables
+			for _, def := range findTableDefs(pkg, sf.file) {
+				varDef := pkg.TypesInfo.Defs[def.Ident]
+				if varDef == nil {
+					continue
+				}
+				tableVar, ok := varDef.(*types.Var)
+				if !ok || bound[tableKey(tableVar)] {
+					continue
+				}
+
+				baseName, ormVarName := unboundNames(pkg.Name, def.Ident.Name)
+				modelName, optionalModelName := namer(baseName, def.TableName)
+				modelName = freeName(pkg.Types.Scope(), taken, modelName, "Model", isStructType)
+				optionalModelName = freeName(pkg.Types.Scope(), taken, optionalModelName, "Model", isStructType)
+				ormVarName = freeName(pkg.Types.Scope(), taken, ormVarName, "", nil)
+				fields := extractFieldRelations(pkg, tableVar)
+
+				tables = append(tables, &TableRelation{
+					TablVarName:   def.Ident.Name,
+					ORMVarName:    ormVarName,
+					TableName:     def.TableName,
+					Model:         findModelInfoByName(pkg, modelName),
+					OptionalModel: findModelInfoByName(pkg, optionalModelName),
+					Fields:        fields,
+					Indexes:       extractIndexRelations(pkg, tableVar, fields),
+					NeedCreateORM: true,
+				})
+			}
 
 			// Only add file to the result if it has any relations
 			if len(tables) > 0 {
 				files = append(files, &File{
-					AbsFile:     filePath,
-					HasGenerate: hasGenerate,
+					AbsFile:     pkg.Fset.Position(sf.file.Pos()).Filename,
+					HasGenerate: sf.hasGenerate,
 					Tables:      tables,
-					AST:         file,
+					AST:         sf.file,
 				})
-			} else {
-				// no ORM found, find table
-				firstTable, ident, _ := findFirstTableDef(pkg, file)
-				if firstTable != "" {
-					varDef := pkg.TypesInfo.Defs[ident]
-					if varDef == nil {
-						continue
-					}
-					tableVar, ok := varDef.(*types.Var)
-					if !ok {
-						continue
-					}
-
-					modelName := strcase.SnakeToCamel(pkg.Name)
-					optionalModelName := modelName + "Optional"
-
-					model := findModelInfoByName(pkg, modelName)
-					optModel := findModelInfoByName(pkg, optionalModelName)
-
-					// Extract field relations
-					fields := extractFieldRelations(pkg, tableVar)
-
-					files = append(files, &File{
-						AbsFile:     filePath,
-						HasGenerate: hasGenerate,
-						Tables: []*TableRelation{{
-							TablVarName:   ident.Name,
-							TableName:     firstTable,
-							Model:         model,
-							OptionalModel: optModel,
-							Fields:        fields,
-							NeedCreateORM: true,
-						}},
-						AST: file,
-					})
-				}
 			}
 		}
 
-		// Only add package to the result if it has any files with relations
-		if len(files) > 0 {
+		// Only add package to the result if it has any files with relations or diagnostics
+		if len(files) > 0 || len(sp.diagnostics) > 0 {
 			result = append(result, &Package{
-				PkgPath: pkg.PkgPath,
-				Files:   files,
+				PkgPath:     pkg.PkgPath,
+				Files:       files,
+				Diagnostics: sp.diagnostics,
 			})
 		}
 	}
@@ -198,8 +281,50 @@ func ScanRelations(fset *token.FileSet, dir string, args []string) ([]*Package,
 	return result, nil
 }
 
-// tryExtractORMTableRelation extracts a TableRelation from an orm.Bind call expression
-func tryExtractORMTableRelation(callExpr *ast.CallExpr, pkg *packages.Package) (*TableRelation, error) {
+// tableKey identifies a table variable across packages
+func tableKey(tableVar *types.Var) string {
+	if tableVar.Pkg() == nil {
+		return tableVar.Name()
+	}
+	return tableVar.Pkg().Path() + "." + tableVar.Name()
+}
+
+// freeName returns name if the package scope does not declare it, or
+// declares an object accepted by reuse, like the model created by a
+// previous run. Otherwise suffix is appended, then numbers until free.
+// The returned name is marked as taken.
+func freeName(scope *types.Scope, taken map[string]bool, name string, suffix string, reuse func(types.Object) bool) string {
+	free := func(candidate string) bool {
+		if taken[candidate] {
+			return false
+		}
+		obj := scope.Lookup(candidate)
+		return obj == nil || (reuse != nil && reuse(obj))
+	}
+	candidate := name
+	if !free(candidate) {
+		candidate = name + suffix
+		for i := 2; !free(candidate); i++ {
+			candidate = fmt.Sprintf("%s%s%d", name, suffix, i)
+		}
+	}
+	taken[candidate] = true
+	return candidate
+}
+
+// isStructType reports whether obj is a struct type declaration
+func isStructType(obj types.Object) bool {
+	typeName, ok := obj.(*types.TypeName)
+	if !ok {
+		return false
+	}
+	_, ok = typeName.Type().Underlying().(*types.Struct)
+	return ok
+}
+
+// tryExtractORMTableRelation extracts a TableRelation and its table variable from an orm.Bind
+// call expression, returning nil for other calls and an error for orm.Bind calls that cannot be processed
+func tryExtractORMTableRelation(callExpr *ast.CallExpr, pkg *packages.Package) (*TableRelation, *types.Var, error) {
 	typeInfo := pkg.TypesInfo
 
 	// First, check if we're dealing with an orm.Bind call
@@ -208,11 +333,11 @@ func tryExtractORMTableRelation(callExpr *ast.CallExpr, pkg *packages.Package) (
 
 	indexListExpr, ok := callExpr.Fun.(*ast.IndexListExpr)
 	if !ok {
-		return nil, nil
+		return nil, nil, nil
 	}
 	// Check if this is an orm.Bind call with type parameters (Go 1.18+)
 	if indexListExpr.X == nil {
-		return nil, nil
+		return nil, nil, nil
 	}
 	fn := indexListExpr.X
 
@@ -224,41 +349,43 @@ func tryExtractORMTableRelation(callExpr *ast.CallExpr, pkg *packages.Package) (
 	}
 
 	if ident == nil || ident.Name != "Bind" {
-		return nil, nil
+		return nil, nil, nil
 	}
 	use := pkg.TypesInfo.Uses[ident]
 
 	fnType, ok := use.(*types.Func)
 	if !ok {
-		return nil, nil // Not a function, skip
+		return nil, nil, nil // Not a function, skip
 	}
 	if fnType.Name() != "Bind" || fnType.Pkg().Path() != "github.com/xhd2015/arc-orm/orm" {
-		return nil, nil // Not an orm.Bind call, skip
+		return nil, nil, nil // Not an orm.Bind call, skip
 	}
 
 	indices = indexListExpr.Indices
 	// Try to extract model names from type indices
 	for _, idx := range indices {
-		if id, ok := idx.(*ast.Ident); ok {
-			modelNames = append(modelNames, id.Name)
+		id, ok := idx.(*ast.Ident)
+		if !ok {
+			return nil, nil, errors.New("orm.Bind model types must be declared in the same package")
 		}
+		modelNames = append(modelNames, id.Name)
 	}
 
 	// For type checking, use the expression type
 	exprType := typeInfo.TypeOf(callExpr)
 	if !isPtrToORM(exprType) {
-		return nil, nil // Not a ptr to orm.ORM, skip
+		return nil, nil, errors.New("orm.Bind does not type check")
 	}
 
 	// Extract table name from the second argument
 	if len(callExpr.Args) < 2 {
-		return nil, nil
+		return nil, nil, errors.New("orm.Bind requires the table argument")
 	}
 
 	tableArg := callExpr.Args[1]
 	tableVar, tableName := extractRefTableName(pkg, tableArg)
 	if tableName == "" {
-		return nil, nil
+		return nil, nil, fmt.Errorf("cannot resolve table of %s, expect a variable declared as table.New(\"name\")", types.ExprString(tableArg))
 	}
 
 	// Extract model types
@@ -275,11 +402,13 @@ func tryExtractORMTableRelation(callExpr *ast.CallExpr, pkg *packages.Package) (
 
 	// Create and return the table relation
 	return &TableRelation{
+		TablVarName:   tableVar.Name(),
 		TableName:     tableName,
 		Model:         model,
 		OptionalModel: optModel,
 		Fields:        fields,
-	}, nil
+		Indexes:       extractIndexRelations(pkg, tableVar, fields),
+	}, tableVar, nil
 }
 
 // findModelInfoByName searches for a struct type with the given name in the package
@@ -407,15 +536,42 @@ func extractRefTableName(pkg *packages.Package, expr ast.Expr) (*types.Var, stri
 }
 
 func extractTableFromVar(pkg *packages.Package, tableVar *types.Var) string {
-	// typeInfo.Defs[]
-	name := tableVar.Name()
-	tablePkg := tableVar.Pkg()
-	if tablePkg.Path() != pkg.PkgPath {
+	// the table may be defined in an imported package
+	defPkg := definingPackage(pkg, tableVar)
+	if defPkg == nil {
 		return ""
 	}
+	_, value := findVarDef(defPkg, tableVar.Name())
+	return extractTableFromVarDef(defPkg.TypesInfo, value)
+}
 
-	_, value := findVarDef(pkg, name)
-	return extractTableFromVarDef(pkg.TypesInfo, value)
+// definingPackage returns the loaded package declaring obj,
+// which is either pkg or one of its transitive imports
+func definingPackage(pkg *packages.Package, obj types.Object) *packages.Package {
+	if obj.Pkg() == nil {
+		return nil
+	}
+	path := obj.Pkg().Path()
+	seen := make(map[string]bool)
+	queue := []*packages.Package{pkg}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if seen[p.PkgPath] {
+			continue
+		}
+		seen[p.PkgPath] = true
+		if p.PkgPath == path {
+			if p.TypesInfo == nil {
+				return nil
+			}
+			return p
+		}
+		for _, imp := range p.Imports {
+			queue = append(queue, imp)
+		}
+	}
+	return nil
 }
 
 func extractTableFromVarDef(typeInfo *types.Info, expr ast.Expr) string {
@@ -493,24 +649,26 @@ func findVarDef(pkg *packages.Package, name string) (*ast.Ident, ast.Expr) {
 	return ident, expr
 }
 
-func findFirstTableDef(pkg *packages.Package, wantFile *ast.File) (string, *ast.Ident, ast.Expr) {
-	var tableName string
-	var ident *ast.Ident
-	var expr ast.Expr
+// tableDef is a variable defined by table.New
+type tableDef struct {
+	TableName string
+	Ident     *ast.Ident
+}
+
+// findTableDefs finds the table.New variables of the file in declaration order
+func findTableDefs(pkg *packages.Package, wantFile *ast.File) []tableDef {
+	var defs []tableDef
 	forEachVarDef(pkg, func(file *ast.File, spec *ast.ValueSpec, varName *ast.Ident, value ast.Expr) bool {
 		if file != wantFile {
 			return false
 		}
 		resolvedName := extractTableFromVarDef(pkg.TypesInfo, value)
 		if resolvedName != "" {
-			tableName = resolvedName
-			ident = varName
-			expr = value
-			return true
+			defs = append(defs, tableDef{TableName: resolvedName, Ident: varName})
 		}
 		return false
 	})
-	return tableName, ident, expr
+	return defs
 }
 
 // extractModelInfo extracts information about a model struct from its type expression
@@ -568,10 +726,44 @@ func extractModelInfo(expr ast.Expr, typeInfo *types.Info, pkg *packages.Package
 	return info
 }
 
+// unwrapFieldDecls returns the innermost call of a chain
+// like Table.String("name").NotNull().MaxLen(255)
+func unwrapFieldDecls(expr ast.Expr) (*ast.CallExpr, bool) {
+	callExpr, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	for {
+		sel, ok := callExpr.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return callExpr, true
+		}
+		inner, ok := sel.X.(*ast.CallExpr)
+		if !ok {
+			return callExpr, true
+		}
+		callExpr = inner
+	}
+}
+
+// isIndexMethod reports whether the Table method declares an index
+func isIndexMethod(name string) bool {
+	switch name {
+	case "Index", "Unique", "PrimaryKey":
+		return true
+	}
+	return false
+}
+
 // extractFieldRelations finds field definitions in the package
 func extractFieldRelations(pkg *packages.Package, tableVar *types.Var) []FieldRelation {
 	var fields []FieldRelation
 
+	// fields are defined next to the table, possibly in an imported package
+	if defPkg := definingPackage(pkg, tableVar); defPkg != nil {
+		pkg = defPkg
+	}
+
 	for _, file := range pkg.Syntax {
 		for _, decl := range file.Decls {
 			genDecl, ok := decl.(*ast.GenDecl)
@@ -590,8 +782,9 @@ func extractFieldRelations(pkg *packages.Package, tableVar *types.Var) []FieldRe
 						continue
 					}
 
-					// Check if this is a field definition (like ID = Table.Int64("id"))
-					callExpr, ok := valueSpec.Values[i].(*ast.CallExpr)
+					// Check if this is a field definition (like ID = Table.Int64("id")),
+					// possibly followed by declarations like .NotNull()
+					callExpr, ok := unwrapFieldDecls(valueSpec.Values[i])
 					if !ok {
 						continue
 					}
@@ -614,6 +807,9 @@ func extractFieldRelations(pkg *packages.Package, tableVar *types.Var) []FieldRe
 					if useVar != tableVar {
 						continue
 					}
+					if isIndexMethod(selExpr.Sel.Name) {
+						continue
+					}
 
 					// Check if there's an argument for the column name
 					if len(callExpr.Args) == 0 {
@@ -639,6 +835,7 @@ func extractFieldRelations(pkg *packages.Package, tableVar *types.Var) []FieldRe
 						IsPrimary: columnName == "id",
 						IsIndex:   false,
 						IsUnique:  false,
+						Node:      callExpr,
 					}
 					fields = append(fields, field)
 				}
@@ -648,3 +845,70 @@ func extractFieldRelations(pkg *packages.Package, tableVar *types.Var) []FieldRe
 
 	return fields
 }
+
+// extractIndexRelations finds index declarations of the table,
+// resolving their field arguments to columns
+func extractIndexRelations(pkg *packages.Package, tableVar *types.Var, fields []FieldRelation) []IndexRelation {
+	if defPkg := definingPackage(pkg, tableVar); defPkg != nil {
+		pkg = defPkg
+	}
+	columns := make(map[string]string, len(fields))
+	for _, f := range fields {
+		columns[f.FieldName] = f.ColumnName
+	}
+
+	var indexes []IndexRelation
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for _, value := range valueSpec.Values {
+					callExpr, ok := value.(*ast.CallExpr)
+					if !ok {
+						continue
+					}
+					selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+					if !ok || !isIndexMethod(selExpr.Sel.Name) {
+						continue
+					}
+					tableIdent, ok := selExpr.X.(*ast.Ident)
+					if !ok || pkg.TypesInfo.Uses[tableIdent] != tableVar {
+						continue
+					}
+
+					index := IndexRelation{Kind: selExpr.Sel.Name, Name: "PRIMARY"}
+					args := callExpr.Args
+					if index.Kind != "PrimaryKey" {
+						if len(args) == 0 {
+							continue
+						}
+						lit, ok := args[0].(*ast.BasicLit)
+						if !ok || lit.Kind != token.STRING {
+							continue
+						}
+						index.Name = strings.Trim(lit.Value, "\"")
+						args = args[1:]
+					}
+					for _, arg := range args {
+						ident, ok := arg.(*ast.Ident)
+						if !ok {
+							continue
+						}
+						if column, ok := columns[ident.Name]; ok {
+							index.Columns = append(index.Columns, column)
+						}
+					}
+					indexes = append(indexes, index)
+				}
+			}
+		}
+	}
+	return indexes
+}
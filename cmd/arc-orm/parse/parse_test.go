@@ -7,6 +7,7 @@ import (
 	"go/types"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -194,6 +195,15 @@ func TestExtractFieldRelations(t *testing.T) {
 	for field := range expectedFields {
 		t.Errorf("Expected field relation for %q not found", field)
 	}
+
+	indexes := extractIndexRelations(pkg, tableVarDef, fieldRelations)
+	expectedIndexes := []IndexRelation{
+		{Kind: "Unique", Name: "uk_email", Columns: []string{"email"}},
+		{Kind: "Index", Name: "idx_name_created", Columns: []string{"name", "create_time"}},
+	}
+	if !reflect.DeepEqual(indexes, expectedIndexes) {
+		t.Errorf("Expected indexes %v, got %v", expectedIndexes, indexes)
+	}
 }
 
 func TestIsOrmBind(t *testing.T) {
@@ -255,11 +265,16 @@ var Table = table.New("test_users")
 var (
 	ID         = Table.Int64("id")
 	Name       = Table.String("name")
-	Email      = Table.String("email")
+	Email      = Table.String("email").NotNull().MaxLen(255)
 	CreateTime = Table.Time("create_time")
 	UpdateTime = Table.Time("update_time")
 )
 
+var (
+	UniqueEmail    = Table.Unique("uk_email", Email)
+	IdxNameCreated = Table.Index("idx_name_created", Name, CreateTime)
+)
+
 var ORM = orm.Bind[User, UserOptional](nil, Table)
 
 type User struct {
@@ -300,7 +315,7 @@ func TestLoadAndExtractRelations(t *testing.T) {
 
 	// Call LoadAndExtractRelations
 	fset := token.NewFileSet()
-	pkgResults, err := ScanRelations(fset, tmpDir, []string{"./..."})
+	pkgResults, err := ScanRelations(fset, tmpDir, []string{"./..."}, nil)
 	if err != nil {
 		t.Fatalf("LoadAndExtractRelations failed: %v", err)
 	}
@@ -367,3 +382,140 @@ func TestLoadAndExtractRelations(t *testing.T) {
 		}
 	}
 }
+
+// TestScanRelations_CrossPackage tests binding an ORM to a table defined in another package
+func TestScanRelations_CrossPackage(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	err := os.MkdirAll(filepath.Join(tmpDir, "schema"), 0755)
+	if err != nil {
+		t.Fatalf("Failed to create schema dir: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(tmpDir, "schema", "schema.go"), []byte(`package schema
+
+import "github.com/xhd2015/arc-orm/table"
+
+var Orders = table.New("orders")
+
+var (
+	OrderId     = Orders.Int64("id")
+	OrderAmount = Orders.Float64("amount")
+)
+`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write schema: %v", err)
+	}
+	err = os.MkdirAll(filepath.Join(tmpDir, "order"), 0755)
+	if err != nil {
+		t.Fatalf("Failed to create order dir: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(tmpDir, "order", "order.go"), []byte(`package order
+
+import (
+	"github.com/xhd2015/arc-orm/orm"
+	"testormx/schema"
+)
+
+var ORM = orm.Bind[Order, OrderOptional](nil, schema.Orders)
+
+type Order struct {
+	Id     int64
+	Amount float64
+}
+
+type OrderOptional struct {
+	Id     *int64
+	Amount *float64
+}
+`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write order: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := ScanRelations(fset, tmpDir, []string{"./order"}, nil)
+	if err != nil {
+		t.Fatalf("ScanRelations failed: %v", err)
+	}
+	if len(pkgs) != 1 || len(pkgs[0].Files) != 1 || len(pkgs[0].Files[0].Tables) != 1 {
+		t.Fatalf("Expected one table relation, got %+v", pkgs)
+	}
+	rel := pkgs[0].Files[0].Tables[0]
+	if rel.TableName != "orders" || rel.TablVarName != "Orders" {
+		t.Errorf("Unexpected table: %s %s", rel.TableName, rel.TablVarName)
+	}
+	var columns []string
+	for _, f := range rel.Fields {
+		columns = append(columns, f.ColumnName+":"+f.Type)
+	}
+	if strings.Join(columns, ",") != "id:Int64,amount:Float64" {
+		t.Errorf("Unexpected fields: %v", columns)
+	}
+
+	// loading the whole module, the schema table is bound by order
+	pkgs, err = ScanRelations(fset, tmpDir, []string{"./..."}, nil)
+	if err != nil {
+		t.Fatalf("ScanRelations failed: %v", err)
+	}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, rel := range file.Tables {
+				if rel.NeedCreateORM {
+					t.Errorf("Expected %s bound in order, got a new ORM in %s", rel.TablVarName, pkg.PkgPath)
+				}
+			}
+		}
+	}
+}
+
+// TestScanRelations_Diagnostics tests reporting orm.Bind calls that cannot be processed
+func TestScanRelations_Diagnostics(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	defer os.RemoveAll(tmpDir)
+
+	err := os.MkdirAll(filepath.Join(tmpDir, "bad"), 0755)
+	if err != nil {
+		t.Fatalf("Failed to create bad dir: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(tmpDir, "bad", "bad.go"), []byte(`package bad
+
+import (
+	"github.com/xhd2015/arc-orm/orm"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+var Table = newTable()
+
+func newTable() *table.Table {
+	return table.New("bad")
+}
+
+var ORM = orm.Bind[Bad, BadOptional](nil, Table)
+
+type Bad struct {
+	Id int64
+}
+
+type BadOptional struct {
+	Id *int64
+}
+`), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write bad: %v", err)
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := ScanRelations(fset, tmpDir, []string{"./bad"}, nil)
+	if err != nil {
+		t.Fatalf("ScanRelations failed: %v", err)
+	}
+	if len(pkgs) != 1 || len(pkgs[0].Diagnostics) != 1 {
+		t.Fatalf("Expected one diagnostic, got %+v", pkgs)
+	}
+	got := pkgs[0].Diagnostics[0].String()
+	want := `bad.go:14:11: cannot resolve table of Table, expect a variable declared as table.New("name")`
+	if !strings.HasSuffix(got, want) {
+		t.Errorf("Expected diagnostic ending with %q, got %q", want, got)
+	}
+}
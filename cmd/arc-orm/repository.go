@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/cmd/arc-orm/parse"
+)
+
+// repositoryFile returns the file holding the repository scaffolding
+// of a table file, e.g. table.go -> table_repository.go
+func repositoryFile(file string) string {
+	return strings.TrimSuffix(file, ".go") + "_repository.go"
+}
+
+// repositoryChange scaffolds a repository interface and its implementation
+// calling the ORM for each table defined in file. The file is owned by
+// the user afterwards, so it is only created when absent.
+func repositoryChange(file *parse.File) (*fileChange, error) {
+	target := repositoryFile(file.AbsFile)
+	_, err := os.Stat(target)
+	if err == nil {
+		return nil, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "package %s\n\nimport \"context\"\n", file.AST.Name.Name)
+	for _, table := range file.Tables {
+		writeRepository(&b, table)
+	}
+	return formatChange(target, nil, []byte(b.String()))
+}
+
+func writeRepository(b *strings.Builder, table *parse.TableRelation) {
+	model := table.Model.Name
+	optional := table.OptionalModel.Name
+	repo := model + "Repository"
+	impl := strings.ToLower(repo[:1]) + repo[1:]
+	orm := table.ORMVarName
+
+	fmt.Fprintf(b, `
+// %s is the data access layer of the %s table,
+// both %s and the generated fake implement it
+type %s interface {
+	Insert(ctx context.Context, model *%s) (int64, error)
+	GetByID(ctx context.Context, id int64) (*%s, error)
+	FindByID(ctx context.Context, id int64) (*%s, error)
+	GetBy(ctx context.Context, condition *%s) (*%s, error)
+	UpdateByID(ctx context.Context, id int64, data *%s) error
+	UpdateBy(ctx context.Context, condition *%s, data *%s) error
+	DeleteByID(ctx context.Context, id int64) error
+	DeleteBy(ctx context.Context, condition *%s) error
+}
+
+// New%s creates a %s backed by %s
+func New%s() %s {
+	return &%s{}
+}
+
+type %s struct{}
+
+func (r *%s) Insert(ctx context.Context, model *%s) (int64, error) {
+	return %s.Insert(ctx, model)
+}
+
+func (r *%s) GetByID(ctx context.Context, id int64) (*%s, error) {
+	return %s.GetByID(ctx, id)
+}
+
+func (r *%s) FindByID(ctx context.Context, id int64) (*%s, error) {
+	return %s.FindByID(ctx, id)
+}
+
+func (r *%s) GetBy(ctx context.Context, condition *%s) (*%s, error) {
+	return %s.GetBy(ctx, condition)
+}
+
+func (r *%s) UpdateByID(ctx context.Context, id int64, data *%s) error {
+	return %s.UpdateByID(ctx, id, data)
+}
+
+func (r *%s) UpdateBy(ctx context.Context, condition *%s, data *%s) error {
+	return %s.UpdateBy(ctx, condition, data)
+}
+
+func (r *%s) DeleteByID(ctx context.Context, id int64) error {
+	return %s.DeleteByID(ctx, id)
+}
+
+func (r *%s) DeleteBy(ctx context.Context, condition *%s) error {
+	return %s.DeleteBy(ctx, condition)
+}
+`,
+		repo, table.TableName, orm,
+		repo, model, model, model, optional, model, optional, optional, optional, optional,
+		repo, repo, orm, repo, repo, impl,
+		impl,
+		impl, model, orm,
+		impl, model, orm,
+		impl, model, orm,
+		impl, optional, model, orm,
+		impl, optional, orm,
+		impl, optional, optional, orm,
+		impl, orm,
+		impl, optional, orm)
+}
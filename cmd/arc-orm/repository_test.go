@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/xhd2015/xgo/support/assert"
+	"github.com/xhd2015/xgo/support/cmd"
+)
+
+const repositoryUsage = `package testorm
+
+var _ UserRepository = NewUserRepository()
+var _ UserRepository = ORM
+var _ UserRepository = NewFakeUserORM()
+`
+
+// TestGen_Repository tests scaffolding a repository that compiles and is kept once edited
+func TestGen_Repository(t *testing.T) {
+	tmpDir, file := setupTestDir(t, FullDefiniton)
+	defer os.RemoveAll(tmpDir)
+
+	err := gen([]string{"--dir=" + tmpDir, "--repository", "--mocks"})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	err = os.WriteFile(filepath.Join(tmpDir, "usage.go"), []byte(repositoryUsage), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write usage: %v", err)
+	}
+	output, err := cmd.Dir(tmpDir).Output("go", "vet", "./...")
+	if err != nil {
+		t.Fatalf("Failed to compile repository: %v\n%s", err, output)
+	}
+
+	edited := []byte("package testorm\n\n// edited by hand\n")
+	err = os.WriteFile(repositoryFile(file), edited, 0644)
+	if err != nil {
+		t.Fatalf("Failed to write repository: %v", err)
+	}
+	err = os.Remove(filepath.Join(tmpDir, "usage.go"))
+	if err != nil {
+		t.Fatalf("Failed to remove usage: %v", err)
+	}
+	err = gen([]string{"--dir=" + tmpDir, "--repository"})
+	if err != nil {
+		t.Fatalf("Failed to run gen: %v", err)
+	}
+	content, err := os.ReadFile(repositoryFile(file))
+	if err != nil {
+		t.Fatalf("Failed to read repository: %v", err)
+	}
+	if diff := assert.Diff(string(edited), string(content)); diff != "" {
+		t.Error(diff)
+	}
+}
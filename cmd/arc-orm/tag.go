@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/xhd2015/less-gen/strcase"
+)
+
+// tag cases of generated struct tag values
+const (
+	tagCaseSnake      = "snake"       // user_id
+	tagCaseCamel      = "camel"       // UserId
+	tagCaseLowerCamel = "lower_camel" // userId
+)
+
+func checkTagCase(tagCase string) error {
+	switch tagCase {
+	case "", tagCaseSnake, tagCaseCamel, tagCaseLowerCamel:
+		return nil
+	}
+	return fmt.Errorf("unknown tag_case: %s, expect one of %s, %s, %s", tagCase, tagCaseSnake, tagCaseCamel, tagCaseLowerCamel)
+}
+
+// tagValue converts the column name according to the configured tag case
+func (c *config) tagValue(column string) string {
+	switch c.TagCase {
+	case tagCaseCamel:
+		return strcase.SnakeToCamel(column)
+	case tagCaseLowerCamel:
+		return strcase.Decapitalize(strcase.SnakeToCamel(column))
+	}
+	return column
+}
+
+// fieldTag returns the struct tag of a model field, adding the configured
+// tags missing from the current tag while keeping existing ones as is
+func (c *config) fieldTag(current string, column string) string {
+	if len(c.Tags) == 0 {
+		return current
+	}
+	pairs := parseTagPairs(current)
+	has := make(map[string]bool, len(pairs))
+	for _, pair := range pairs {
+		has[pair[0]] = true
+	}
+	value := c.tagValue(column)
+	for _, key := range c.Tags {
+		if !has[key] {
+			pairs = append(pairs, [2]string{key, value})
+			has[key] = true
+		}
+	}
+	parts := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		parts = append(parts, pair[0]+":"+strconv.Quote(pair[1]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseTagPairs parses a struct tag like `json:"id" db:"id"`
+// into ordered key-value pairs, following reflect.StructTag conventions
+func parseTagPairs(tag string) [][2]string {
+	var pairs [][2]string
+	for tag != "" {
+		tag = strings.TrimLeft(tag, " ")
+		if tag == "" {
+			break
+		}
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			break
+		}
+		tag = tag[i+1:]
+		pairs = append(pairs, [2]string{key, value})
+	}
+	return pairs
+}
+
+// tagColumn returns the column declared by an `orm:"column:name"`
+// struct tag, or empty if absent
+func tagColumn(tag string) string {
+	for _, pair := range parseTagPairs(tag) {
+		if pair[0] != "orm" {
+			continue
+		}
+		for _, opt := range strings.Split(pair[1], ";") {
+			opt = strings.TrimSpace(opt)
+			if strings.HasPrefix(opt, "column:") {
+				return strings.TrimSpace(strings.TrimPrefix(opt, "column:"))
+			}
+		}
+	}
+	return ""
+}
+
+// tagIgnored reports whether a struct tag has `orm:"-"`,
+// marking a model field mapped to no column
+func tagIgnored(tag string) bool {
+	for _, pair := range parseTagPairs(tag) {
+		if pair[0] == "orm" {
+			return strings.TrimSpace(pair[1]) == "-"
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+// Package ddl generates MySQL DDL statements from table definitions
+// and compares table definitions against a live database schema
+package ddl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// ColumnType returns the MySQL column type used for a table field
+func ColumnType(f field.Field) string {
+	switch f := f.(type) {
+	case field.Int64Field:
+		return "BIGINT"
+	case field.Int32Field:
+		return "INT"
+	case field.Float64Field:
+		return "DOUBLE"
+	case field.StringField:
+		if n := f.Constraints().MaxLen; n > 0 {
+			return fmt.Sprintf("VARCHAR(%d)", n)
+		}
+		return "VARCHAR(255)"
+	case field.UuidField:
+		return "CHAR(36)"
+	case field.TimeField:
+		return "DATETIME"
+	case field.BoolField:
+		return "TINYINT(1)"
+	}
+	return ""
+}
+
+// ColumnDefinition returns the column definition used in
+// CREATE TABLE and ALTER TABLE statements, e.g. `age` BIGINT NOT NULL DEFAULT 0
+func ColumnDefinition(f field.Field) (string, error) {
+	if err := field.ValidateIdentifier(f.Name()); err != nil {
+		return "", err
+	}
+	colType := ColumnType(f)
+	if colType == "" {
+		return "", fmt.Errorf("unsupported field type for column %s: %T", f.Name(), f)
+	}
+	def := quote(f.Name()) + " " + colType + " NOT NULL"
+	if f.Name() == "id" {
+		return def + " AUTO_INCREMENT", nil
+	}
+	if d, ok := f.(field.Defaulted); ok {
+		if value, ok := d.DefaultValue(); ok {
+			def += " DEFAULT " + defaultLiteral(value)
+			if f.Name() == "update_time" {
+				def += " ON UPDATE CURRENT_TIMESTAMP"
+			}
+			return def, nil
+		}
+	}
+	switch f.(type) {
+	case field.StringField, field.UuidField:
+		def += " DEFAULT ''"
+	case field.TimeField:
+		def += " DEFAULT CURRENT_TIMESTAMP"
+		if f.Name() == "update_time" {
+			def += " ON UPDATE CURRENT_TIMESTAMP"
+		}
+	default:
+		def += " DEFAULT 0"
+	}
+	return def, nil
+}
+
+// defaultLiteral formats a declared default value as a SQL literal
+func defaultLiteral(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case time.Time:
+		return "'" + v.Format("2006-01-02 15:04:05") + "'"
+	}
+	return fmt.Sprint(value)
+}
+
+// CreateTable generates the CREATE TABLE statement for a table
+func CreateTable(t table.Table) (string, error) {
+	if err := field.ValidateTable(t.Name()); err != nil {
+		return "", err
+	}
+	var lines []string
+	var hasID bool
+	for _, f := range t.Fields() {
+		def, err := ColumnDefinition(f)
+		if err != nil {
+			return "", err
+		}
+		if f.Name() == "id" {
+			hasID = true
+		}
+		lines = append(lines, "  "+def)
+	}
+	if len(lines) == 0 {
+		return "", fmt.Errorf("table %s has no fields", t.Name())
+	}
+	if pk, ok := t.Primary(); ok {
+		lines = append(lines, "  PRIMARY KEY "+indexColumns(pk))
+	} else if hasID {
+		lines = append(lines, "  PRIMARY KEY (`id`)")
+	}
+	for _, idx := range t.Indexes() {
+		switch idx.Kind {
+		case table.IndexUnique:
+			lines = append(lines, "  UNIQUE KEY "+quote(idx.Name)+" "+indexColumns(idx))
+		case table.IndexPlain:
+			lines = append(lines, "  KEY "+quote(idx.Name)+" "+indexColumns(idx))
+		}
+	}
+	return "CREATE TABLE " + field.QuoteTable(t.Name()) + " (\n" + strings.Join(lines, ",\n") + "\n);", nil
+}
+
+// Compatible reports whether a table field can be mapped to
+// a column of the given MySQL data type (information_schema DATA_TYPE)
+func Compatible(f field.Field, dataType string) bool {
+	dataType = strings.ToLower(dataType)
+	switch f.(type) {
+	case field.Int64Field:
+		return oneOf(dataType, "bigint", "int", "integer", "mediumint", "smallint", "tinyint")
+	case field.Int32Field:
+		return oneOf(dataType, "int", "integer", "mediumint", "smallint", "tinyint")
+	case field.Float64Field:
+		return oneOf(dataType, "double", "float", "decimal", "real")
+	case field.StringField:
+		return oneOf(dataType, "varchar", "char", "text", "tinytext", "mediumtext", "longtext", "enum", "set", "json")
+	case field.UuidField:
+		return oneOf(dataType, "char", "varchar")
+	case field.TimeField:
+		return oneOf(dataType, "datetime", "timestamp", "date")
+	case field.BoolField:
+		return oneOf(dataType, "tinyint", "bit", "bool", "boolean")
+	}
+	return false
+}
+
+// Widens reports whether changing a column of the given MySQL data type
+// to the declared type of the field keeps all its values, so MODIFY COLUMN
+// is safe. Only data types are compared, not lengths.
+func Widens(f field.Field, dataType string) bool {
+	dataType = strings.ToLower(dataType)
+	switch f.(type) {
+	case field.Int64Field:
+		return oneOf(dataType, "bigint", "int", "integer", "mediumint", "smallint", "tinyint")
+	case field.Int32Field:
+		return oneOf(dataType, "int", "integer", "mediumint", "smallint", "tinyint")
+	case field.Float64Field:
+		return oneOf(dataType, "double", "float", "real", "int", "integer", "mediumint", "smallint", "tinyint")
+	case field.StringField:
+		return oneOf(dataType, "varchar", "char", "bigint", "int", "integer", "mediumint", "smallint", "tinyint",
+			"double", "float", "real", "decimal", "datetime", "timestamp", "date", "time", "year")
+	case field.UuidField:
+		return oneOf(dataType, "char", "varchar")
+	case field.TimeField:
+		return oneOf(dataType, "datetime", "timestamp", "date")
+	case field.BoolField:
+		return oneOf(dataType, "tinyint", "bit", "bool", "boolean")
+	}
+	return false
+}
+
+// indexColumns returns the quoted column list of an index, like (`a`, `b`)
+func indexColumns(idx table.Index) string {
+	columns := idx.Columns()
+	for i, column := range columns {
+		columns[i] = quote(column)
+	}
+	return "(" + strings.Join(columns, ", ") + ")"
+}
+
+// AddIndex returns the ALTER TABLE statement adding the index
+func AddIndex(tableName string, idx table.Index) string {
+	alter := "ALTER TABLE " + quote(tableName)
+	switch idx.Kind {
+	case table.IndexPrimary:
+		return alter + " ADD PRIMARY KEY " + indexColumns(idx) + ";"
+	case table.IndexUnique:
+		return alter + " ADD UNIQUE INDEX " + quote(idx.Name) + " " + indexColumns(idx) + ";"
+	}
+	return alter + " ADD INDEX " + quote(idx.Name) + " " + indexColumns(idx) + ";"
+}
+
+func oneOf(s string, list ...string) bool {
+	for _, v := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+func quote(name string) string {
+	return "`" + name + "`"
+}
@@ -0,0 +1,288 @@
+package ddl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/table"
+	"github.com/xhd2015/xgo/support/assert"
+)
+
+func newUserTable() table.Table {
+	t := table.New("users")
+	t.Int64("id")
+	t.String("name")
+	t.Int32("age")
+	t.Bool("active")
+	t.Float64("score")
+	t.Time("create_time")
+	t.Time("update_time")
+	return t
+}
+
+func TestCreateTable(t *testing.T) {
+	stmt, err := CreateTable(newUserTable())
+	if err != nil {
+		t.Fatalf("Failed to generate CREATE TABLE: %v", err)
+	}
+	expected := "CREATE TABLE `users` (\n" +
+		"  `id` BIGINT NOT NULL AUTO_INCREMENT,\n" +
+		"  `name` VARCHAR(255) NOT NULL DEFAULT '',\n" +
+		"  `age` INT NOT NULL DEFAULT 0,\n" +
+		"  `active` TINYINT(1) NOT NULL DEFAULT 0,\n" +
+		"  `score` DOUBLE NOT NULL DEFAULT 0,\n" +
+		"  `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+		"  `update_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		");"
+	if diff := assert.Diff(expected, stmt); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCreateTable_Indexes(t *testing.T) {
+	tbl := table.New("user_roles")
+	userID := tbl.Int64("user_id")
+	role := tbl.String("role")
+	createTime := tbl.Time("create_time")
+	tbl.PrimaryKey(userID, role)
+	tbl.Unique("uk_role_user", role, userID)
+	tbl.Index("idx_user_time", userID, createTime)
+
+	stmt, err := CreateTable(tbl)
+	if err != nil {
+		t.Fatalf("Failed to generate CREATE TABLE: %v", err)
+	}
+	expected := "CREATE TABLE `user_roles` (\n" +
+		"  `user_id` BIGINT NOT NULL DEFAULT 0,\n" +
+		"  `role` VARCHAR(255) NOT NULL DEFAULT '',\n" +
+		"  `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+		"  PRIMARY KEY (`user_id`, `role`),\n" +
+		"  UNIQUE KEY `uk_role_user` (`role`, `user_id`),\n" +
+		"  KEY `idx_user_time` (`user_id`, `create_time`)\n" +
+		");"
+	if diff := assert.Diff(expected, stmt); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCreateTable_Qualified(t *testing.T) {
+	tbl := table.NewIn("analytics", "events")
+	tbl.Int64("id")
+
+	stmt, err := CreateTable(tbl)
+	if err != nil {
+		t.Fatalf("Failed to generate CREATE TABLE: %v", err)
+	}
+	expected := "CREATE TABLE `analytics`.`events` (\n" +
+		"  `id` BIGINT NOT NULL AUTO_INCREMENT,\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		");"
+	if diff := assert.Diff(expected, stmt); diff != "" {
+		t.Error(diff)
+	}
+
+	cond, args := tableCondition(tbl.Name())
+	if cond != "`TABLE_SCHEMA` = ? AND `TABLE_NAME` = ?" || len(args) != 2 || args[0] != "analytics" || args[1] != "events" {
+		t.Errorf("Expected the condition to match the qualifying schema, got %s %v", cond, args)
+	}
+}
+
+func TestColumnType_MaxLen(t *testing.T) {
+	tbl := table.New("users")
+	name := tbl.String("name").MaxLen(64)
+	if got := ColumnType(name); got != "VARCHAR(64)" {
+		t.Errorf("Expected VARCHAR(64), got %s", got)
+	}
+}
+
+func TestColumnDefinition_Default(t *testing.T) {
+	tbl := table.New("users")
+	tests := []struct {
+		field    field.Field
+		expected string
+	}{
+		{tbl.Int64("status").Default(1), "`status` BIGINT NOT NULL DEFAULT 1"},
+		{tbl.String("role").Default("it's"), "`role` VARCHAR(255) NOT NULL DEFAULT 'it''s'"},
+		{tbl.Bool("active").Default(true), "`active` TINYINT(1) NOT NULL DEFAULT 1"},
+	}
+	for _, tt := range tests {
+		def, err := ColumnDefinition(tt.field)
+		if err != nil {
+			t.Fatalf("Failed to generate column definition: %v", err)
+		}
+		if def != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, def)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	columns := []*Column{
+		{ColumnName: "id", DataType: "bigint"},
+		{ColumnName: "name", DataType: "varchar"},
+		{ColumnName: "age", DataType: "bigint", ColumnType: "bigint(20)"},
+		{ColumnName: "active", DataType: "tinyint"},
+		{ColumnName: "legacy", DataType: "text"},
+		{ColumnName: "create_time", DataType: "timestamp"},
+		{ColumnName: "update_time", DataType: "datetime"},
+	}
+	diff := Compare(newUserTable(), columns)
+	if diff.Empty() {
+		t.Fatalf("Expected differences, got none")
+	}
+
+	// narrowing age to INT and dropping legacy lose data, left by hand
+	stmts, err := diff.Statements()
+	if err != nil {
+		t.Fatalf("Failed to generate statements: %v", err)
+	}
+	expected := []string{
+		"ALTER TABLE `users` ADD COLUMN `score` DOUBLE NOT NULL DEFAULT 0;",
+	}
+	if diff := assert.Diff(expected, stmts); diff != "" {
+		t.Error(diff)
+	}
+	expectedSkipped := []string{
+		"column age: changing bigint(20) to INT may lose data",
+		"column legacy: not declared, not dropped",
+	}
+	if diff := assert.Diff(expectedSkipped, diff.Skipped()); diff != "" {
+		t.Error(diff)
+	}
+
+	diff.DropColumns = true
+	stmts, err = diff.Statements()
+	if err != nil {
+		t.Fatalf("Failed to generate statements: %v", err)
+	}
+	expected = append(expected, "ALTER TABLE `users` DROP COLUMN `legacy`;")
+	if diff := assert.Diff(expected, stmts); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestCompare_Widening(t *testing.T) {
+	tbl := table.New("users")
+	tbl.Int64("id")
+	tbl.String("code")
+	tbl.Float64("score")
+	diff := Compare(tbl, []*Column{
+		{ColumnName: "id", DataType: "bigint"},
+		{ColumnName: "code", DataType: "int"},
+		{ColumnName: "score", DataType: "int"},
+	})
+	stmts, err := diff.Statements()
+	if err != nil {
+		t.Fatalf("Failed to generate statements: %v", err)
+	}
+	expected := []string{
+		"ALTER TABLE `users` MODIFY COLUMN `code` VARCHAR(255) NOT NULL DEFAULT '';",
+		"ALTER TABLE `users` MODIFY COLUMN `score` DOUBLE NOT NULL DEFAULT 0;",
+	}
+	if diff := assert.Diff(expected, stmts); diff != "" {
+		t.Error(diff)
+	}
+	if skipped := diff.Skipped(); len(skipped) != 0 {
+		t.Errorf("Expected nothing skipped, got %v", skipped)
+	}
+}
+
+func TestCompare_MissingTable(t *testing.T) {
+	diff := Compare(newUserTable(), nil)
+	if !diff.Missing {
+		t.Fatalf("Expected table to be missing")
+	}
+	stmts, err := diff.Statements()
+	if err != nil {
+		t.Fatalf("Failed to generate statements: %v", err)
+	}
+	if len(stmts) != 1 || stmts[0][:len("CREATE TABLE")] != "CREATE TABLE" {
+		t.Errorf("Expected a single CREATE TABLE statement, got %v", stmts)
+	}
+}
+
+func TestCompare_UpToDate(t *testing.T) {
+	tbl := table.New("users")
+	tbl.Int64("id")
+	tbl.String("name")
+	diff := Compare(tbl, []*Column{
+		{ColumnName: "id", DataType: "int"},
+		{ColumnName: "name", DataType: "text"},
+	})
+	if !diff.Empty() {
+		t.Errorf("Expected no differences, got %+v", diff)
+	}
+}
+
+func TestCompareIndexes(t *testing.T) {
+	tbl := newUserTable()
+	var name, createTime field.Field
+	for _, f := range tbl.Fields() {
+		switch f.Name() {
+		case "name":
+			name = f
+		case "create_time":
+			createTime = f
+		}
+	}
+	tbl.Unique("uk_name", name)
+	tbl.Index("idx_create_time", createTime)
+
+	diff := Compare(tbl, []*Column{
+		{ColumnName: "id", DataType: "bigint"},
+		{ColumnName: "name", DataType: "varchar"},
+		{ColumnName: "age", DataType: "int"},
+		{ColumnName: "active", DataType: "tinyint"},
+		{ColumnName: "score", DataType: "double"},
+		{ColumnName: "create_time", DataType: "datetime"},
+		{ColumnName: "update_time", DataType: "datetime"},
+	})
+	diff.CompareIndexes([]*IndexColumn{
+		{IndexName: "PRIMARY", ColumnName: "id"},
+		{IndexName: "uk_name", ColumnName: "name"},
+	})
+	if diff.Empty() {
+		t.Fatal("Expected missing index")
+	}
+	stmts, err := diff.Statements()
+	if err != nil {
+		t.Fatalf("Failed to generate statements: %v", err)
+	}
+	expected := []string{"ALTER TABLE `users` ADD INDEX `idx_create_time` (`create_time`);"}
+	if diff := assert.Diff(strings.Join(expected, "\n"), strings.Join(stmts, "\n")); diff != "" {
+		t.Error(diff)
+	}
+}
+
+func TestParseCreateTables(t *testing.T) {
+	tables, err := ParseCreateTables("CREATE TABLE IF NOT EXISTS `db`.`users` (\n" +
+		"  `id` BIGINT NOT NULL AUTO_INCREMENT, -- primary\n" +
+		"  `age` INT NOT NULL DEFAULT 0,\n" +
+		"  `price` DECIMAL(10,2) NOT NULL DEFAULT 0,\n" +
+		"  `note` VARCHAR(64) NOT NULL DEFAULT 'a;b',\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		");\n" +
+		"INSERT INTO `users` VALUES (1);")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if len(tables) != 1 {
+		t.Fatalf("Expected 1 table, got %d", len(tables))
+	}
+	stmt, err := CreateTable(tables[0])
+	if err != nil {
+		t.Fatalf("Failed to generate CREATE TABLE: %v", err)
+	}
+	expected := "CREATE TABLE `users` (\n" +
+		"  `id` BIGINT NOT NULL AUTO_INCREMENT,\n" +
+		"  `age` INT NOT NULL DEFAULT 0,\n" +
+		"  `price` DOUBLE NOT NULL DEFAULT 0,\n" +
+		"  `note` VARCHAR(255) NOT NULL DEFAULT '',\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		");"
+	if diff := assert.Diff(expected, stmt); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,210 @@
+package ddl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// Column describes a column of a live table as reported by information_schema
+type Column struct {
+	ColumnName string
+	DataType   string
+	ColumnType string
+}
+
+const queryColumnsSQL = "SELECT `COLUMN_NAME` AS `column_name`, `DATA_TYPE` AS `data_type`, `COLUMN_TYPE` AS `column_type`" +
+	" FROM `information_schema`.`COLUMNS`" +
+	" WHERE %s" +
+	" ORDER BY `ORDINAL_POSITION`"
+
+// QueryColumns loads the columns of a table in the current database,
+// or in the database qualifying the name like analytics.events.
+// It returns an empty list if the table does not exist.
+func QueryColumns(ctx context.Context, eng engine.Engine, tableName string) ([]*Column, error) {
+	var columns []*Column
+	cond, args := tableCondition(tableName)
+	err := eng.Query(ctx, fmt.Sprintf(queryColumnsSQL, cond), args, &columns)
+	if err != nil {
+		return nil, fmt.Errorf("query columns of %s: %w", tableName, err)
+	}
+	return columns, nil
+}
+
+// IndexColumn describes a column of a live index as reported by information_schema
+type IndexColumn struct {
+	IndexName  string
+	ColumnName string
+}
+
+const queryIndexesSQL = "SELECT `INDEX_NAME` AS `index_name`, `COLUMN_NAME` AS `column_name`" +
+	" FROM `information_schema`.`STATISTICS`" +
+	" WHERE %s" +
+	" ORDER BY `INDEX_NAME`, `SEQ_IN_INDEX`"
+
+// QueryIndexes loads the index columns of a table in the current database,
+// or in the database qualifying the name like analytics.events
+func QueryIndexes(ctx context.Context, eng engine.Engine, tableName string) ([]*IndexColumn, error) {
+	var indexes []*IndexColumn
+	cond, args := tableCondition(tableName)
+	err := eng.Query(ctx, fmt.Sprintf(queryIndexesSQL, cond), args, &indexes)
+	if err != nil {
+		return nil, fmt.Errorf("query indexes of %s: %w", tableName, err)
+	}
+	return indexes, nil
+}
+
+// tableCondition matches the information_schema rows of a table
+func tableCondition(tableName string) (string, []interface{}) {
+	if schema, name, ok := strings.Cut(tableName, "."); ok {
+		return "`TABLE_SCHEMA` = ? AND `TABLE_NAME` = ?", []interface{}{schema, name}
+	}
+	return "`TABLE_SCHEMA` = DATABASE() AND `TABLE_NAME` = ?", []interface{}{tableName}
+}
+
+// Diff describes the differences between a table definition and the live columns
+type Diff struct {
+	Table table.Table
+	// Missing is true if the table does not exist in the database
+	Missing bool
+	// Added are fields declared in the table definition but missing from the database
+	Added []field.Field
+	// Removed are columns present in the database but not declared in the table definition
+	Removed []*Column
+	// Retyped are fields whose declared type is not compatible with the database column
+	Retyped []field.Field
+	// AddedIndexes are indexes declared in the table definition but missing from the database
+	AddedIndexes []table.Index
+	// DropColumns makes Statements drop the Removed columns,
+	// off by default since it loses their data
+	DropColumns bool
+
+	// columns are the live columns by name
+	columns map[string]*Column
+}
+
+// Compare compares a table definition against its live columns
+func Compare(t table.Table, columns []*Column) *Diff {
+	diff := &Diff{Table: t}
+	if len(columns) == 0 {
+		diff.Missing = true
+		return diff
+	}
+
+	columnMap := make(map[string]*Column, len(columns))
+	for _, col := range columns {
+		columnMap[col.ColumnName] = col
+	}
+	diff.columns = columnMap
+	declared := make(map[string]bool, len(t.Fields()))
+	for _, f := range t.Fields() {
+		declared[f.Name()] = true
+		col, ok := columnMap[f.Name()]
+		if !ok {
+			diff.Added = append(diff.Added, f)
+			continue
+		}
+		if !Compatible(f, col.DataType) {
+			diff.Retyped = append(diff.Retyped, f)
+		}
+	}
+	for _, col := range columns {
+		if !declared[col.ColumnName] {
+			diff.Removed = append(diff.Removed, col)
+		}
+	}
+	return diff
+}
+
+// CompareIndexes records the declared indexes missing from the live
+// indexes, matched by name. Changed index columns are not detected.
+func (d *Diff) CompareIndexes(indexes []*IndexColumn) {
+	if d.Missing {
+		return
+	}
+	live := make(map[string]bool, len(indexes))
+	for _, idx := range indexes {
+		live[idx.IndexName] = true
+	}
+	for _, idx := range d.Table.Indexes() {
+		if !live[idx.Name] {
+			d.AddedIndexes = append(d.AddedIndexes, idx)
+		}
+	}
+}
+
+// Empty reports whether the table definition matches the database
+func (d *Diff) Empty() bool {
+	return !d.Missing && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Retyped) == 0 && len(d.AddedIndexes) == 0
+}
+
+// Statements returns the DDL statements that migrate the database
+// to match the table definition. Retyped columns are only modified if
+// the declared type widens them, and Removed columns are only dropped
+// with DropColumns, see Skipped.
+func (d *Diff) Statements() ([]string, error) {
+	if d.Missing {
+		stmt, err := CreateTable(d.Table)
+		if err != nil {
+			return nil, err
+		}
+		return []string{stmt}, nil
+	}
+	alter := "ALTER TABLE " + field.QuoteTable(d.Table.Name())
+
+	var stmts []string
+	for _, f := range d.Added {
+		def, err := ColumnDefinition(f)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, alter+" ADD COLUMN "+def+";")
+	}
+	for _, f := range d.Retyped {
+		if !Widens(f, d.columns[f.Name()].DataType) {
+			continue
+		}
+		def, err := ColumnDefinition(f)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, alter+" MODIFY COLUMN "+def+";")
+	}
+	if d.DropColumns {
+		for _, col := range d.Removed {
+			stmts = append(stmts, alter+" DROP COLUMN "+quote(col.ColumnName)+";")
+		}
+	}
+	for _, idx := range d.AddedIndexes {
+		stmts = append(stmts, AddIndex(d.Table.Name(), idx))
+	}
+	return stmts, nil
+}
+
+// Skipped describes the differences Statements leaves to be
+// migrated by hand: narrowing type changes, and the Removed
+// columns unless DropColumns is set
+func (d *Diff) Skipped() []string {
+	var skipped []string
+	for _, f := range d.Retyped {
+		col := d.columns[f.Name()]
+		if Widens(f, col.DataType) {
+			continue
+		}
+		actual := col.ColumnType
+		if actual == "" {
+			actual = col.DataType
+		}
+		skipped = append(skipped, fmt.Sprintf("column %s: changing %s to %s may lose data", f.Name(), actual, ColumnType(f)))
+	}
+	if !d.DropColumns {
+		for _, col := range d.Removed {
+			skipped = append(skipped, fmt.Sprintf("column %s: not declared, not dropped", col.ColumnName))
+		}
+	}
+	return skipped
+}
@@ -0,0 +1,243 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// ParseCreateTables parses the CREATE TABLE statements in a MySQL
+// schema file and returns the corresponding table definitions.
+// Other statements, indexes and constraints are ignored.
+func ParseCreateTables(src string) ([]table.Table, error) {
+	var tables []table.Table
+	for _, stmt := range splitStatements(stripComments(src)) {
+		t, ok, err := parseCreateTable(stmt)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			tables = append(tables, t)
+		}
+	}
+	return tables, nil
+}
+
+func parseCreateTable(stmt string) (table.Table, bool, error) {
+	fields := strings.Fields(stmt)
+	if len(fields) < 3 || !strings.EqualFold(fields[0], "CREATE") {
+		return table.Table{}, false, nil
+	}
+	// skip optional TEMPORARY
+	idx := 1
+	if strings.EqualFold(fields[idx], "TEMPORARY") {
+		idx++
+	}
+	if idx >= len(fields) || !strings.EqualFold(fields[idx], "TABLE") {
+		return table.Table{}, false, nil
+	}
+
+	open := strings.Index(stmt, "(")
+	closeIdx := -1
+	if open >= 0 {
+		closeIdx = matchParen(stmt, open)
+	}
+	if closeIdx < 0 {
+		return table.Table{}, false, fmt.Errorf("invalid CREATE TABLE statement: %s", firstLine(stmt))
+	}
+
+	header := strings.Fields(stmt[:open])
+	nameTokens := header[idx+1:]
+	// skip optional IF NOT EXISTS
+	if len(nameTokens) >= 3 && strings.EqualFold(nameTokens[0], "IF") {
+		nameTokens = nameTokens[3:]
+	}
+	if len(nameTokens) != 1 {
+		return table.Table{}, false, fmt.Errorf("invalid table name in statement: %s", firstLine(stmt))
+	}
+	name := unquoteIdent(nameTokens[0])
+	// db.table: keep only the table part
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = unquoteIdent(name[dot+1:])
+	}
+
+	t := table.New(name)
+	for _, def := range splitTopLevel(stmt[open+1:closeIdx], ',') {
+		def = strings.TrimSpace(def)
+		if def == "" || isConstraintDef(def) {
+			continue
+		}
+		colTokens := strings.Fields(def)
+		if len(colTokens) < 2 {
+			return table.Table{}, false, fmt.Errorf("table %s: invalid column definition: %s", name, def)
+		}
+		colName := unquoteIdent(colTokens[0])
+		if err := addColumn(&t, colName, colTokens[1]); err != nil {
+			return table.Table{}, false, fmt.Errorf("table %s: %w", name, err)
+		}
+	}
+	return t, true, nil
+}
+
+// addColumn adds a field to the table according to the MySQL column type
+func addColumn(t *table.Table, name string, colType string) error {
+	lower := strings.ToLower(colType)
+	baseType := lower
+	if p := strings.Index(baseType, "("); p >= 0 {
+		baseType = baseType[:p]
+	}
+	switch baseType {
+	case "bigint":
+		t.Int64(name)
+	case "tinyint":
+		if lower == "tinyint(1)" {
+			t.Bool(name)
+		} else {
+			t.Int32(name)
+		}
+	case "int", "integer", "mediumint", "smallint":
+		t.Int32(name)
+	case "bool", "boolean", "bit":
+		t.Bool(name)
+	case "double", "float", "decimal", "real":
+		t.Float64(name)
+	case "varchar", "char", "text", "tinytext", "mediumtext", "longtext", "enum", "set", "json":
+		t.String(name)
+	case "datetime", "timestamp", "date":
+		t.Time(name)
+	default:
+		return fmt.Errorf("unsupported type %s of column %s", colType, name)
+	}
+	return nil
+}
+
+func isConstraintDef(def string) bool {
+	first := strings.ToUpper(strings.Fields(def)[0])
+	switch first {
+	case "PRIMARY", "KEY", "INDEX", "UNIQUE", "CONSTRAINT", "FOREIGN", "FULLTEXT", "SPATIAL", "CHECK":
+		return true
+	}
+	return false
+}
+
+func unquoteIdent(s string) string {
+	return strings.Trim(s, "`\"")
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.Index(s, "\n"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// stripComments removes --, # and /* */ comments outside of quotes
+func stripComments(src string) string {
+	var b strings.Builder
+	var quote byte
+	n := len(src)
+	for i := 0; i < n; i++ {
+		c := src[i]
+		if quote != 0 {
+			b.WriteByte(c)
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '#' || (c == '-' && i+1 < n && src[i+1] == '-'):
+			for i < n && src[i] != '\n' {
+				i++
+			}
+			if i < n {
+				b.WriteByte('\n')
+			}
+			continue
+		case c == '/' && i+1 < n && src[i+1] == '*':
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return b.String()
+			}
+			i += end + 3
+			b.WriteByte(' ')
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func splitStatements(src string) []string {
+	var stmts []string
+	for _, stmt := range splitTopLevel(src, ';') {
+		stmt = strings.TrimSpace(stmt)
+		if stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts
+}
+
+// matchParen returns the index of the parenthesis closing the one at open
+func matchParen(s string, open int) int {
+	var quote byte
+	depth := 0
+	for i := open; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits s by sep, ignoring separators inside
+// parentheses and quotes
+func splitTopLevel(s string, sep byte) []string {
+	var parts []string
+	var quote byte
+	depth := 0
+	start := 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"', '`':
+			quote = c
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -25,6 +25,49 @@ type Engine interface {
 	ExecInsert(ctx context.Context, sql string, args []interface{}) (int64, error)
 }
 
+// AffectedExecer is optionally implemented by an Engine
+// to report the number of rows affected by a sql
+type AffectedExecer interface {
+	// ExecAffected execute a sql, and return the number of rows affected
+	ExecAffected(ctx context.Context, sql string, args []interface{}) (int64, error)
+}
+
+// Tx is an Engine executing statements in a transaction,
+// and the Factory of itself, e.g. for ORM.WithEngine
+type Tx interface {
+	Engine
+	Factory
+	Commit() error
+	Rollback() error
+}
+
+// TxBeginner is optionally implemented by an Engine
+// to run statements in transactions
+type TxBeginner interface {
+	// BeginTx starts a transaction with the given options
+	BeginTx(ctx context.Context, opts TxOptions) (Tx, error)
+}
+
+// IsolationLevel is the isolation level of a transaction
+type IsolationLevel int
+
+const (
+	// DefaultIsolation is the default isolation level of the database
+	DefaultIsolation IsolationLevel = iota
+	ReadUncommitted
+	ReadCommitted
+	RepeatableRead
+	Serializable
+)
+
+// TxOptions are the options of a transaction, the zero
+// value starts a read-write transaction of the default isolation level
+type TxOptions struct {
+	Isolation IsolationLevel
+	// ReadOnly starts a read-only transaction, which MySQL runs cheaper
+	ReadOnly bool
+}
+
 // Getter is a function that returns an Engine
 type Getter func() Engine
 
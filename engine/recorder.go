@@ -0,0 +1,238 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Statement is a statement executed through a RecordingEngine
+type Statement struct {
+	// Method is the engine method executing the statement:
+	// Query, Exec, ExecInsert or ExecAffected
+	Method   string
+	SQL      string
+	Args     []interface{}
+	Duration time.Duration
+	Err      error
+}
+
+// RecordingEngine records every statement executed through it,
+// see Recorder
+type RecordingEngine struct {
+	inner Engine
+	// parent records the statements of a transaction begun by it
+	parent *RecordingEngine
+
+	mutex      sync.Mutex
+	statements []Statement
+}
+
+// Recorder wraps inner to record every executed statement, with its
+// args, duration and error, for assertions in tests, see
+// sqltest.AssertExecuted.
+// A nil inner executes nothing and reports no error, which is enough
+// to assert the SQL generated by the code under test.
+// Use it as a Factory, so AffectedExecer and TxBeginner of inner are
+// kept, the statements of transactions are recorded too.
+func Recorder(inner Engine) *RecordingEngine {
+	return &RecordingEngine{inner: inner}
+}
+
+// GetEngine implements Factory
+func (r *RecordingEngine) GetEngine() Engine {
+	execer, affected := r.inner.(AffectedExecer)
+	beginner, tx := r.inner.(TxBeginner)
+	switch {
+	case affected && tx:
+		return recordingAffectedTxEngine{
+			recordingAffectedEngine: recordingAffectedEngine{RecordingEngine: r, execer: execer},
+			txBeginner:              txBeginner{rec: r, beginner: beginner},
+		}
+	case affected:
+		return recordingAffectedEngine{RecordingEngine: r, execer: execer}
+	case tx:
+		return recordingTxEngine{RecordingEngine: r, txBeginner: txBeginner{rec: r, beginner: beginner}}
+	}
+	return r
+}
+
+// Query executes the query on the inner engine and records it
+func (r *RecordingEngine) Query(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+	start := time.Now()
+	var err error
+	if r.inner != nil {
+		err = r.inner.Query(ctx, sql, args, result)
+	}
+	r.record("Query", sql, args, start, err)
+	return err
+}
+
+// Exec executes the sql on the inner engine and records it
+func (r *RecordingEngine) Exec(ctx context.Context, sql string, args []interface{}) error {
+	start := time.Now()
+	var err error
+	if r.inner != nil {
+		err = r.inner.Exec(ctx, sql, args)
+	}
+	r.record("Exec", sql, args, start, err)
+	return err
+}
+
+// ExecInsert executes the insert sql on the inner engine and records it
+func (r *RecordingEngine) ExecInsert(ctx context.Context, sql string, args []interface{}) (int64, error) {
+	start := time.Now()
+	var id int64
+	var err error
+	if r.inner != nil {
+		id, err = r.inner.ExecInsert(ctx, sql, args)
+	}
+	r.record("ExecInsert", sql, args, start, err)
+	return id, err
+}
+
+func (r *RecordingEngine) record(method string, sql string, args []interface{}, start time.Time, err error) {
+	if r.parent != nil {
+		r.parent.record(method, sql, args, start, err)
+		return
+	}
+	stmt := Statement{
+		Method:   method,
+		SQL:      sql,
+		Args:     args,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	r.mutex.Lock()
+	r.statements = append(r.statements, stmt)
+	r.mutex.Unlock()
+}
+
+// Statements returns the recorded statements in execution order
+func (r *RecordingEngine) Statements() []Statement {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]Statement(nil), r.statements...)
+}
+
+// SQLs returns the SQL of the recorded statements in execution order
+func (r *RecordingEngine) SQLs() []string {
+	stmts := r.Statements()
+	sqls := make([]string, len(stmts))
+	for i, stmt := range stmts {
+		sqls[i] = stmt.SQL
+	}
+	return sqls
+}
+
+// Last returns the last recorded statement, false if there is none
+func (r *RecordingEngine) Last() (Statement, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(r.statements) == 0 {
+		return Statement{}, false
+	}
+	return r.statements[len(r.statements)-1], true
+}
+
+// Find returns the recorded statements whose SQL contains substr
+func (r *RecordingEngine) Find(substr string) []Statement {
+	var found []Statement
+	for _, stmt := range r.Statements() {
+		if strings.Contains(stmt.SQL, substr) {
+			found = append(found, stmt)
+		}
+	}
+	return found
+}
+
+// Reset discards the recorded statements
+func (r *RecordingEngine) Reset() {
+	r.mutex.Lock()
+	r.statements = nil
+	r.mutex.Unlock()
+}
+
+// recordingAffectedEngine keeps AffectedExecer of the recorded engine
+type recordingAffectedEngine struct {
+	*RecordingEngine
+	execer AffectedExecer
+}
+
+func (e recordingAffectedEngine) ExecAffected(ctx context.Context, sql string, args []interface{}) (int64, error) {
+	start := time.Now()
+	affected, err := e.execer.ExecAffected(ctx, sql, args)
+	e.record("ExecAffected", sql, args, start, err)
+	return affected, err
+}
+
+// recordingTxEngine keeps TxBeginner of the recorded engine
+type recordingTxEngine struct {
+	*RecordingEngine
+	txBeginner
+}
+
+// recordingAffectedTxEngine keeps both AffectedExecer and
+// TxBeginner of the recorded engine
+type recordingAffectedTxEngine struct {
+	recordingAffectedEngine
+	txBeginner
+}
+
+// txBeginner begins transactions recorded by rec
+type txBeginner struct {
+	rec      *RecordingEngine
+	beginner TxBeginner
+}
+
+func (b txBeginner) BeginTx(ctx context.Context, opts TxOptions) (Tx, error) {
+	tx, err := b.beginner.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	rec := &RecordingEngine{inner: tx, parent: b.rec}
+	if execer, ok := tx.(AffectedExecer); ok {
+		return recordingAffectedTx{
+			recordingAffectedEngine: recordingAffectedEngine{RecordingEngine: rec, execer: execer},
+			tx:                      tx,
+		}, nil
+	}
+	return recordingTx{RecordingEngine: rec, tx: tx}, nil
+}
+
+// recordingTx records the statements of a transaction
+type recordingTx struct {
+	*RecordingEngine
+	tx Tx
+}
+
+func (t recordingTx) GetEngine() Engine {
+	return t
+}
+
+func (t recordingTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t recordingTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+// recordingAffectedTx keeps AffectedExecer of the recorded transaction
+type recordingAffectedTx struct {
+	recordingAffectedEngine
+	tx Tx
+}
+
+func (t recordingAffectedTx) GetEngine() Engine {
+	return t
+}
+
+func (t recordingAffectedTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t recordingAffectedTx) Rollback() error {
+	return t.tx.Rollback()
+}
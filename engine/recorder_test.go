@@ -0,0 +1,151 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// affectedEngine reports 3 rows affected and fails Query
+type affectedEngine struct{}
+
+func (affectedEngine) Query(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+	return errors.New("query failed")
+}
+
+func (affectedEngine) Exec(ctx context.Context, sql string, args []interface{}) error {
+	return nil
+}
+
+func (affectedEngine) ExecInsert(ctx context.Context, sql string, args []interface{}) (int64, error) {
+	return 7, nil
+}
+
+func (affectedEngine) ExecAffected(ctx context.Context, sql string, args []interface{}) (int64, error) {
+	return 3, nil
+}
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	rec := Recorder(affectedEngine{})
+	eng := rec.GetEngine()
+
+	if err := eng.Query(ctx, "SELECT `id` FROM `users` WHERE `id` = ?", []interface{}{int64(1)}, nil); err == nil {
+		t.Errorf("Expected query error to be passed through")
+	}
+	id, err := eng.ExecInsert(ctx, "INSERT INTO `users` SET `name`=?", []interface{}{"Alice"})
+	if err != nil || id != 7 {
+		t.Errorf("Expected insert id 7, got %d, %v", id, err)
+	}
+	execer, ok := eng.(AffectedExecer)
+	if !ok {
+		t.Fatalf("Expected AffectedExecer of the inner engine to be kept")
+	}
+	affected, err := execer.ExecAffected(ctx, "DELETE FROM `users` WHERE `id` = ?", []interface{}{int64(1)})
+	if err != nil || affected != 3 {
+		t.Errorf("Expected 3 rows affected, got %d, %v", affected, err)
+	}
+
+	stmts := rec.Statements()
+	if len(stmts) != 3 {
+		t.Fatalf("Expected 3 statements, got %d", len(stmts))
+	}
+	methods := []string{"Query", "ExecInsert", "ExecAffected"}
+	for i, stmt := range stmts {
+		if stmt.Method != methods[i] {
+			t.Errorf("Expected statement %d method %s, got %s", i, methods[i], stmt.Method)
+		}
+	}
+	if stmts[0].Err == nil || stmts[1].Err != nil {
+		t.Errorf("Expected only the query error to be recorded, got %v, %v", stmts[0].Err, stmts[1].Err)
+	}
+	if last, ok := rec.Last(); !ok || last.Method != "ExecAffected" {
+		t.Errorf("Expected last statement ExecAffected, got %+v", last)
+	}
+	if found := rec.Find("INSERT INTO"); len(found) != 1 || found[0].Args[0] != "Alice" {
+		t.Errorf("Expected to find the insert, got %+v", found)
+	}
+
+	rec.Reset()
+	if sqls := rec.SQLs(); len(sqls) != 0 {
+		t.Errorf("Expected no statements after Reset, got %v", sqls)
+	}
+}
+
+func TestRecorder_NilInner(t *testing.T) {
+	rec := Recorder(nil)
+	eng := rec.GetEngine()
+	if _, ok := eng.(AffectedExecer); ok {
+		t.Errorf("Expected no AffectedExecer without an inner engine")
+	}
+	if err := eng.Exec(context.Background(), "UPDATE `users` SET `age`=?", []interface{}{int64(18)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sqls := rec.SQLs(); len(sqls) != 1 || sqls[0] != "UPDATE `users` SET `age`=?" {
+		t.Errorf("Expected the update recorded, got %v", sqls)
+	}
+}
+
+// txEngine begins transactions on itself, counting commits
+type txEngine struct {
+	affectedEngine
+	commits *int
+}
+
+func (e txEngine) BeginTx(ctx context.Context, opts TxOptions) (Tx, error) {
+	return e, nil
+}
+
+func (e txEngine) GetEngine() Engine {
+	return e
+}
+
+func (e txEngine) Commit() error {
+	*e.commits++
+	return nil
+}
+
+func (e txEngine) Rollback() error {
+	return nil
+}
+
+func TestRecorder_Tx(t *testing.T) {
+	ctx := context.Background()
+	var commits int
+	rec := Recorder(txEngine{commits: &commits})
+	eng := rec.GetEngine()
+	if _, ok := eng.(AffectedExecer); !ok {
+		t.Errorf("Expected AffectedExecer of the inner engine to be kept")
+	}
+	beginner, ok := eng.(TxBeginner)
+	if !ok {
+		t.Fatalf("Expected TxBeginner of the inner engine to be kept")
+	}
+	tx, err := beginner.BeginTx(ctx, TxOptions{})
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+	if err := tx.GetEngine().Exec(ctx, "UPDATE `users` SET `age`=?", []interface{}{int64(18)}); err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+	execer, ok := tx.(AffectedExecer)
+	if !ok {
+		t.Fatalf("Expected AffectedExecer of the transaction to be kept")
+	}
+	if _, err := execer.ExecAffected(ctx, "DELETE FROM `users`", nil); err != nil {
+		t.Fatalf("ExecAffected: %v", err)
+	}
+	if err := tx.Commit(); err != nil || commits != 1 {
+		t.Errorf("Expected the commit passed through, got %d, %v", commits, err)
+	}
+
+	expected := []string{"UPDATE `users` SET `age`=?", "DELETE FROM `users`"}
+	if sqls := rec.SQLs(); strings.Join(sqls, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected the transaction statements recorded, got %v", sqls)
+	}
+
+	if _, ok := Recorder(affectedEngine{}).GetEngine().(TxBeginner); ok {
+		t.Errorf("Expected no TxBeginner without one in the inner engine")
+	}
+}
@@ -0,0 +1,213 @@
+// Package sqldb provides an engine.Engine backed by the standard database/sql package
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/orm/column"
+)
+
+// Engine adapts a *sql.DB to engine.Engine
+// Query results are scanned into struct fields by matching
+// column names against column.Name of fields, with or without
+// initialisms, see orm.NamingStrategy.
+// For MySQL, the DSN should contain parseTime=true so that
+// DATETIME columns can be scanned into time.Time.
+type Engine struct {
+	DB *sql.DB
+}
+
+// New creates a new Engine from a *sql.DB
+func New(db *sql.DB) *Engine {
+	return &Engine{DB: db}
+}
+
+var _ engine.Engine = (*Engine)(nil)
+var _ engine.AffectedExecer = (*Engine)(nil)
+var _ engine.TxBeginner = (*Engine)(nil)
+
+// conn is implemented by *sql.DB and *sql.Tx
+type conn interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// GetEngine implements engine.Factory
+func (e *Engine) GetEngine() engine.Engine {
+	return e
+}
+
+// Query executes the query and scans all rows into result,
+// which must be a pointer to a slice of structs or struct pointers
+func (e *Engine) Query(ctx context.Context, sqlQuery string, args []interface{}, result interface{}) error {
+	return query(ctx, e.DB, sqlQuery, args, result)
+}
+
+// Exec executes the sql
+func (e *Engine) Exec(ctx context.Context, sqlQuery string, args []interface{}) error {
+	_, err := e.DB.ExecContext(ctx, sqlQuery, args...)
+	return err
+}
+
+// ExecAffected executes the sql and returns the number of rows affected
+func (e *Engine) ExecAffected(ctx context.Context, sqlQuery string, args []interface{}) (int64, error) {
+	return execAffected(ctx, e.DB, sqlQuery, args)
+}
+
+// ExecInsert executes the insert sql and returns the last insert id
+func (e *Engine) ExecInsert(ctx context.Context, sqlQuery string, args []interface{}) (int64, error) {
+	return execInsert(ctx, e.DB, sqlQuery, args)
+}
+
+// BeginTx starts a transaction, implementing engine.TxBeginner
+func (e *Engine) BeginTx(ctx context.Context, opts engine.TxOptions) (engine.Tx, error) {
+	isolation, err := isolationLevel(opts.Isolation)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := e.DB.BeginTx(ctx, &sql.TxOptions{Isolation: isolation, ReadOnly: opts.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx}, nil
+}
+
+func isolationLevel(level engine.IsolationLevel) (sql.IsolationLevel, error) {
+	switch level {
+	case engine.DefaultIsolation:
+		return sql.LevelDefault, nil
+	case engine.ReadUncommitted:
+		return sql.LevelReadUncommitted, nil
+	case engine.ReadCommitted:
+		return sql.LevelReadCommitted, nil
+	case engine.RepeatableRead:
+		return sql.LevelRepeatableRead, nil
+	case engine.Serializable:
+		return sql.LevelSerializable, nil
+	}
+	return 0, fmt.Errorf("unknown isolation level: %d", level)
+}
+
+func query(ctx context.Context, c conn, sqlQuery string, args []interface{}, result interface{}) error {
+	rows, err := c.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return ScanRows(rows, result)
+}
+
+func execAffected(ctx context.Context, c conn, sqlQuery string, args []interface{}) (int64, error) {
+	res, err := c.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func execInsert(ctx context.Context, c conn, sqlQuery string, args []interface{}) (int64, error) {
+	res, err := c.ExecContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// ScanRows scans all rows into result, which must be a pointer
+// to a slice of structs or struct pointers.
+// Columns without a matching field are ignored.
+// Models implementing column.RowScanner are scanned without reflection.
+func ScanRows(rows *sql.Rows, result interface{}) error {
+	rv := reflect.ValueOf(result)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("result must be a pointer to slice, got %T", result)
+	}
+	sliceV := rv.Elem()
+	elemType := sliceV.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	structType := elemType
+	if isPtr {
+		structType = elemType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return fmt.Errorf("result element must be a struct, got %s", elemType)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	isScanner := reflect.PtrTo(structType).Implements(rowScannerType)
+	var fieldIndex map[string]int
+	if !isScanner {
+		fieldIndex = columnFieldIndex(structType)
+	}
+
+	for rows.Next() {
+		elem := reflect.New(structType).Elem()
+		var dests []interface{}
+		if isScanner {
+			dests = elem.Addr().Interface().(column.RowScanner).ScanRow(columns)
+			for i, dest := range dests {
+				if dest == nil {
+					var discard interface{}
+					dests[i] = &discard
+				}
+			}
+		} else {
+			dests = make([]interface{}, len(columns))
+			for i, col := range columns {
+				idx, ok := fieldIndex[col]
+				if !ok {
+					var discard interface{}
+					dests[i] = &discard
+					continue
+				}
+				dests[i] = elem.Field(idx).Addr().Interface()
+			}
+		}
+		if err := rows.Scan(dests...); err != nil {
+			return err
+		}
+		if isPtr {
+			sliceV.Set(reflect.Append(sliceV, elem.Addr()))
+		} else {
+			sliceV.Set(reflect.Append(sliceV, elem))
+		}
+	}
+	return rows.Err()
+}
+
+var rowScannerType = reflect.TypeOf((*column.RowScanner)(nil)).Elem()
+
+// columnFieldIndex maps column names to exported field indexes,
+// fields like UserIDs also match their initialism-aware column
+// user_ids if no other field maps to it
+func columnFieldIndex(structType reflect.Type) map[string]int {
+	index := make(map[string]int, structType.NumField())
+	for i := 0; i < structType.NumField(); i++ {
+		f := structType.Field(i)
+		if !f.IsExported() || f.Anonymous || column.Ignored(f) {
+			continue
+		}
+		index[column.Name(f, false)] = i
+	}
+	for i := 0; i < structType.NumField(); i++ {
+		f := structType.Field(i)
+		if !f.IsExported() || f.Anonymous || column.Ignored(f) {
+			continue
+		}
+		// models bound with orm.WithNamingStrategy spelling initialisms
+		if col := column.Name(f, true); col != column.Name(f, false) {
+			if _, ok := index[col]; !ok {
+				index[col] = i
+			}
+		}
+	}
+	return index
+}
@@ -0,0 +1,53 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/xhd2015/arc-orm/engine"
+)
+
+// Tx adapts a *sql.Tx to engine.Tx, see Engine.BeginTx
+type Tx struct {
+	Tx *sql.Tx
+}
+
+var _ engine.Tx = (*Tx)(nil)
+var _ engine.AffectedExecer = (*Tx)(nil)
+
+// GetEngine implements engine.Factory
+func (t *Tx) GetEngine() engine.Engine {
+	return t
+}
+
+// Query executes the query in the transaction and scans all rows
+// into result, see Engine.Query
+func (t *Tx) Query(ctx context.Context, sqlQuery string, args []interface{}, result interface{}) error {
+	return query(ctx, t.Tx, sqlQuery, args, result)
+}
+
+// Exec executes the sql in the transaction
+func (t *Tx) Exec(ctx context.Context, sqlQuery string, args []interface{}) error {
+	_, err := t.Tx.ExecContext(ctx, sqlQuery, args...)
+	return err
+}
+
+// ExecAffected executes the sql in the transaction and returns the number of rows affected
+func (t *Tx) ExecAffected(ctx context.Context, sqlQuery string, args []interface{}) (int64, error) {
+	return execAffected(ctx, t.Tx, sqlQuery, args)
+}
+
+// ExecInsert executes the insert sql in the transaction and returns the last insert id
+func (t *Tx) ExecInsert(ctx context.Context, sqlQuery string, args []interface{}) (int64, error) {
+	return execInsert(ctx, t.Tx, sqlQuery, args)
+}
+
+// Commit commits the transaction
+func (t *Tx) Commit() error {
+	return t.Tx.Commit()
+}
+
+// Rollback aborts the transaction
+func (t *Tx) Rollback() error {
+	return t.Tx.Rollback()
+}
@@ -0,0 +1,133 @@
+package sqlitetest
+
+import (
+	"regexp"
+	"strings"
+)
+
+const onDuplicateKeyUpdate = " ON DUPLICATE KEY UPDATE "
+
+var valuesRef = regexp.MustCompile("VALUES\\((`[^`]+`)\\)")
+
+// Rewrite rewrites the MySQL-isms generated by the sql and orm
+// packages into their SQLite equivalents:
+//   - INSERT IGNORE becomes INSERT OR IGNORE
+//   - INSERT ... SET a=x, b=y becomes INSERT ... (a, b) VALUES (x, y)
+//   - ON DUPLICATE KEY UPDATE becomes ON CONFLICT DO UPDATE SET,
+//     with VALUES(`col`) referring to excluded.`col`
+//
+// Other statements are returned unchanged.
+func Rewrite(query string) string {
+	if !strings.HasPrefix(query, "INSERT ") {
+		return query
+	}
+	if strings.HasPrefix(query, "INSERT IGNORE ") {
+		query = "INSERT OR IGNORE " + strings.TrimPrefix(query, "INSERT IGNORE ")
+	}
+
+	// a trailing comment never contains a comment delimiter,
+	// see sql.SanitizeComment
+	var comment string
+	if strings.HasSuffix(query, " */") {
+		if idx := strings.LastIndex(query, " /* "); idx >= 0 {
+			query, comment = query[:idx], query[idx:]
+		}
+	}
+
+	var upsert string
+	if idx := indexTopLevel(query, onDuplicateKeyUpdate); idx >= 0 {
+		query, upsert = query[:idx], query[idx+len(onDuplicateKeyUpdate):]
+	}
+	if idx := indexTopLevel(query, " SET "); idx >= 0 {
+		if columns, values, ok := splitAssignments(query[idx+len(" SET "):]); ok {
+			query = query[:idx] + " (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(values, ", ") + ")"
+		}
+	}
+	if upsert != "" {
+		upsert = valuesRef.ReplaceAllString(upsert, "excluded.$1")
+		query += " ON CONFLICT DO UPDATE SET " + upsert
+	}
+	return query + comment
+}
+
+// splitAssignments splits a list of assignments like `a`=?, `b`=?
+// into its columns and values
+func splitAssignments(s string) (columns []string, values []string, ok bool) {
+	for _, assignment := range splitTopLevel(s) {
+		column, value, found := strings.Cut(assignment, "=")
+		if !found {
+			return nil, nil, false
+		}
+		columns = append(columns, strings.TrimSpace(column))
+		values = append(values, strings.TrimSpace(value))
+	}
+	return columns, values, true
+}
+
+// indexTopLevel returns the index of the first occurrence of substr
+// outside of quotes and parentheses, or -1
+func indexTopLevel(s string, substr string) int {
+	topLevel := topLevelMask(s)
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if topLevel[i] && strings.HasPrefix(s[i:], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// splitTopLevel splits s at commas outside of quotes and parentheses
+func splitTopLevel(s string) []string {
+	topLevel := topLevelMask(s)
+	var parts []string
+	start := 0
+	for i := 0; i < len(s); i++ {
+		if topLevel[i] && s[i] == ',' {
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// topLevelMask reports for each byte of s whether it is
+// outside of quotes, comments and parentheses
+func topLevelMask(s string) []bool {
+	mask := make([]bool, len(s))
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\'', '"', '`':
+			end := i + 1
+			for end < len(s) {
+				if s[end] == '\\' && c != '`' {
+					end += 2
+					continue
+				}
+				if s[end] == c {
+					break
+				}
+				end++
+			}
+			i = end
+			continue
+		case '/':
+			if strings.HasPrefix(s[i:], "/*") {
+				if end := strings.Index(s[i+2:], "*/"); end >= 0 {
+					i += end + 3
+				} else {
+					i = len(s)
+				}
+				continue
+			}
+		case '(':
+			depth++
+			continue
+		case ')':
+			depth--
+			continue
+		}
+		mask[i] = depth == 0
+	}
+	return mask
+}
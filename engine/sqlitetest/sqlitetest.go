@@ -0,0 +1,235 @@
+// Package sqlitetest provides an engine backed by an in-memory SQLite
+// database, so tests exercise real SQL execution instead of mocks.
+// Tables are created from table definitions via the ddl package, and
+// the MySQL-isms generated by the sql and orm packages are rewritten
+// into their SQLite equivalents before execution.
+package sqlitetest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+	"github.com/xhd2015/arc-orm/ddl"
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/engine/sqldb"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// Engine is an engine.Engine backed by an in-memory SQLite database
+type Engine struct {
+	DB *sql.DB
+
+	db *sqldb.Engine
+
+	// insertMu serializes ExecInsert, which reads upsertID
+	insertMu sync.Mutex
+	// upsertID is the id recorded by LAST_INSERT_ID(expr) during
+	// an ON DUPLICATE KEY UPDATE, which SQLite does not report
+	// as the last insert rowid
+	upsertID int64
+}
+
+var _ engine.Engine = (*Engine)(nil)
+var _ engine.AffectedExecer = (*Engine)(nil)
+
+// New opens an in-memory SQLite database and creates the given tables,
+// the database is closed when the test finishes.
+// It fails the test if the database cannot be set up.
+func New(t testing.TB, tables ...table.Table) *Engine {
+	t.Helper()
+	e, err := Open()
+	if err != nil {
+		t.Fatalf("sqlitetest: open database: %v", err)
+	}
+	t.Cleanup(func() {
+		e.DB.Close()
+	})
+	if err := e.CreateTables(context.Background(), tables...); err != nil {
+		t.Fatalf("sqlitetest: %v", err)
+	}
+	return e
+}
+
+// Open opens an in-memory SQLite database, the caller is
+// responsible for closing DB. Prefer New in tests.
+func Open() (*Engine, error) {
+	e := &Engine{}
+	d := &sqlite3.SQLiteDriver{
+		ConnectHook: e.registerFuncs,
+	}
+	db := sql.OpenDB(&connector{driver: d, dsn: ":memory:"})
+	// every connection to :memory: is a separate database,
+	// so all statements must share one connection
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	e.DB = db
+	e.db = sqldb.New(db)
+	return e, nil
+}
+
+// CreateTables creates the tables from their definitions
+func (e *Engine) CreateTables(ctx context.Context, tables ...table.Table) error {
+	for _, t := range tables {
+		stmts, err := CreateTable(t)
+		if err != nil {
+			return err
+		}
+		for _, stmt := range stmts {
+			if _, err := e.DB.ExecContext(ctx, stmt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetEngine implements engine.Factory
+func (e *Engine) GetEngine() engine.Engine {
+	return e
+}
+
+// Query executes the query and scans all rows into result,
+// see sqldb.ScanRows
+func (e *Engine) Query(ctx context.Context, sqlQuery string, args []interface{}, result interface{}) error {
+	return e.db.Query(ctx, Rewrite(sqlQuery), args, result)
+}
+
+// Exec executes the sql
+func (e *Engine) Exec(ctx context.Context, sqlQuery string, args []interface{}) error {
+	return e.db.Exec(ctx, Rewrite(sqlQuery), args)
+}
+
+// ExecAffected executes the sql and returns the number of rows affected
+func (e *Engine) ExecAffected(ctx context.Context, sqlQuery string, args []interface{}) (int64, error) {
+	return e.db.ExecAffected(ctx, Rewrite(sqlQuery), args)
+}
+
+// ExecInsert executes the insert sql and returns the last insert id.
+// For upserts updating an existing row, the id recorded by
+// LAST_INSERT_ID(expr) is returned, like MySQL does.
+func (e *Engine) ExecInsert(ctx context.Context, sqlQuery string, args []interface{}) (int64, error) {
+	e.insertMu.Lock()
+	defer e.insertMu.Unlock()
+	e.upsertID = 0
+	id, err := e.db.ExecInsert(ctx, Rewrite(sqlQuery), args)
+	if err != nil {
+		return 0, err
+	}
+	if e.upsertID != 0 {
+		return e.upsertID, nil
+	}
+	return id, nil
+}
+
+// registerFuncs registers the MySQL functions used by
+// the sql package that SQLite does not provide
+func (e *Engine) registerFuncs(conn *sqlite3.SQLiteConn) error {
+	now := func() string {
+		return time.Now().UTC().Format(sqlite3.SQLiteTimestampFormats[0])
+	}
+	funcs := []struct {
+		name string
+		impl interface{}
+		pure bool
+	}{
+		{"NOW", now, false},
+		{"UTC_TIMESTAMP", now, false},
+		{"CONCAT", func(args ...string) string { return strings.Join(args, "") }, true},
+		{"LAST_INSERT_ID", func(id int64) int64 {
+			e.upsertID = id
+			return id
+		}, false},
+	}
+	for _, f := range funcs {
+		if err := conn.RegisterFunc(f.name, f.impl, f.pure); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// connector opens connections with a dedicated driver,
+// so that registered functions can refer to the engine
+type connector struct {
+	driver *sqlite3.SQLiteDriver
+	dsn    string
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// CreateTable generates the SQLite statements creating the table:
+// the CREATE TABLE statement generated by ddl.CreateTable, rewritten
+// for SQLite, followed by CREATE INDEX statements of its indexes
+func CreateTable(t table.Table) ([]string, error) {
+	stmt, err := ddl.CreateTable(t)
+	if err != nil {
+		return nil, err
+	}
+	header, body, ok := cutDefinitions(stmt)
+	if !ok {
+		return nil, fmt.Errorf("unrecognized CREATE TABLE statement: %s", stmt)
+	}
+	var autoIncrement bool
+	var defs []string
+	var indexes []string
+	for _, def := range strings.Split(body, ",\n") {
+		def = strings.TrimSpace(def)
+		switch {
+		case strings.HasSuffix(def, " AUTO_INCREMENT"):
+			// only INTEGER PRIMARY KEY columns auto increment in SQLite
+			name := def[:strings.Index(def, " ")]
+			def = name + " INTEGER PRIMARY KEY AUTOINCREMENT"
+			autoIncrement = true
+		case strings.HasPrefix(def, "PRIMARY KEY "):
+			if autoIncrement {
+				continue
+			}
+		case strings.HasPrefix(def, "UNIQUE KEY "):
+			indexes = append(indexes, createIndex("CREATE UNIQUE INDEX ", t.Name(), strings.TrimPrefix(def, "UNIQUE KEY ")))
+			continue
+		case strings.HasPrefix(def, "KEY "):
+			indexes = append(indexes, createIndex("CREATE INDEX ", t.Name(), strings.TrimPrefix(def, "KEY ")))
+			continue
+		}
+		def = strings.TrimSuffix(def, " ON UPDATE CURRENT_TIMESTAMP")
+		defs = append(defs, "  "+def)
+	}
+	stmts := []string{header + "\n" + strings.Join(defs, ",\n") + "\n);"}
+	return append(stmts, indexes...), nil
+}
+
+// cutDefinitions splits a CREATE TABLE statement into
+// its header and its column and index definitions
+func cutDefinitions(stmt string) (header string, body string, ok bool) {
+	start := strings.Index(stmt, "(\n")
+	end := strings.LastIndex(stmt, "\n)")
+	if start < 0 || end < start {
+		return "", "", false
+	}
+	return stmt[:start+1], stmt[start+2 : end], true
+}
+
+// createIndex turns an index definition like `name` (`a`, `b`)
+// into a CREATE INDEX statement on the table
+func createIndex(prefix string, tableName string, def string) string {
+	name, columns, _ := strings.Cut(def, " ")
+	return prefix + name + " ON `" + tableName + "` " + columns + ";"
+}
@@ -0,0 +1,281 @@
+package sqlitetest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/orm"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+type user struct {
+	Id         int64
+	Email      string
+	Name       string
+	Age        int64
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+type userOptional struct {
+	Id         *int64
+	Email      *string
+	Name       *string
+	Age        *int64
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+
+func newUserTable() table.Table {
+	users := table.New("users")
+	users.Int64("id")
+	email := users.String("email")
+	name := users.String("name")
+	users.Int64("age")
+	users.Time("create_time")
+	users.Time("update_time")
+	users.Unique("uk_email", email)
+	users.Index("idx_name", name)
+	return users
+}
+
+func TestCreateTable(t *testing.T) {
+	stmts, err := CreateTable(newUserTable())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		"CREATE TABLE `users` (\n" +
+			"  `id` INTEGER PRIMARY KEY AUTOINCREMENT,\n" +
+			"  `email` VARCHAR(255) NOT NULL DEFAULT '',\n" +
+			"  `name` VARCHAR(255) NOT NULL DEFAULT '',\n" +
+			"  `age` BIGINT NOT NULL DEFAULT 0,\n" +
+			"  `create_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,\n" +
+			"  `update_time` DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP\n" +
+			");",
+		"CREATE UNIQUE INDEX `uk_email` ON `users` (`email`);",
+		"CREATE INDEX `idx_name` ON `users` (`name`);",
+	}
+	if len(stmts) != len(expected) {
+		t.Fatalf("Expected %d statements, got %d: %q", len(expected), len(stmts), stmts)
+	}
+	for i, stmt := range stmts {
+		if stmt != expected[i] {
+			t.Errorf("Expected statement %d:\n%s\ngot:\n%s", i, expected[i], stmt)
+		}
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{
+			"SELECT `id` FROM `users` WHERE `name` = ?",
+			"SELECT `id` FROM `users` WHERE `name` = ?",
+		},
+		{
+			"INSERT INTO `users` SET `name`=?, `age`=?",
+			"INSERT INTO `users` (`name`, `age`) VALUES (?, ?)",
+		},
+		{
+			"INSERT IGNORE INTO `users` SET `name`=? /* svc=a, b */",
+			"INSERT OR IGNORE INTO `users` (`name`) VALUES (?) /* svc=a, b */",
+		},
+		{
+			"INSERT INTO `users` SET `name`=CONCAT(?, ', ', ?), `age`=? ON DUPLICATE KEY UPDATE `id`=LAST_INSERT_ID(`users`.`id`), `age`=VALUES(`age`)",
+			"INSERT INTO `users` (`name`, `age`) VALUES (CONCAT(?, ', ', ?), ?) ON CONFLICT DO UPDATE SET `id`=LAST_INSERT_ID(`users`.`id`), `age`=excluded.`age`",
+		},
+		{
+			"INSERT INTO `users` (`name`, `age`) VALUES (?, ?), (?, ?)",
+			"INSERT INTO `users` (`name`, `age`) VALUES (?, ?), (?, ?)",
+		},
+	}
+	for _, tt := range tests {
+		if got := Rewrite(tt.query); got != tt.expected {
+			t.Errorf("Rewrite(%q):\nexpected: %s\ngot:      %s", tt.query, tt.expected, got)
+		}
+	}
+}
+
+func TestORM(t *testing.T) {
+	ctx := context.Background()
+	users := newUserTable()
+	o := orm.Bind[user, userOptional](New(t, users), users)
+
+	id, err := o.Insert(ctx, &user{Email: "alice@example.com", Name: "Alice", Age: 30})
+	if err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	if id != 1 {
+		t.Errorf("Expected id 1, got %d", id)
+	}
+
+	age := int64(31)
+	if err := o.UpdateByID(ctx, id, &userOptional{Age: &age}); err != nil {
+		t.Fatalf("UpdateByID: %v", err)
+	}
+	got, err := o.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got == nil || got.Name != "Alice" || got.Age != 31 || got.CreateTime.IsZero() {
+		t.Errorf("Unexpected user: %+v", got)
+	}
+
+	inserted, err := o.InsertIgnore(ctx, &user{Email: "alice@example.com", Name: "Duplicate"})
+	if err != nil {
+		t.Fatalf("InsertIgnore: %v", err)
+	}
+	if inserted {
+		t.Errorf("Expected duplicate email to be ignored")
+	}
+
+	age = 32
+	upsertID, err := o.InsertOrUpdate(ctx, &user{Email: "alice@example.com", Name: "Alice"}, &userOptional{Age: &age})
+	if err != nil {
+		t.Fatalf("InsertOrUpdate: %v", err)
+	}
+	if upsertID != id {
+		t.Errorf("Expected upsert to report existing id %d, got %d", id, upsertID)
+	}
+	got, err = o.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.Age != 32 {
+		t.Errorf("Expected age 32 after upsert, got %d", got.Age)
+	}
+
+	name := users.Fields()[2]
+	names, err := o.Select(name).QueryOptional(ctx)
+	if err != nil {
+		t.Fatalf("QueryOptional: %v", err)
+	}
+	if len(names) != 1 || names[0].Name == nil || *names[0].Name != "Alice" || names[0].Age != nil {
+		t.Errorf("Expected only the selected name, got %+v", names)
+	}
+
+	if err := o.DeleteByID(ctx, id); err != nil {
+		t.Fatalf("DeleteByID: %v", err)
+	}
+	if _, err := o.GetByID(ctx, id); err == nil {
+		t.Errorf("Expected user to be deleted")
+	}
+
+	err = o.InsertMany(ctx, []*user{
+		{Email: "bob@example.com", Name: "Bob"},
+		{Email: "carol@example.com", Name: "Carol"},
+	})
+	if err != nil {
+		t.Fatalf("InsertMany: %v", err)
+	}
+	all, err := o.SelectAll().Query(ctx)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(all) != 2 || all[0].Name != "Bob" || all[1].Name != "Carol" {
+		t.Errorf("Expected the inserted users, got %+v", all)
+	}
+}
+
+type post struct {
+	Id    int64
+	Title string
+	Body  string
+}
+
+type postOptional struct {
+	Id    *int64
+	Title *string
+	Body  *string
+}
+
+func TestUpdateModelByID_Lazy(t *testing.T) {
+	ctx := context.Background()
+	posts := table.New("posts")
+	postId := posts.Int64("id")
+	posts.String("title")
+	body := posts.String("body").Lazy()
+	o := orm.Bind[post, postOptional](New(t, posts), posts)
+
+	id, err := o.Insert(ctx, &post{Title: "Hello", Body: "World"})
+	if err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	model, err := o.SelectAll().Where(postId.Eq(id)).QueryOne(ctx)
+	if err != nil {
+		t.Fatalf("QueryOne: %v", err)
+	}
+	if model.Body != "" {
+		t.Fatalf("Expected lazy body not loaded, got %q", model.Body)
+	}
+
+	model.Title = "Hi"
+	if err := o.UpdateModelByID(ctx, id, model); err != nil {
+		t.Fatalf("UpdateModelByID: %v", err)
+	}
+	got, err := o.SelectAll().WithLazy().Where(postId.Eq(id)).QueryOne(ctx)
+	if err != nil {
+		t.Fatalf("QueryOne: %v", err)
+	}
+	if got.Title != "Hi" || got.Body != "World" {
+		t.Errorf("Expected title updated and body kept, got %+v", got)
+	}
+
+	got.Body = "Everyone"
+	if err := o.UpdateModelByID(ctx, id, got, orm.IncludeColumns(body)); err != nil {
+		t.Fatalf("UpdateModelByID: %v", err)
+	}
+	got, err = o.SelectAll().WithLazy().Where(postId.Eq(id)).QueryOne(ctx)
+	if err != nil {
+		t.Fatalf("QueryOne: %v", err)
+	}
+	if got.Body != "Everyone" {
+		t.Errorf("Expected included body written, got %q", got.Body)
+	}
+}
+
+type doc struct {
+	Id      int64
+	DocUuid field.UUID
+	Title   string
+}
+
+type docOptional struct {
+	Id      *int64
+	DocUuid *field.UUID
+	Title   *string
+}
+
+func TestUUID(t *testing.T) {
+	ctx := context.Background()
+	docs := table.New("docs")
+	docs.Int64("id")
+	docUUID := docs.UUID("doc_uuid").AutoGenerate()
+	docs.String("title")
+	o := orm.Bind[doc, docOptional](New(t, docs), docs)
+
+	d := &doc{Title: "hello"}
+	id, err := o.Insert(ctx, d)
+	if err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	got, err := o.GetByID(ctx, id)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+	if got.DocUuid == (field.UUID{}) || got.DocUuid != d.DocUuid {
+		t.Errorf("Expected UUID %s scanned back, got %s", d.DocUuid, got.DocUuid)
+	}
+	optional, err := o.Select(docUUID).Where(docUUID.Eq(d.DocUuid.String())).QueryOptional(ctx)
+	if err != nil {
+		t.Fatalf("QueryOptional: %v", err)
+	}
+	if len(optional) != 1 || optional[0].DocUuid == nil || *optional[0].DocUuid != d.DocUuid {
+		t.Errorf("Expected optional UUID %s, got %+v", d.DocUuid, optional)
+	}
+}
@@ -4,13 +4,16 @@ package field
 type AliasField struct {
 	field Field
 	alias string
+	err   error
 }
 
-// As creates an aliased field
+// As creates an aliased field, an invalid alias
+// is reported by ToSQL, see ValidateIdentifier
 func As(f Field, alias string) *AliasField {
 	return &AliasField{
 		field: f,
 		alias: alias,
+		err:   ValidateIdentifier(alias),
 	}
 }
 
@@ -26,6 +29,9 @@ func (a *AliasField) Table() string {
 
 // ToSQL returns the SQL representation of the field with its alias
 func (a *AliasField) ToSQL() (string, []interface{}, error) {
+	if a.err != nil {
+		return "", nil, a.err
+	}
 	sql, params, err := a.field.ToSQL()
 	if err != nil {
 		return "", nil, err
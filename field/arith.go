@@ -0,0 +1,171 @@
+package field
+
+// ArithExpr is an arithmetic expression between fields and
+// expressions, like `price` * `quantity`, usable in SELECT, WHERE and SET
+type ArithExpr struct {
+	left  Expr
+	op    string
+	right Expr
+}
+
+func arith(left Expr, op string, right Expr) ArithExpr {
+	return ArithExpr{left: left, op: op, right: right}
+}
+
+// ToSQL returns the SQL of the expression, nested
+// arithmetic operands are parenthesized
+func (a ArithExpr) ToSQL() (string, []interface{}, error) {
+	leftSQL, params, err := operandSQL(a.left)
+	if err != nil {
+		return "", nil, err
+	}
+	rightSQL, rightParams, err := operandSQL(a.right)
+	if err != nil {
+		return "", nil, err
+	}
+	return leftSQL + " " + a.op + " " + rightSQL, concatParams(params, rightParams), nil
+}
+
+// operandSQL returns the SQL of an arithmetic operand
+func operandSQL(e Expr) (string, []interface{}, error) {
+	sql, params, err := e.ToSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	switch e.(type) {
+	case ArithExpr, *mathOperation:
+		return "(" + sql + ")", params, nil
+	}
+	return sql, params, nil
+}
+
+// Add creates an addition expression (expr + other)
+func (a ArithExpr) Add(other Expr) ArithExpr {
+	return arith(a, "+", other)
+}
+
+// Sub creates a subtraction expression (expr - other)
+func (a ArithExpr) Sub(other Expr) ArithExpr {
+	return arith(a, "-", other)
+}
+
+// Mul creates a multiplication expression (expr * other)
+func (a ArithExpr) Mul(other Expr) ArithExpr {
+	return arith(a, "*", other)
+}
+
+// Div creates a division expression (expr / other)
+func (a ArithExpr) Div(other Expr) ArithExpr {
+	return arith(a, "/", other)
+}
+
+// Mod creates a modulo expression (expr % other)
+func (a ArithExpr) Mod(other Expr) ArithExpr {
+	return arith(a, "%", other)
+}
+
+// BitAnd creates a bitwise AND expression (expr & other)
+func (a ArithExpr) BitAnd(other Expr) ArithExpr {
+	return arith(a, "&", other)
+}
+
+// BitOr creates a bitwise OR expression (expr | other)
+func (a ArithExpr) BitOr(other Expr) ArithExpr {
+	return arith(a, "|", other)
+}
+
+// BitXor creates a bitwise XOR expression (expr ^ other)
+func (a ArithExpr) BitXor(other Expr) ArithExpr {
+	return arith(a, "^", other)
+}
+
+// Eq creates an equality condition (expr = value),
+// value can be a literal or an Expr
+func (a ArithExpr) Eq(value interface{}) Expr {
+	return &exprComparison{expr: a, op: "=", value: value}
+}
+
+// Neq creates a not equal condition (expr != value)
+func (a ArithExpr) Neq(value interface{}) Expr {
+	return &exprComparison{expr: a, op: "!=", value: value}
+}
+
+// Gt creates a greater than condition (expr > value)
+func (a ArithExpr) Gt(value interface{}) Expr {
+	return &exprComparison{expr: a, op: ">", value: value}
+}
+
+// Gte creates a greater than or equal to condition (expr >= value)
+func (a ArithExpr) Gte(value interface{}) Expr {
+	return &exprComparison{expr: a, op: ">=", value: value}
+}
+
+// Lt creates a less than condition (expr < value)
+func (a ArithExpr) Lt(value interface{}) Expr {
+	return &exprComparison{expr: a, op: "<", value: value}
+}
+
+// Lte creates a less than or equal to condition (expr <= value)
+func (a ArithExpr) Lte(value interface{}) Expr {
+	return &exprComparison{expr: a, op: "<=", value: value}
+}
+
+// As returns this expression with an alias, for SELECT
+func (a ArithExpr) As(alias string) Expr {
+	return newExprAlias(a, alias)
+}
+
+// Asc returns an ascending order specification for this expression
+func (a ArithExpr) Asc() OrderField {
+	return OrderField{field: a, desc: false}
+}
+
+// Desc returns a descending order specification for this expression
+func (a ArithExpr) Desc() OrderField {
+	return OrderField{field: a, desc: true}
+}
+
+// exprComparison compares an expression with a value,
+// or another expression inlined as is
+type exprComparison struct {
+	expr  Expr
+	op    string
+	value interface{}
+}
+
+func (c *exprComparison) ToSQL() (string, []interface{}, error) {
+	sql, params, err := c.expr.ToSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	if other, ok := c.value.(Expr); ok {
+		otherSQL, otherParams, err := other.ToSQL()
+		if err != nil {
+			return "", nil, err
+		}
+		return sql + " " + c.op + " " + otherSQL, concatParams(params, otherParams), nil
+	}
+	return sql + " " + c.op + " ?", append(params, c.value), nil
+}
+
+// exprAlias wraps an expression with an alias
+type exprAlias struct {
+	expr  Expr
+	alias string
+	err   error
+}
+
+func newExprAlias(e Expr, alias string) *exprAlias {
+	return &exprAlias{expr: e, alias: alias, err: ValidateIdentifier(alias)}
+}
+
+func (a *exprAlias) ToSQL() (string, []interface{}, error) {
+	if a.err != nil {
+		return "", nil, a.err
+	}
+	sql, params, err := a.expr.ToSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	return sql + " AS `" + a.alias + "`", params, nil
+}
@@ -5,6 +5,37 @@ package field
 type BoolField struct {
 	FieldName string
 	TableName string
+
+	meta *meta
+}
+
+// NewBool creates a BoolField of the table whose chained
+// declarations, e.g. constraints, are shared by all its copies
+func NewBool(tableName string, name string) BoolField {
+	return BoolField{FieldName: name, TableName: tableName, meta: &meta{}}
+}
+
+// NotNull declares the field must not be written as NULL
+func (f BoolField) NotNull() BoolField {
+	getMeta(&f.meta).constraints.NotNull = true
+	return f
+}
+
+// Constraints returns the constraints declared on the field
+func (f BoolField) Constraints() Constraints {
+	return constraintsOf(f.meta)
+}
+
+// Default declares the default value of the field, used by the
+// DDL generator and by Insert with orm.WithInsertDefaults
+func (f BoolField) Default(value bool) BoolField {
+	setDefault(&f.meta, value)
+	return f
+}
+
+// DefaultValue implements Defaulted
+func (f BoolField) DefaultValue() (interface{}, bool) {
+	return defaultOf(f.meta)
 }
 
 // Name returns the field name
@@ -19,24 +50,67 @@ func (f BoolField) Table() string {
 
 // ToSQL returns the SQL representation of the field
 func (f BoolField) ToSQL() (string, []interface{}, error) {
-	if f.TableName == "" {
-		return "`" + f.FieldName + "`", nil, nil
-	}
-	return "`" + f.TableName + "`.`" + f.FieldName + "`", nil, nil
+	return quoteColumn(f.TableName, f.FieldName)
 }
 
 // Eq creates an equality condition (field = value)
 // The boolean value is converted to 1 (true) or 0 (false) for SQL
 func (f BoolField) Eq(value bool) Expr {
-	sqlValue := int32(0)
-	if value {
-		sqlValue = 1
-	}
 	return &comparison{
 		field: f,
 		op:    "=",
-		value: sqlValue,
+		value: boolToSQL(value),
+	}
+}
+
+// EqField creates an equality condition between two fields (field1 = field2)
+func (f BoolField) EqField(other Field) Expr {
+	return &fieldComparison{
+		left:  f,
+		op:    "=",
+		right: other,
+	}
+}
+
+// Neq creates a not equal condition (field != value)
+func (f BoolField) Neq(value bool) Expr {
+	return &comparison{
+		field: f,
+		op:    "!=",
+		value: boolToSQL(value),
+	}
+}
+
+// NeqField creates a not equal condition between two fields (field1 != field2)
+func (f BoolField) NeqField(other BoolField) Expr {
+	return &fieldComparison{
+		left:  f,
+		op:    "!=",
+		right: other,
+	}
+}
+
+// In creates an IN condition (field IN (values...))
+func (f BoolField) In(values ...bool) Expr {
+	if len(values) == 0 {
+		panic("in requires non-empty values")
+	}
+	interfaceValues := make([]interface{}, len(values))
+	for i, v := range values {
+		interfaceValues[i] = boolToSQL(v)
+	}
+	return &inCondition{
+		field:  f,
+		values: interfaceValues,
+	}
+}
+
+// boolToSQL converts a boolean to 1 (true) or 0 (false)
+func boolToSQL(value bool) int32 {
+	if value {
+		return 1
 	}
+	return 0
 }
 
 // IsTrue creates a condition checking if the field is true (field = 1)
@@ -0,0 +1,75 @@
+package field
+
+import "fmt"
+
+// DefaultFoldCollation is the case-insensitive collation used by EqFold
+const DefaultFoldCollation = "utf8mb4_general_ci"
+
+// CollatedExpr is an expression compared under an explicit
+// collation, like `users`.`name` COLLATE utf8mb4_bin = ?
+type CollatedExpr struct {
+	expr      Expr
+	collation string
+}
+
+// ToSQL returns the SQL of the expression with its COLLATE clause
+func (c CollatedExpr) ToSQL() (string, []interface{}, error) {
+	if !validCollation(c.collation) {
+		return "", nil, fmt.Errorf("invalid collation: %q", c.collation)
+	}
+	sql, params, err := c.expr.ToSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	return sql + " COLLATE " + c.collation, params, nil
+}
+
+// validCollation reports whether name is safe to inline as a collation
+func validCollation(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if !(c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Eq creates an equality condition under the collation
+func (c CollatedExpr) Eq(value string) Expr {
+	return &exprComparison{expr: c, op: "=", value: value}
+}
+
+// Neq creates a not equal condition under the collation
+func (c CollatedExpr) Neq(value string) Expr {
+	return &exprComparison{expr: c, op: "!=", value: value}
+}
+
+// Like creates a LIKE condition under the collation
+func (c CollatedExpr) Like(value string) Expr {
+	return &exprComparison{expr: c, op: "LIKE", value: value}
+}
+
+// In creates an IN condition under the collation
+func (c CollatedExpr) In(values ...string) Expr {
+	if len(values) == 0 {
+		panic("in requires non-empty values")
+	}
+	interfaceValues := make([]interface{}, len(values))
+	for i, v := range values {
+		interfaceValues[i] = v
+	}
+	return &exprIn{expr: c, values: interfaceValues}
+}
+
+// Asc returns an ascending order specification under the collation
+func (c CollatedExpr) Asc() OrderField {
+	return OrderField{field: c, desc: false}
+}
+
+// Desc returns a descending order specification under the collation
+func (c CollatedExpr) Desc() OrderField {
+	return OrderField{field: c, desc: true}
+}
@@ -30,7 +30,7 @@ func (c *inCondition) ToSQL() (string, []interface{}, error) {
 
 // OrderField represents a field with ordering direction
 type OrderField struct {
-	field Field
+	field Expr
 	desc  bool
 }
 
@@ -0,0 +1,79 @@
+package field
+
+// Constraints are the client-side checks declared on a table field,
+// enforced by the ORM before writes, e.g.
+//
+//	Name = Table.String("name").NotNull().MaxLen(255)
+//	Age  = Table.Int64("age").Min(0)
+type Constraints struct {
+	// NotNull rejects writing NULL, e.g. a nil pointer on insert
+	NotNull bool
+	// MaxLen is the maximum length in characters, 0 means unlimited
+	MaxLen int
+	// Min and Max bound numeric values, nil means unbounded
+	Min *float64
+	Max *float64
+}
+
+// Constrained is implemented by fields carrying constraints
+type Constrained interface {
+	Constraints() Constraints
+}
+
+// Defaulted is implemented by fields carrying a default value,
+// declared like Table.Int64("status").Default(1)
+type Defaulted interface {
+	// DefaultValue returns the declared default, reporting whether one is declared
+	DefaultValue() (interface{}, bool)
+}
+
+// LazyLoaded is implemented by fields that can be declared lazy,
+// like Table.String("body").Lazy()
+type LazyLoaded interface {
+	// IsLazy reports whether the field is skipped by SelectAll by default
+	IsLazy() bool
+}
+
+// meta is the metadata shared by the copies of a field created by a table,
+// so that declarations chained on a field are visible from the table
+type meta struct {
+	constraints  Constraints
+	defaultValue interface{}
+	hasDefault   bool
+	// autoGenerate is set by UuidField.AutoGenerate
+	autoGenerate bool
+	// lazy is set by StringField.Lazy
+	lazy bool
+}
+
+// getMeta returns the shared metadata, allocating it for fields not created by a table
+func getMeta(m **meta) *meta {
+	if *m == nil {
+		*m = &meta{}
+	}
+	return *m
+}
+
+func constraintsOf(m *meta) Constraints {
+	if m == nil {
+		return Constraints{}
+	}
+	return m.constraints
+}
+
+func setDefault(m **meta, value interface{}) {
+	mt := getMeta(m)
+	mt.defaultValue = value
+	mt.hasDefault = true
+}
+
+func defaultOf(m *meta) (interface{}, bool) {
+	if m == nil || !m.hasDefault {
+		return nil, false
+	}
+	return m.defaultValue, true
+}
+
+func floatPtr(v float64) *float64 {
+	return &v
+}
@@ -9,6 +9,10 @@ import (
 // Expr represents a SQL condition
 type Expr = expr.Expr
 
+// Condition is an alias of Expr, conditions of all field
+// types share the single ToSQL() (string, []interface{}, error)
+type Condition = Expr
+
 // Field interface represents a database field with a name and table
 type Field interface {
 	Name() string
@@ -16,6 +20,12 @@ type Field interface {
 	expr.Expr
 }
 
+// QuoteTable quotes a table name, qualified names like
+// analytics.events are quoted per part: `analytics`.`events`
+func QuoteTable(table string) string {
+	return "`" + strings.ReplaceAll(table, ".", "`.`") + "`"
+}
+
 // comparison represents a comparison operation between a field and a value
 type comparison struct {
 	field Field
@@ -58,6 +68,11 @@ type paren struct {
 	expr Expr
 }
 
+// not represents a NOT condition
+type not struct {
+	expr Expr
+}
+
 func (p *paren) ToSQL() (string, []interface{}, error) {
 	sql, params, err := p.expr.ToSQL()
 	if err != nil {
@@ -83,6 +98,11 @@ func Paren(expr Expr) Expr {
 	return &paren{expr: expr}
 }
 
+// Not negates any condition as NOT (condition)
+func Not(condition Expr) Expr {
+	return &not{expr: condition}
+}
+
 func Add(exprs ...Expr) Expr {
 	return mathOpExprs("+", exprs)
 }
@@ -116,16 +136,37 @@ func (m *mathOperation) ToSQL() (string, []interface{}, error) {
 	sqlParts := make([]string, 0, len(m.exprs))
 	params := make([]interface{}, 0)
 	for _, expr := range m.exprs {
-		sql, params, err := expr.ToSQL()
+		sql, exprParams, err := operandSQL(expr)
 		if err != nil {
 			return "", nil, err
 		}
 		sqlParts = append(sqlParts, sql)
-		params = append(params, params...)
+		params = append(params, exprParams...)
 	}
 	return strings.Join(sqlParts, " "+m.op+" "), params, nil
 }
 
+func (n *not) ToSQL() (string, []interface{}, error) {
+	if n.expr == nil {
+		return "", nil, nil
+	}
+	sql, params, err := n.expr.ToSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	if sql == "" {
+		return "", nil, nil
+	}
+	switch n.expr.(type) {
+	case *and, *or, *paren:
+		// already parenthesized when joining multiple conditions
+		if strings.HasPrefix(sql, "(") {
+			return "NOT " + sql, params, nil
+		}
+	}
+	return "NOT (" + sql + ")", params, nil
+}
+
 func (o *or) ToSQL() (string, []interface{}, error) {
 	return joinCodnitions(o.conditions, "OR")
 }
@@ -172,5 +213,9 @@ type fieldOperation struct {
 
 // ToSQL implements Expr for field operations (increment, decrement, etc.)
 func (op *fieldOperation) ToSQL() (string, []interface{}, error) {
-	return "`" + op.field.Table() + "`.`" + op.field.Name() + "`" + op.operator + "?", []interface{}{op.value}, nil
+	column, _, err := quoteColumn(op.field.Table(), op.field.Name())
+	if err != nil {
+		return "", nil, err
+	}
+	return column + op.operator + "?", []interface{}{op.value}, nil
 }
@@ -6,6 +6,49 @@ import "fmt"
 type Float64Field struct {
 	FieldName string
 	TableName string
+
+	meta *meta
+}
+
+// NewFloat64 creates a Float64Field of the table whose chained
+// declarations, e.g. constraints, are shared by all its copies
+func NewFloat64(tableName string, name string) Float64Field {
+	return Float64Field{FieldName: name, TableName: tableName, meta: &meta{}}
+}
+
+// NotNull declares the field must not be written as NULL
+func (f Float64Field) NotNull() Float64Field {
+	getMeta(&f.meta).constraints.NotNull = true
+	return f
+}
+
+// Min declares the minimum value of the field
+func (f Float64Field) Min(value float64) Float64Field {
+	getMeta(&f.meta).constraints.Min = floatPtr(float64(value))
+	return f
+}
+
+// Max declares the maximum value of the field
+func (f Float64Field) Max(value float64) Float64Field {
+	getMeta(&f.meta).constraints.Max = floatPtr(float64(value))
+	return f
+}
+
+// Constraints returns the constraints declared on the field
+func (f Float64Field) Constraints() Constraints {
+	return constraintsOf(f.meta)
+}
+
+// Default declares the default value of the field, used by the
+// DDL generator and by Insert with orm.WithInsertDefaults
+func (f Float64Field) Default(value float64) Float64Field {
+	setDefault(&f.meta, value)
+	return f
+}
+
+// DefaultValue implements Defaulted
+func (f Float64Field) DefaultValue() (interface{}, bool) {
+	return defaultOf(f.meta)
 }
 
 // Name returns the field name
@@ -21,10 +64,7 @@ func (f Float64Field) Table() string {
 // ToSQL returns the SQL representation of the field
 func (f Float64Field) ToSQL() (string, []interface{}, error) {
 	// If the field has no table, or the table name is empty, just use the field name
-	if f.TableName == "" {
-		return "`" + f.FieldName + "`", nil, nil
-	}
-	return "`" + f.TableName + "`.`" + f.FieldName + "`", nil, nil
+	return quoteColumn(f.TableName, f.FieldName)
 }
 
 // Eq creates an equality condition (field = value)
@@ -198,3 +238,23 @@ func (f Float64Field) Decrement(value float64) Expr {
 		value:    value,
 	}
 }
+
+// Add creates an addition expression (field + other), e.g. price.Add(tax)
+func (f Float64Field) Add(other Expr) ArithExpr {
+	return arith(f, "+", other)
+}
+
+// Sub creates a subtraction expression (field - other)
+func (f Float64Field) Sub(other Expr) ArithExpr {
+	return arith(f, "-", other)
+}
+
+// Mul creates a multiplication expression (field * other), e.g. price.Mul(quantity)
+func (f Float64Field) Mul(other Expr) ArithExpr {
+	return arith(f, "*", other)
+}
+
+// Div creates a division expression (field / other)
+func (f Float64Field) Div(other Expr) ArithExpr {
+	return arith(f, "/", other)
+}
@@ -0,0 +1,110 @@
+package field
+
+import "strings"
+
+// FuncExpr is a SQL function call on fields, like LOWER(`users`.`email`),
+// usable in SELECT, WHERE and ORDER BY
+type FuncExpr struct {
+	name string
+	// args are inlined if they are Expr, otherwise passed as params
+	args []interface{}
+}
+
+func funcCall(name string, args ...interface{}) FuncExpr {
+	return FuncExpr{name: name, args: args}
+}
+
+// ToSQL returns the SQL of the function call
+func (f FuncExpr) ToSQL() (string, []interface{}, error) {
+	parts := make([]string, 0, len(f.args))
+	var params []interface{}
+	for _, arg := range f.args {
+		e, ok := arg.(Expr)
+		if !ok {
+			parts = append(parts, "?")
+			params = append(params, arg)
+			continue
+		}
+		sql, argParams, err := e.ToSQL()
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, sql)
+		params = append(params, argParams...)
+	}
+	return f.name + "(" + strings.Join(parts, ", ") + ")", params, nil
+}
+
+// Eq creates an equality condition (func = value),
+// value can be a literal or an Expr
+func (f FuncExpr) Eq(value interface{}) Expr {
+	return &exprComparison{expr: f, op: "=", value: value}
+}
+
+// Neq creates a not equal condition (func != value)
+func (f FuncExpr) Neq(value interface{}) Expr {
+	return &exprComparison{expr: f, op: "!=", value: value}
+}
+
+// Gt creates a greater than condition (func > value)
+func (f FuncExpr) Gt(value interface{}) Expr {
+	return &exprComparison{expr: f, op: ">", value: value}
+}
+
+// Gte creates a greater than or equal to condition (func >= value)
+func (f FuncExpr) Gte(value interface{}) Expr {
+	return &exprComparison{expr: f, op: ">=", value: value}
+}
+
+// Lt creates a less than condition (func < value)
+func (f FuncExpr) Lt(value interface{}) Expr {
+	return &exprComparison{expr: f, op: "<", value: value}
+}
+
+// Lte creates a less than or equal to condition (func <= value)
+func (f FuncExpr) Lte(value interface{}) Expr {
+	return &exprComparison{expr: f, op: "<=", value: value}
+}
+
+// Like creates a LIKE condition (func LIKE value)
+func (f FuncExpr) Like(value string) Expr {
+	return &exprComparison{expr: f, op: "LIKE", value: value}
+}
+
+// In creates an IN condition (func IN (values...))
+func (f FuncExpr) In(values ...interface{}) Expr {
+	if len(values) == 0 {
+		panic("in requires non-empty values")
+	}
+	return &exprIn{expr: f, values: values}
+}
+
+// As returns this expression with an alias, for SELECT
+func (f FuncExpr) As(alias string) Expr {
+	return newExprAlias(f, alias)
+}
+
+// Asc returns an ascending order specification for this expression
+func (f FuncExpr) Asc() OrderField {
+	return OrderField{field: f, desc: false}
+}
+
+// Desc returns a descending order specification for this expression
+func (f FuncExpr) Desc() OrderField {
+	return OrderField{field: f, desc: true}
+}
+
+// exprIn represents an IN condition on an expression
+type exprIn struct {
+	expr   Expr
+	values []interface{}
+}
+
+func (c *exprIn) ToSQL() (string, []interface{}, error) {
+	sql, params, err := c.expr.ToSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(c.values)), ", ")
+	return sql + " IN (" + placeholders + ")", concatParams(params, c.values), nil
+}
@@ -0,0 +1,57 @@
+package field
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidIdentifier is returned for a table, column, alias or
+// function name that can not be rendered safely, see ValidateIdentifier
+var ErrInvalidIdentifier = errors.New("invalid identifier")
+
+// maxIdentifierLen is the maximum length of MySQL identifiers
+const maxIdentifierLen = 64
+
+// ValidateIdentifier checks that name is safe to quote with backticks:
+// not empty, at most 64 bytes long, without backticks or NUL. Any other
+// name is a valid quoted MySQL identifier, like `2fa_codes` or `größe`.
+// Builders check the names when they are set, so names read from config
+// can not inject SQL.
+func ValidateIdentifier(name string) error {
+	if name == "" || len(name) > maxIdentifierLen || strings.ContainsAny(name, "`\x00") {
+		return fmt.Errorf("%w: %q", ErrInvalidIdentifier, name)
+	}
+	return nil
+}
+
+// ValidateTable checks a table name by ValidateIdentifier,
+// qualified names like analytics.events per part
+func ValidateTable(name string) error {
+	schema, table, qualified := strings.Cut(name, ".")
+	if !qualified {
+		return ValidateIdentifier(name)
+	}
+	if err := ValidateIdentifier(schema); err != nil {
+		return err
+	}
+	if strings.Contains(table, ".") {
+		return fmt.Errorf("%w: %q", ErrInvalidIdentifier, name)
+	}
+	return ValidateIdentifier(table)
+}
+
+// quoteColumn renders the column qualified by its table, `table`.`name`,
+// or `name` without table, after validating both
+func quoteColumn(table string, name string) (string, []interface{}, error) {
+	if err := ValidateIdentifier(name); err != nil {
+		return "", nil, err
+	}
+	if table == "" {
+		return "`" + name + "`", nil, nil
+	}
+	if err := ValidateTable(table); err != nil {
+		return "", nil, err
+	}
+	return QuoteTable(table) + ".`" + name + "`", nil, nil
+}
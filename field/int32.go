@@ -6,6 +6,49 @@ import "fmt"
 type Int32Field struct {
 	FieldName string
 	TableName string
+
+	meta *meta
+}
+
+// NewInt32 creates a Int32Field of the table whose chained
+// declarations, e.g. constraints, are shared by all its copies
+func NewInt32(tableName string, name string) Int32Field {
+	return Int32Field{FieldName: name, TableName: tableName, meta: &meta{}}
+}
+
+// NotNull declares the field must not be written as NULL
+func (f Int32Field) NotNull() Int32Field {
+	getMeta(&f.meta).constraints.NotNull = true
+	return f
+}
+
+// Min declares the minimum value of the field
+func (f Int32Field) Min(value int32) Int32Field {
+	getMeta(&f.meta).constraints.Min = floatPtr(float64(value))
+	return f
+}
+
+// Max declares the maximum value of the field
+func (f Int32Field) Max(value int32) Int32Field {
+	getMeta(&f.meta).constraints.Max = floatPtr(float64(value))
+	return f
+}
+
+// Constraints returns the constraints declared on the field
+func (f Int32Field) Constraints() Constraints {
+	return constraintsOf(f.meta)
+}
+
+// Default declares the default value of the field, used by the
+// DDL generator and by Insert with orm.WithInsertDefaults
+func (f Int32Field) Default(value int32) Int32Field {
+	setDefault(&f.meta, value)
+	return f
+}
+
+// DefaultValue implements Defaulted
+func (f Int32Field) DefaultValue() (interface{}, bool) {
+	return defaultOf(f.meta)
 }
 
 // Name returns the field name
@@ -21,10 +64,7 @@ func (f Int32Field) Table() string {
 // ToSQL returns the SQL representation of the field
 func (f Int32Field) ToSQL() (string, []interface{}, error) {
 	// If the field has no table, or the table name is empty, just use the field name
-	if f.TableName == "" {
-		return "`" + f.FieldName + "`", nil, nil
-	}
-	return "`" + f.TableName + "`.`" + f.FieldName + "`", nil, nil
+	return quoteColumn(f.TableName, f.FieldName)
 }
 
 // Eq creates an equality condition (field = value)
@@ -200,3 +240,43 @@ func (f Int32Field) Decrement(value int32) Expr {
 		value:    value,
 	}
 }
+
+// Add creates an addition expression (field + other), e.g. price.Add(tax)
+func (f Int32Field) Add(other Expr) ArithExpr {
+	return arith(f, "+", other)
+}
+
+// Sub creates a subtraction expression (field - other)
+func (f Int32Field) Sub(other Expr) ArithExpr {
+	return arith(f, "-", other)
+}
+
+// Mul creates a multiplication expression (field * other), e.g. price.Mul(quantity)
+func (f Int32Field) Mul(other Expr) ArithExpr {
+	return arith(f, "*", other)
+}
+
+// Div creates a division expression (field / other)
+func (f Int32Field) Div(other Expr) ArithExpr {
+	return arith(f, "/", other)
+}
+
+// Mod creates a modulo expression (field % other), e.g. id.Mod(sql.Int64(100)).Lt(10) for sampling
+func (f Int32Field) Mod(other Expr) ArithExpr {
+	return arith(f, "%", other)
+}
+
+// BitAnd creates a bitwise AND expression (field & other), e.g. flags.BitAnd(sql.Int64(4)).Neq(0)
+func (f Int32Field) BitAnd(other Expr) ArithExpr {
+	return arith(f, "&", other)
+}
+
+// BitOr creates a bitwise OR expression (field | other), e.g. to set flags
+func (f Int32Field) BitOr(other Expr) ArithExpr {
+	return arith(f, "|", other)
+}
+
+// BitXor creates a bitwise XOR expression (field ^ other), e.g. to toggle flags
+func (f Int32Field) BitXor(other Expr) ArithExpr {
+	return arith(f, "^", other)
+}
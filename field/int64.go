@@ -6,6 +6,49 @@ import "fmt"
 type Int64Field struct {
 	FieldName string
 	TableName string
+
+	meta *meta
+}
+
+// NewInt64 creates a Int64Field of the table whose chained
+// declarations, e.g. constraints, are shared by all its copies
+func NewInt64(tableName string, name string) Int64Field {
+	return Int64Field{FieldName: name, TableName: tableName, meta: &meta{}}
+}
+
+// NotNull declares the field must not be written as NULL
+func (f Int64Field) NotNull() Int64Field {
+	getMeta(&f.meta).constraints.NotNull = true
+	return f
+}
+
+// Min declares the minimum value of the field
+func (f Int64Field) Min(value int64) Int64Field {
+	getMeta(&f.meta).constraints.Min = floatPtr(float64(value))
+	return f
+}
+
+// Max declares the maximum value of the field
+func (f Int64Field) Max(value int64) Int64Field {
+	getMeta(&f.meta).constraints.Max = floatPtr(float64(value))
+	return f
+}
+
+// Constraints returns the constraints declared on the field
+func (f Int64Field) Constraints() Constraints {
+	return constraintsOf(f.meta)
+}
+
+// Default declares the default value of the field, used by the
+// DDL generator and by Insert with orm.WithInsertDefaults
+func (f Int64Field) Default(value int64) Int64Field {
+	setDefault(&f.meta, value)
+	return f
+}
+
+// DefaultValue implements Defaulted
+func (f Int64Field) DefaultValue() (interface{}, bool) {
+	return defaultOf(f.meta)
 }
 
 // Name returns the field name
@@ -21,10 +64,7 @@ func (f Int64Field) Table() string {
 // ToSQL returns the SQL representation of the field
 func (f Int64Field) ToSQL() (string, []interface{}, error) {
 	// If the field has no table, or the table name is empty, just use the field name
-	if f.TableName == "" {
-		return "`" + f.FieldName + "`", nil, nil
-	}
-	return "`" + f.TableName + "`.`" + f.FieldName + "`", nil, nil
+	return quoteColumn(f.TableName, f.FieldName)
 }
 
 // Eq creates an equality condition (field = value)
@@ -138,6 +178,7 @@ func (f Int64Field) IsNull() Expr {
 	}
 }
 
+// IsNotNull creates an IS NOT NULL condition (field IS NOT NULL)
 func (f Int64Field) IsNotNull() Expr {
 	return &nullCondition{
 		field:  f,
@@ -160,6 +201,33 @@ func (f Int64Field) In(values ...int64) Expr {
 	}
 }
 
+// InOrEmpty creates an IN condition (field IN (values)),
+// or no condition if values is empty
+func (f Int64Field) InOrEmpty(values ...int64) Expr {
+	if len(values) == 0 {
+		return noOp{}
+	}
+	return f.In(values...)
+}
+
+// Between creates a BETWEEN condition (field BETWEEN start AND end)
+func (f Int64Field) Between(start int64, end int64) Expr {
+	return &between{
+		field: f,
+		start: start,
+		end:   end,
+	}
+}
+
+// BetweenField creates a BETWEEN condition between fields (field BETWEEN start AND end)
+func (f Int64Field) BetweenField(start Int64Field, end Int64Field) Expr {
+	return &betweenExpr{
+		field: f,
+		start: start,
+		end:   end,
+	}
+}
+
 // Asc returns an ascending order specification for this field
 func (f Int64Field) Asc() OrderField {
 	return OrderField{field: f, desc: false}
@@ -192,3 +260,43 @@ func (f Int64Field) Decrement(value int64) Expr {
 		value:    value,
 	}
 }
+
+// Add creates an addition expression (field + other), e.g. price.Add(tax)
+func (f Int64Field) Add(other Expr) ArithExpr {
+	return arith(f, "+", other)
+}
+
+// Sub creates a subtraction expression (field - other)
+func (f Int64Field) Sub(other Expr) ArithExpr {
+	return arith(f, "-", other)
+}
+
+// Mul creates a multiplication expression (field * other), e.g. price.Mul(quantity)
+func (f Int64Field) Mul(other Expr) ArithExpr {
+	return arith(f, "*", other)
+}
+
+// Div creates a division expression (field / other)
+func (f Int64Field) Div(other Expr) ArithExpr {
+	return arith(f, "/", other)
+}
+
+// Mod creates a modulo expression (field % other), e.g. id.Mod(sql.Int64(100)).Lt(10) for sampling
+func (f Int64Field) Mod(other Expr) ArithExpr {
+	return arith(f, "%", other)
+}
+
+// BitAnd creates a bitwise AND expression (field & other), e.g. flags.BitAnd(sql.Int64(4)).Neq(0)
+func (f Int64Field) BitAnd(other Expr) ArithExpr {
+	return arith(f, "&", other)
+}
+
+// BitOr creates a bitwise OR expression (field | other), e.g. to set flags
+func (f Int64Field) BitOr(other Expr) ArithExpr {
+	return arith(f, "|", other)
+}
+
+// BitXor creates a bitwise XOR expression (field ^ other), e.g. to toggle flags
+func (f Int64Field) BitXor(other Expr) ArithExpr {
+	return arith(f, "^", other)
+}
@@ -4,6 +4,56 @@ package field
 type StringField struct {
 	FieldName string
 	TableName string
+
+	meta *meta
+}
+
+// NewString creates a StringField of the table whose chained
+// declarations, e.g. constraints, are shared by all its copies
+func NewString(tableName string, name string) StringField {
+	return StringField{FieldName: name, TableName: tableName, meta: &meta{}}
+}
+
+// NotNull declares the field must not be written as NULL
+func (f StringField) NotNull() StringField {
+	getMeta(&f.meta).constraints.NotNull = true
+	return f
+}
+
+// MaxLen declares the maximum length of the field in characters
+func (f StringField) MaxLen(n int) StringField {
+	getMeta(&f.meta).constraints.MaxLen = n
+	return f
+}
+
+// Constraints returns the constraints declared on the field
+func (f StringField) Constraints() Constraints {
+	return constraintsOf(f.meta)
+}
+
+// Default declares the default value of the field, used by the
+// DDL generator and by Insert with orm.WithInsertDefaults
+func (f StringField) Default(value string) StringField {
+	setDefault(&f.meta, value)
+	return f
+}
+
+// DefaultValue implements Defaulted
+func (f StringField) DefaultValue() (interface{}, bool) {
+	return defaultOf(f.meta)
+}
+
+// Lazy declares the field heavy, e.g. a megabyte TEXT column, so that
+// the ORM SelectAll skips it unless WithLazy is called. Lookups by
+// id like GetByID still load it.
+func (f StringField) Lazy() StringField {
+	getMeta(&f.meta).lazy = true
+	return f
+}
+
+// IsLazy implements LazyLoaded
+func (f StringField) IsLazy() bool {
+	return f.meta != nil && f.meta.lazy
 }
 
 // Name returns the field name
@@ -18,7 +68,7 @@ func (f StringField) Table() string {
 
 // ToSQL returns the SQL representation of the field
 func (f StringField) ToSQL() (string, []interface{}, error) {
-	return "`" + f.TableName + "`.`" + f.FieldName + "`", nil, nil
+	return quoteColumn(f.TableName, f.FieldName)
 }
 
 // Eq creates an equality condition (field = value)
@@ -230,3 +280,40 @@ func (f StringField) Concat(value string) Expr {
 		value:    value,
 	}
 }
+
+// Lower returns LOWER(field), e.g. email.Lower().Eq("a@x.com")
+func (f StringField) Lower() FuncExpr {
+	return funcCall("LOWER", f)
+}
+
+// Upper returns UPPER(field)
+func (f StringField) Upper() FuncExpr {
+	return funcCall("UPPER", f)
+}
+
+// Trim returns TRIM(field)
+func (f StringField) Trim() FuncExpr {
+	return funcCall("TRIM", f)
+}
+
+// Length returns CHAR_LENGTH(field), the length in characters
+func (f StringField) Length() FuncExpr {
+	return funcCall("CHAR_LENGTH", f)
+}
+
+// Substring returns SUBSTRING(field, start, length), start is 1-based
+func (f StringField) Substring(start int, length int) FuncExpr {
+	return funcCall("SUBSTRING", f, start, length)
+}
+
+// Collate compares or orders the field under the collation,
+// e.g. name.Collate("utf8mb4_bin").Eq("Alice")
+func (f StringField) Collate(collation string) CollatedExpr {
+	return CollatedExpr{expr: f, collation: collation}
+}
+
+// EqFold creates a case-insensitive equality condition
+// under DefaultFoldCollation, for case-sensitive schemas
+func (f StringField) EqFold(value string) Expr {
+	return f.Collate(DefaultFoldCollation).Eq(value)
+}
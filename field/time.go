@@ -1,9 +1,42 @@
 package field
 
+import "time"
+
 // TimeField represents a time.Time database field
 type TimeField struct {
 	FieldName string
 	TableName string
+
+	meta *meta
+}
+
+// NewTime creates a TimeField of the table whose chained
+// declarations, e.g. constraints, are shared by all its copies
+func NewTime(tableName string, name string) TimeField {
+	return TimeField{FieldName: name, TableName: tableName, meta: &meta{}}
+}
+
+// NotNull declares the field must not be written as NULL
+func (f TimeField) NotNull() TimeField {
+	getMeta(&f.meta).constraints.NotNull = true
+	return f
+}
+
+// Constraints returns the constraints declared on the field
+func (f TimeField) Constraints() Constraints {
+	return constraintsOf(f.meta)
+}
+
+// Default declares the default value of the field, used by the
+// DDL generator and by Insert with orm.WithInsertDefaults
+func (f TimeField) Default(value time.Time) TimeField {
+	setDefault(&f.meta, value)
+	return f
+}
+
+// DefaultValue implements Defaulted
+func (f TimeField) DefaultValue() (interface{}, bool) {
+	return defaultOf(f.meta)
 }
 
 // Name returns the field name
@@ -18,10 +51,7 @@ func (f TimeField) Table() string {
 
 // ToSQL returns the SQL representation of the field
 func (f TimeField) ToSQL() (string, []interface{}, error) {
-	if f.TableName == "" {
-		return "`" + f.FieldName + "`", nil, nil
-	}
-	return "`" + f.TableName + "`.`" + f.FieldName + "`", nil, nil
+	return quoteColumn(f.TableName, f.FieldName)
 }
 
 // Eq creates an equality condition (field = value)
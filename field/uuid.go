@@ -0,0 +1,192 @@
+package field
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+)
+
+// UuidField represents a UUID database field, stored in its
+// canonical 36-character text form. Model fields can be string or UUID.
+type UuidField struct {
+	FieldName string
+	TableName string
+
+	meta *meta
+}
+
+// NewUUID creates a UuidField of the table whose chained
+// declarations, e.g. constraints, are shared by all its copies
+func NewUUID(tableName string, name string) UuidField {
+	return UuidField{FieldName: name, TableName: tableName, meta: &meta{}}
+}
+
+// NotNull declares the field must not be written as NULL
+func (f UuidField) NotNull() UuidField {
+	getMeta(&f.meta).constraints.NotNull = true
+	return f
+}
+
+// Constraints returns the constraints declared on the field
+func (f UuidField) Constraints() Constraints {
+	return constraintsOf(f.meta)
+}
+
+// AutoGenerate declares that Insert fills an empty model
+// value with a new random UUID, emptied again if the model
+// is not inserted
+func (f UuidField) AutoGenerate() UuidField {
+	getMeta(&f.meta).autoGenerate = true
+	return f
+}
+
+// AutoGenerated reports whether AutoGenerate is declared
+func (f UuidField) AutoGenerated() bool {
+	return f.meta != nil && f.meta.autoGenerate
+}
+
+// Name returns the field name
+func (f UuidField) Name() string {
+	return f.FieldName
+}
+
+// Table returns the table name
+func (f UuidField) Table() string {
+	return f.TableName
+}
+
+// ToSQL returns the SQL representation of the field
+func (f UuidField) ToSQL() (string, []interface{}, error) {
+	return quoteColumn(f.TableName, f.FieldName)
+}
+
+// Eq creates an equality condition (field = value)
+func (f UuidField) Eq(value string) Expr {
+	return &comparison{
+		field: f,
+		op:    "=",
+		value: value,
+	}
+}
+
+// Neq creates a not equal condition (field != value)
+func (f UuidField) Neq(value string) Expr {
+	return &comparison{
+		field: f,
+		op:    "!=",
+		value: value,
+	}
+}
+
+// In creates an IN condition (field IN (values...))
+func (f UuidField) In(values ...string) Expr {
+	if len(values) == 0 {
+		panic("in requires non-empty values")
+	}
+	interfaceValues := make([]interface{}, len(values))
+	for i, v := range values {
+		interfaceValues[i] = v
+	}
+	return &inCondition{
+		field:  f,
+		values: interfaceValues,
+	}
+}
+
+func (f UuidField) IsNull() Expr {
+	return &nullCondition{
+		field:  f,
+		isNull: true,
+	}
+}
+
+func (f UuidField) IsNotNull() Expr {
+	return &nullCondition{
+		field:  f,
+		isNull: false,
+	}
+}
+
+// GenerateUUID returns a random (version 4) UUID
+func GenerateUUID() [16]byte {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		panic(err)
+	}
+	u[6] = (u[6] & 0x0f) | 0x40
+	u[8] = (u[8] & 0x3f) | 0x80
+	return u
+}
+
+// FormatUUID returns the canonical form of a UUID,
+// like 123e4567-e89b-12d3-a456-426614174000
+func FormatUUID(u [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+// UUID is a binary UUID model field, written and scanned
+// in the canonical text form of UuidField columns
+type UUID [16]byte
+
+// String returns the canonical form of the UUID, see FormatUUID
+func (u UUID) String() string {
+	return FormatUUID(u)
+}
+
+// Value implements driver.Valuer
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+// Scan implements sql.Scanner, accepting the canonical text
+// form or the 16 raw bytes. NULL scans to the zero UUID.
+func (u *UUID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*u = UUID{}
+		return nil
+	case string:
+		parsed, err := ParseUUID(v)
+		if err != nil {
+			return err
+		}
+		*u = parsed
+		return nil
+	case []byte:
+		if len(v) == len(u) {
+			copy(u[:], v)
+			return nil
+		}
+		parsed, err := ParseUUID(string(v))
+		if err != nil {
+			return err
+		}
+		*u = parsed
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into UUID", src)
+}
+
+// ParseUUID parses the canonical form of a UUID
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("invalid UUID: %q", s)
+	}
+	src := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	if _, err := hex.Decode(u[:], []byte(src)); err != nil {
+		return u, fmt.Errorf("invalid UUID: %q", s)
+	}
+	return u, nil
+}
@@ -3,9 +3,12 @@ module github.com/xhd2015/arc-orm
 go 1.18
 
 require (
+	github.com/go-sql-driver/mysql v1.7.1
+	github.com/mattn/go-sqlite3 v1.14.17
 	github.com/xhd2015/less-gen v0.0.19
 	github.com/xhd2015/xgo v1.1.7
 	golang.org/x/tools v0.21.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
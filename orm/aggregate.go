@@ -0,0 +1,54 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sql"
+)
+
+// aggregateResult receives the value of a single aggregate
+type aggregateResult struct {
+	Value *float64 `orm:"column:value" json:"value"`
+}
+
+// Sum returns SUM(f) of the records matching the conditions, 0 if there is none
+func (o *ORM[T, P]) Sum(ctx context.Context, f field.Field, conditions ...field.Expr) (float64, error) {
+	return o.aggregate(ctx, sql.Sum(f), conditions)
+}
+
+// Avg returns AVG(f) of the records matching the conditions, 0 if there is none
+func (o *ORM[T, P]) Avg(ctx context.Context, f field.Field, conditions ...field.Expr) (float64, error) {
+	return o.aggregate(ctx, sql.Avg(f), conditions)
+}
+
+// Min returns MIN(f) of the records matching the conditions, 0 if there is none
+func (o *ORM[T, P]) Min(ctx context.Context, f field.Field, conditions ...field.Expr) (float64, error) {
+	return o.aggregate(ctx, sql.Min(f), conditions)
+}
+
+// Max returns MAX(f) of the records matching the conditions, 0 if there is none
+func (o *ORM[T, P]) Max(ctx context.Context, f field.Field, conditions ...field.Expr) (float64, error) {
+	return o.aggregate(ctx, sql.Max(f), conditions)
+}
+
+func (o *ORM[T, P]) aggregate(ctx context.Context, agg sql.AggregateFunc, conditions []field.Expr) (float64, error) {
+	querySQL, args, err := o.newSelect(agg.As("value")).
+		Where(o.scoped(ctx, conditions)...).
+		SQL()
+	if err != nil {
+		return 0, fmt.Errorf("sql: %w", err)
+	}
+
+	var results []*aggregateResult
+	err = o.getEngine(ctx).Query(ctx, querySQL, args, &results)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute %s: %w", agg.Name(), err)
+	}
+	if len(results) == 0 || results[0].Value == nil {
+		// aggregates of no rows are NULL
+		return 0, nil
+	}
+	return *results[0].Value, nil
+}
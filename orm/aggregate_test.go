@@ -0,0 +1,80 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+func TestAggregate(t *testing.T) {
+	testTable := table.New("orders")
+	testTable.Int64("id")
+	amount := testTable.Float64("amount")
+	status := testTable.String("status")
+
+	var gotSQL string
+	var gotArgs []interface{}
+	value := 12.5
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			gotSQL = sql
+			gotArgs = args
+			*result.(*[]*aggregateResult) = []*aggregateResult{{Value: &value}}
+			return nil
+		},
+	}
+	orm := &ORM[TestModel, TestModelOptional]{table: testTable, engine: mockEngine}
+
+	tests := []struct {
+		name        string
+		fn          func() (float64, error)
+		expectedSQL string
+	}{
+		{"Sum", func() (float64, error) { return orm.Sum(context.Background(), amount, status.Eq("paid")) },
+			"SELECT SUM(`orders`.`amount`) AS `value` FROM `orders` WHERE `orders`.`status` = ?"},
+		{"Avg", func() (float64, error) { return orm.Avg(context.Background(), amount, status.Eq("paid")) },
+			"SELECT AVG(`orders`.`amount`) AS `value` FROM `orders` WHERE `orders`.`status` = ?"},
+		{"Min", func() (float64, error) { return orm.Min(context.Background(), amount, status.Eq("paid")) },
+			"SELECT MIN(`orders`.`amount`) AS `value` FROM `orders` WHERE `orders`.`status` = ?"},
+		{"Max", func() (float64, error) { return orm.Max(context.Background(), amount, status.Eq("paid")) },
+			"SELECT MAX(`orders`.`amount`) AS `value` FROM `orders` WHERE `orders`.`status` = ?"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fn()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != 12.5 {
+				t.Errorf("Expected 12.5, got %v", got)
+			}
+			if gotSQL != tt.expectedSQL {
+				t.Errorf("Expected SQL:\n%s\ngot:\n%s", tt.expectedSQL, gotSQL)
+			}
+			if len(gotArgs) != 1 || gotArgs[0] != "paid" {
+				t.Errorf("Expected args [paid], got %v", gotArgs)
+			}
+		})
+	}
+}
+
+func TestAggregate_NoRows(t *testing.T) {
+	testTable := table.New("orders")
+	amount := testTable.Float64("amount")
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			*result.(*[]*aggregateResult) = []*aggregateResult{{Value: nil}}
+			return nil
+		},
+	}
+	orm := &ORM[TestModel, TestModelOptional]{table: testTable, engine: mockEngine}
+
+	got, err := orm.Sum(context.Background(), amount)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 0 {
+		t.Errorf("Expected 0, got %v", got)
+	}
+}
@@ -0,0 +1,43 @@
+package orm
+
+import (
+	"reflect"
+
+	"github.com/xhd2015/arc-orm/orm/column"
+)
+
+// ColumnName returns the column a model field maps to by the default
+// naming strategy, which is the `orm:"column:name"` tag if present,
+// otherwise the snake_case of the field name
+func ColumnName(f reflect.StructField) string {
+	return column.Name(f, false)
+}
+
+// Ignored reports whether a model field is tagged `orm:"-"`, like a
+// computed or cached value, which maps to no column: it is skipped
+// by Validate, queries, Insert and the updates
+func Ignored(f reflect.StructField) bool {
+	return column.Ignored(f)
+}
+
+// optionalColumnName returns the column of an optional model field,
+// falling back to the tag of the model field with the same name
+func (o *ORM[T, P]) optionalColumnName(f reflect.StructField) string {
+	if column.Tag(f.Tag.Get("orm")) == "" {
+		modelField, ok := reflect.TypeOf((*T)(nil)).Elem().FieldByName(f.Name)
+		if ok {
+			return o.opts.naming.ColumnName(modelField)
+		}
+	}
+	return o.opts.naming.ColumnName(f)
+}
+
+// optionalIgnored reports whether an optional model field is ignored,
+// by its own tag or the tag of the model field with the same name
+func optionalIgnored[T any](f reflect.StructField) bool {
+	if Ignored(f) {
+		return true
+	}
+	modelField, ok := reflect.TypeOf((*T)(nil)).Elem().FieldByName(f.Name)
+	return ok && Ignored(modelField)
+}
@@ -0,0 +1,127 @@
+// Package column maps the fields of models to table columns, shared
+// by the orm package and the engines scanning rows into models
+package column
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/xhd2015/less-gen/strcase"
+)
+
+// RowScanner is implemented by models with generated column
+// mapping, engines use it to scan rows without reflection
+type RowScanner interface {
+	// ScanRow returns pointers to the model fields receiving
+	// the columns, nil for columns without a field
+	ScanRow(columns []string) []interface{}
+}
+
+// Name returns the column a model field maps to, which is the
+// `orm:"column:name"` tag if present, otherwise the snake_case of
+// the field name, spelling initialisms like ID as one word if
+// initialisms is true, e.g. UserIDs maps to user_ids
+func Name(f reflect.StructField, initialisms bool) string {
+	if column := Tag(f.Tag.Get("orm")); column != "" {
+		return column
+	}
+	name := f.Name
+	if initialisms {
+		name = capitalizeInitialisms(name)
+	}
+	return strcase.CamelToSnake(name)
+}
+
+// Tag extracts the column from an orm tag like `column:legacy_name`,
+// options are separated by ';'
+func Tag(tag string) string {
+	for _, opt := range strings.Split(tag, ";") {
+		opt = strings.TrimSpace(opt)
+		if strings.HasPrefix(opt, "column:") {
+			return strings.TrimSpace(strings.TrimPrefix(opt, "column:"))
+		}
+	}
+	return ""
+}
+
+// Ignored reports whether a model field is tagged `orm:"-"`,
+// which maps to no column
+func Ignored(f reflect.StructField) bool {
+	return strings.TrimSpace(f.Tag.Get("orm")) == "-"
+}
+
+// commonInitialisms are the initialisms spelled uppercase,
+// following the list of golint
+var commonInitialisms = []string{
+	"ACL", "API", "ASCII", "CPU", "CSS", "DNS", "EOF", "GUID", "HTML", "HTTP",
+	"HTTPS", "ID", "IP", "JSON", "LHS", "QPS", "RAM", "RHS", "RPC", "SLA",
+	"SMTP", "SQL", "SSH", "TCP", "TLS", "TTL", "UDP", "UI", "UID", "UUID",
+	"URI", "URL", "UTF8", "VM", "XML", "XMPP", "XSRF", "XSS",
+}
+
+var initialisms = func() map[string]bool {
+	m := make(map[string]bool, len(commonInitialisms))
+	for _, s := range commonInitialisms {
+		m[s] = true
+	}
+	return m
+}()
+
+// IsInitialism reports whether the uppercase word is a common
+// initialism, e.g. ID or URL
+func IsInitialism(word string) bool {
+	return initialisms[word]
+}
+
+// longestFirstInitialisms are matched in order, so HTTPS wins over HTTP
+var longestFirstInitialisms = func() []string {
+	list := append([]string(nil), commonInitialisms...)
+	sort.SliceStable(list, func(i, j int) bool {
+		return len(list[i]) > len(list[j])
+	})
+	return list
+}()
+
+// capitalizeInitialisms rewrites the initialisms starting words
+// of a field name as words, e.g. UserIDs -> UserIds,
+// HTTPSProxy -> HttpsProxy, so they split like any other word
+func capitalizeInitialisms(name string) string {
+	var b strings.Builder
+	wordStart := true
+	for i := 0; i < len(name); {
+		if wordStart {
+			if initialism := initialismAt(name, i); initialism != "" {
+				b.WriteString(initialism[:1] + strings.ToLower(initialism[1:]))
+				i += len(initialism)
+				continue
+			}
+		}
+		wordStart = !isUpper(name[i])
+		b.WriteByte(name[i])
+		i++
+	}
+	return b.String()
+}
+
+// initialismAt returns the initialism at i followed by the end,
+// an uppercase letter, a digit or a plural 's' ending the word
+func initialismAt(name string, i int) string {
+	for _, initialism := range longestFirstInitialisms {
+		if !strings.HasPrefix(name[i:], initialism) {
+			continue
+		}
+		end := i + len(initialism)
+		if end < len(name) && name[end] == 's' {
+			end++
+		}
+		if end == len(name) || isUpper(name[end]) || (name[end] >= '0' && name[end] <= '9') {
+			return initialism
+		}
+	}
+	return ""
+}
+
+func isUpper(c byte) bool {
+	return c >= 'A' && c <= 'Z'
+}
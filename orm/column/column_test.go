@@ -0,0 +1,40 @@
+package column
+
+import (
+	"reflect"
+	"testing"
+)
+
+type legacyUser struct {
+	UserIDs  []int64
+	HTTPSURL string
+	UserName string `orm:"column:uname"`
+	Cached   string `orm:"-"`
+}
+
+func TestName(t *testing.T) {
+	typ := reflect.TypeOf(legacyUser{})
+	tests := []struct {
+		field       string
+		initialisms bool
+		expected    string
+	}{
+		{"UserIDs", false, "user_i_ds"},
+		{"UserIDs", true, "user_ids"},
+		{"HTTPSURL", true, "https_url"},
+		{"UserName", false, "uname"},
+		{"UserName", true, "uname"},
+	}
+	for _, tt := range tests {
+		f, _ := typ.FieldByName(tt.field)
+		if got := Name(f, tt.initialisms); got != tt.expected {
+			t.Errorf("Name(%s, %v): expected %s, got %s", tt.field, tt.initialisms, tt.expected, got)
+		}
+	}
+
+	cached, _ := typ.FieldByName("Cached")
+	userName, _ := typ.FieldByName("UserName")
+	if !Ignored(cached) || Ignored(userName) {
+		t.Errorf("Expected only Cached ignored")
+	}
+}
@@ -0,0 +1,119 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+type LegacyUser struct {
+	Id       int64
+	UserName string `orm:"column:uname"`
+	URL      string `json:"url" orm:"column:homepage"`
+}
+
+type LegacyUserOptional struct {
+	Id       *int64
+	UserName *string
+	URL      *string `orm:"column:homepage"`
+}
+
+func newLegacyUserTable() table.Table {
+	tbl := table.New("legacy_users")
+	tbl.Int64("id")
+	tbl.String("uname")
+	tbl.String("homepage")
+	return tbl
+}
+
+// TestColumnTag tests that `orm:"column:..."` overrides the derived column name
+func TestColumnTag(t *testing.T) {
+	mockEngine := &MockEngine{}
+	orm, err := bind[LegacyUser, LegacyUserOptional](mockEngine, newLegacyUserTable())
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	_, err = orm.Insert(context.Background(), &LegacyUser{UserName: "alice", URL: "https://a.b"})
+	if err != nil {
+		t.Fatalf("Failed to insert: %v", err)
+	}
+	expectedSQL := "INSERT INTO `legacy_users` SET `uname`=?, `homepage`=?"
+	if got := mockEngine.ExecInsertCalls[0].SQL; got != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, got)
+	}
+
+	// the optional model falls back to the tag of the model field
+	name := "bob"
+	err = orm.UpdateBy(context.Background(), &LegacyUserOptional{UserName: &name}, &LegacyUserOptional{UserName: &name})
+	if err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	expectedSQL = "UPDATE `legacy_users` SET `uname`=? WHERE `legacy_users`.`uname` = ?"
+	if got := mockEngine.ExecCalls[0].SQL; got != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, got)
+	}
+}
+
+func TestColumnTag_Mismatch(t *testing.T) {
+	tbl := table.New("legacy_users")
+	tbl.Int64("id")
+	tbl.String("user_name")
+	tbl.String("homepage")
+	_, err := bind[LegacyUser, LegacyUserOptional](&MockEngine{}, tbl)
+	if err == nil {
+		t.Fatalf("Expected validation error for column uname missing from table")
+	}
+}
+
+type cachedUser struct {
+	Id          int64
+	Name        string
+	DisplayName string `orm:"-"`
+}
+
+type cachedUserOptional struct {
+	Id          *int64
+	Name        *string
+	DisplayName *string
+}
+
+// TestIgnoredField tests that `orm:"-"` fields map to no column
+func TestIgnoredField(t *testing.T) {
+	tbl := table.New("users")
+	tbl.Int64("id")
+	tbl.String("name")
+
+	mockEngine := &MockEngine{}
+	orm, err := bind[cachedUser, cachedUserOptional](mockEngine, tbl)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.Background()
+	_, err = orm.Insert(ctx, &cachedUser{Name: "alice", DisplayName: "Alice A."})
+	if err != nil {
+		t.Fatalf("Failed to insert: %v", err)
+	}
+	expectedSQL := "INSERT INTO `users` SET `name`=?"
+	if got := mockEngine.ExecInsertCalls[0].SQL; got != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, got)
+	}
+
+	// the optional model field is ignored by the tag of the model field
+	name := "bob"
+	display := "Bob B."
+	err = orm.UpdateBy(ctx, &cachedUserOptional{Name: &name, DisplayName: &display}, &cachedUserOptional{Name: &name, DisplayName: &display})
+	if err != nil {
+		t.Fatalf("Failed to update: %v", err)
+	}
+	expectedSQL = "UPDATE `users` SET `name`=? WHERE `users`.`name` = ?"
+	if got := mockEngine.ExecCalls[0].SQL; got != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, got)
+	}
+
+	if _, err := orm.FindByExample(ctx, &cachedUser{DisplayName: "Alice A."}); err != nil {
+		t.Errorf("Expected FindByExample to skip the ignored field, got %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+package orm
+
+import (
+	"context"
+)
+
+// WithComment appends the comment returned by comment for the context of
+// each statement executed by the ORM, e.g. request metadata put into the
+// context by a middleware, so slow queries can be attributed to their
+// call sites. Empty comments are skipped, see sql.AppendComment.
+func WithComment(comment func(ctx context.Context) string) Option {
+	return func(opts *options) {
+		opts.comment = comment
+	}
+}
@@ -5,9 +5,10 @@ import (
 	"reflect"
 
 	"github.com/xhd2015/arc-orm/field"
-	"github.com/xhd2015/less-gen/strcase"
 )
 
+// ToConditions converts the non-nil fields of condition
+// to table-qualified equality conditions
 func (o *ORM[T, P]) ToConditions(condition *P) ([]field.Expr, error) {
 	if condition == nil {
 		return nil, fmt.Errorf("requires condition")
@@ -18,16 +19,11 @@ func (o *ORM[T, P]) ToConditions(condition *P) ([]field.Expr, error) {
 	if rv.Kind() != reflect.Struct {
 		return nil, fmt.Errorf("condition must be a struct")
 	}
-	t := rv.Type()
-	n := rv.NumField()
-	for i := 0; i < n; i++ {
-		fieldV := rv.Field(i)
-		field := t.Field(i)
-		if field.Anonymous {
+	for _, mf := range o.describe().optional {
+		fieldV := rv.Field(mf.index)
+		if mf.field.Anonymous {
 			continue
 		}
-		fieldName := field.Name
-		colName := strcase.CamelToSnake(fieldName)
 
 		condV := fieldV
 		if fieldV.Kind() == reflect.Ptr {
@@ -37,44 +33,87 @@ func (o *ORM[T, P]) ToConditions(condition *P) ([]field.Expr, error) {
 			condV = fieldV.Elem()
 		}
 
-		sqlConditions = append(sqlConditions, &rawCondition{
-			sql:  fmt.Sprintf("`%s` = ?", colName),
-			args: []interface{}{condV.Interface()},
+		tableField := mf.tableField
+		if tableField == nil {
+			return nil, fmt.Errorf("column %s not found in table %s", mf.column, o.table.Name())
+		}
+		value := condV.Interface()
+		if u, ok := value.(field.UUID); ok {
+			value = u.String()
+		}
+		sqlConditions = append(sqlConditions, &opCondition{
+			field:  tableField,
+			op:     "=",
+			values: []interface{}{value},
 		})
 	}
 
 	return sqlConditions, nil
 }
 
+// tableField returns the table field of the column
+func (o *ORM[T, P]) tableField(column string) (field.Field, error) {
+	if f, ok := o.describe().tableFields[column]; ok {
+		return f, nil
+	}
+	return nil, fmt.Errorf("column %s not found in table %s", column, o.table.Name())
+}
+
 func (o *ORM[T, P]) toIDCondition(id int64) (field.Expr, error) {
 	if id == 0 {
 		return nil, fmt.Errorf("requires id, got 0")
 	}
-	// Validate that the table has an 'id' field
-	hasIDField := false
-	for _, f := range o.table.Fields() {
-		if f.Name() == "id" {
-			hasIDField = true
-			break
+	idField, err := o.idField()
+	if err != nil {
+		return nil, err
+	}
+	return idField.Eq(id), nil
+}
+
+// idField returns the int64 primary key field of the table, 'id' by default
+func (o *ORM[T, P]) idField() (field.Int64Field, error) {
+	d := o.describe()
+	if d.pkColumn == "id" {
+		// Validate that the table has an 'id' field
+		if _, ok := d.tableFields["id"]; !ok {
+			return field.Int64Field{}, ErrMissingIDField
 		}
+		return field.Int64Field{
+			FieldName: "id",
+			TableName: o.table.Name(),
+		}, nil
 	}
-	if !hasIDField {
-		return nil, ErrMissingIDField
+	pk, err := o.keyField()
+	if err != nil {
+		return field.Int64Field{}, err
 	}
-
-	idField := field.Int64Field{
-		FieldName: "id",
-		TableName: o.table.Name(),
+	idField, ok := pk.(field.Int64Field)
+	if !ok {
+		return field.Int64Field{}, fmt.Errorf("primary key %s is not an Int64 field, use the *ByKey methods", pk.Name())
 	}
-
-	return idField.Eq(id), nil
+	return idField, nil
 }
 
-type rawCondition struct {
-	sql  string
-	args []interface{}
+// keyField returns the primary key field of the table, of any type
+func (o *ORM[T, P]) keyField() (field.Field, error) {
+	d := o.describe()
+	f, ok := d.tableFields[d.pkColumn]
+	if !ok {
+		if d.pkColumn == "id" {
+			return nil, ErrMissingIDField
+		}
+		return nil, fmt.Errorf("primary key %s not found in table %s", d.pkColumn, o.table.Name())
+	}
+	return f, nil
 }
 
-func (c *rawCondition) ToSQL() (string, []interface{}, error) {
-	return c.sql, c.args, nil
+func (o *ORM[T, P]) toKeyCondition(key interface{}) (field.Expr, error) {
+	if key == nil || reflect.ValueOf(key).IsZero() {
+		return nil, fmt.Errorf("requires key, got %v", key)
+	}
+	keyField, err := o.keyField()
+	if err != nil {
+		return nil, err
+	}
+	return &opCondition{field: keyField, op: "=", values: []interface{}{key}}, nil
 }
@@ -0,0 +1,99 @@
+package orm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/xhd2015/arc-orm/field"
+)
+
+// Violation is a value breaking a constraint declared on a table field
+type Violation struct {
+	Column string
+	// Constraint is one of not_null, max_len, min and max
+	Constraint string
+	Message    string
+}
+
+// ValidationError is returned by writes whose values break the
+// constraints declared on table fields, before reaching the database
+type ValidationError struct {
+	Table      string
+	Violations []Violation
+}
+
+// Error implements error
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, v.Column+" "+v.Message)
+	}
+	return fmt.Sprintf("validate %s: %s", e.Table, strings.Join(msgs, "; "))
+}
+
+// constraintChecker collects the violations of the values written by a statement
+type constraintChecker struct {
+	violations []Violation
+}
+
+// check checks the value written to the field, nil for NULL
+func (c *constraintChecker) check(f field.Field, value interface{}) {
+	constrained, ok := f.(field.Constrained)
+	if !ok {
+		return
+	}
+	cons := constrained.Constraints()
+	if value == nil {
+		if cons.NotNull {
+			c.add(f, "not_null", "must not be null")
+		}
+		return
+	}
+	if cons.MaxLen > 0 {
+		if s, ok := value.(string); ok {
+			if n := utf8.RuneCountInString(s); n > cons.MaxLen {
+				c.add(f, "max_len", fmt.Sprintf("length %d exceeds %d", n, cons.MaxLen))
+			}
+		}
+	}
+	if cons.Min == nil && cons.Max == nil {
+		return
+	}
+	num, ok := toFloat(value)
+	if !ok {
+		return
+	}
+	if cons.Min != nil && num < *cons.Min {
+		c.add(f, "min", fmt.Sprintf("%v is less than %v", value, *cons.Min))
+	}
+	if cons.Max != nil && num > *cons.Max {
+		c.add(f, "max", fmt.Sprintf("%v is greater than %v", value, *cons.Max))
+	}
+}
+
+func (c *constraintChecker) add(f field.Field, constraint string, msg string) {
+	c.violations = append(c.violations, Violation{Column: f.Name(), Constraint: constraint, Message: msg})
+}
+
+// err returns the ValidationError of the table, nil if no violations
+func (c *constraintChecker) err(table string) error {
+	if len(c.violations) == 0 {
+		return nil
+	}
+	return &ValidationError{Table: table, Violations: c.violations}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
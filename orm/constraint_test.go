@@ -0,0 +1,74 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+func TestConstraints(t *testing.T) {
+	testTable := table.New("users")
+	testTable.Int64("id")
+	testTable.String("name").NotNull().MaxLen(5)
+	testTable.Int64("age").Min(0).Max(150)
+
+	mockEngine := &MockEngine{}
+	orm := &ORM[TestModel, TestModelOptional]{
+		table:  testTable,
+		engine: mockEngine,
+	}
+	ctx := context.Background()
+
+	_, err := orm.Insert(ctx, &TestModel{Name: "Alexander", Age: -1})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %v", err)
+	}
+	if len(validationErr.Violations) != 2 ||
+		validationErr.Violations[0].Constraint != "max_len" ||
+		validationErr.Violations[1].Constraint != "min" {
+		t.Errorf("Expected max_len and min violations, got %+v", validationErr.Violations)
+	}
+	if len(mockEngine.ExecInsertCalls) != 0 {
+		t.Errorf("Expected no insert executed")
+	}
+
+	age := 200
+	err = orm.UpdateByID(ctx, 1, &TestModelOptional{Age: &age})
+	if !errors.As(err, &validationErr) || validationErr.Violations[0].Column != "age" || validationErr.Violations[0].Constraint != "max" {
+		t.Fatalf("Expected max violation of age, got %v", err)
+	}
+
+	name := "Bob"
+	_, err = orm.Insert(ctx, &TestModel{Name: name, Age: 30})
+	if err != nil {
+		t.Fatalf("Expected valid insert, got %v", err)
+	}
+	err = orm.UpdateByID(ctx, 1, &TestModelOptional{Name: &name})
+	if err != nil {
+		t.Fatalf("Expected valid update, got %v", err)
+	}
+}
+
+func TestConstraints_NotNull(t *testing.T) {
+	testTable := table.New("users")
+	testTable.Int64("id")
+	testTable.String("name").NotNull()
+	testTable.Int64("age")
+
+	orm := &ORM[TestModelOptional, TestModelOptional]{
+		table:  testTable,
+		engine: &MockEngine{},
+	}
+	_, err := orm.Insert(context.Background(), &TestModelOptional{})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Violations[0].Constraint != "not_null" {
+		t.Fatalf("Expected not_null violation, got %v", err)
+	}
+	expected := "validate users: name must not be null"
+	if err.Error() != expected {
+		t.Errorf("Expected error %q, got %q", expected, err.Error())
+	}
+}
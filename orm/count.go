@@ -7,41 +7,67 @@ import (
 
 	"github.com/xhd2015/arc-orm/field"
 	"github.com/xhd2015/arc-orm/sql"
+	"github.com/xhd2015/arc-orm/sql/expr"
 )
 
 type ORMCountBuilder[T any, P any] struct {
 	builder *sql.SelectBuilder
+	count   sql.Expr
+	fields  []sql.Expr
 	orm     *ORM[T, P]
 }
 
 // Count executes a count query and returns the matching records
 // The model must have a Count field of type int64 to receive the count value
 func (c *ORM[T, P]) Count(fields ...sql.Expr) *ORMCountBuilder[T, P] {
-	// TODO: make this validate once when creating the ORM instance
-	// Validate that type T has a Count field of type int64
-	modelType := reflect.TypeOf((*T)(nil)).Elem()
-
-	// Find the Count field
-	countField, found := modelType.FieldByName("Count")
-	if !found {
-		panic(ErrMissingCountField)
+	// Validate that type T has a Count field of type int64, resolved once at Bind
+	if err := c.describe().countErr; err != nil {
+		panic(err)
 	}
 
-	// Validate that Count field is int64
-	if countField.Type.Kind() != reflect.Int64 {
-		panic(ErrWrongCountFieldType)
+	return &ORMCountBuilder[T, P]{
+		builder: c.newSelect(),
+		count:   sql.Count(sql.All).As("count"),
+		fields:  fields,
+		orm:     c,
 	}
+}
 
-	allFields := make([]sql.Expr, 0, len(fields)+1)
-	countFieldExpr := sql.Count(sql.All).As("count")
-
-	allFields = append(allFields, countFieldExpr)
-	allFields = append(allFields, fields...)
+// Distinct counts the distinct values of the field, i.e. COUNT(DISTINCT field)
+func (c *ORMCountBuilder[T, P]) Distinct(f field.Field) *ORMCountBuilder[T, P] {
+	c.count = sql.CountDistinct(f).As("count")
+	return c
+}
 
+// Clone returns a copy of the builder, see ORMSelectBuilder.Clone
+func (c *ORMCountBuilder[T, P]) Clone() *ORMCountBuilder[T, P] {
 	return &ORMCountBuilder[T, P]{
-		builder: sql.Select(allFields...).From(c.table.Name()),
-		orm:     c,
+		builder: c.builder.Clone(),
+		count:   c.count,
+		fields:  c.fields,
+		orm:     c.orm,
+	}
+}
+
+// SQL generates the SQL string and parameters
+func (c *ORMCountBuilder[T, P]) SQL() (string, []interface{}, error) {
+	return c.buildSQL(c.builder)
+}
+
+// scopedSQL generates the SQL with the default scope of ctx, see WithDefaultScope
+func (c *ORMCountBuilder[T, P]) scopedSQL(ctx context.Context) (string, []interface{}, error) {
+	builder := c.builder
+	if scope := c.orm.scoped(ctx, nil); len(scope) > 0 {
+		builder = builder.Clone().Where(scope...)
 	}
+	return c.buildSQL(builder)
+}
+
+func (c *ORMCountBuilder[T, P]) buildSQL(builder *sql.SelectBuilder) (string, []interface{}, error) {
+	allFields := make([]sql.Expr, 0, len(c.fields)+1)
+	allFields = append(allFields, c.count)
+	allFields = append(allFields, c.fields...)
+	return builder.Fields(allFields...).SQL()
 }
 
 func (c *ORMCountBuilder[T, P]) Exclude(fields ...field.Field) *ORMCountBuilder[T, P] {
@@ -59,6 +85,31 @@ func (c *ORMCountBuilder[T, P]) GroupBy(exprs ...field.Expr) *ORMCountBuilder[T,
 	return c
 }
 
+func (c *ORMCountBuilder[T, P]) Having(conditions ...field.Expr) *ORMCountBuilder[T, P] {
+	c.builder.Having(conditions...)
+	return c
+}
+
+// Join joins the table given by its logical name, prefixed like
+// the ORM table, see WithTablePrefix
+func (c *ORMCountBuilder[T, P]) Join(tableName string, condition field.Expr) *ORMCountBuilder[T, P] {
+	physical, alias := c.orm.joinTable(tableName)
+	c.builder.JoinAs(physical, alias, condition)
+	return c
+}
+
+// LeftJoin left joins the table given by its logical name, see Join
+func (c *ORMCountBuilder[T, P]) LeftJoin(tableName string, condition field.Expr) *ORMCountBuilder[T, P] {
+	physical, alias := c.orm.joinTable(tableName)
+	c.builder.LeftJoinAs(physical, alias, condition)
+	return c
+}
+
+func (c *ORMCountBuilder[T, P]) OrderBy(orderFields ...expr.Expr) *ORMCountBuilder[T, P] {
+	c.builder.OrderBy(orderFields...)
+	return c
+}
+
 func (c *ORMCountBuilder[T, P]) Limit(limit int) *ORMCountBuilder[T, P] {
 	c.builder.Limit(limit)
 	return c
@@ -77,21 +128,33 @@ func (c *ORMCountBuilder[T, P]) Query(ctx context.Context) (int64, error) {
 	if one == nil {
 		return 0, fmt.Errorf("count query expect at least one row")
 	}
-	count := reflect.ValueOf(one).Elem().FieldByName("Count").Int()
+	count := reflect.ValueOf(one).Elem().Field(c.orm.describe().countIndex).Int()
 	return int64(count), nil
 }
 
 func (c *ORMCountBuilder[T, P]) QueryMany(ctx context.Context) ([]*T, error) {
-	sql, args, err := c.builder.SQL()
+	builder := c.builder
+	if scope := c.orm.scoped(ctx, nil); len(scope) > 0 {
+		builder = builder.Clone().Where(scope...)
+	}
+	builder, limited := c.orm.limitRows(builder)
+	sql, args, err := c.buildSQL(builder)
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.orm.QuerySQL(ctx, sql, args)
 	if err != nil {
 		return nil, err
 	}
-	return c.orm.QuerySQL(ctx, sql, args)
+	if err := c.orm.checkRows(limited, len(results)); err != nil {
+		return nil, err
+	}
+	return results, nil
 }
 
 func (c *ORMCountBuilder[T, P]) QueryOneData(ctx context.Context) (*T, error) {
 	c.builder.Limit(1)
-	sql, args, err := c.builder.SQL()
+	sql, args, err := c.scopedSQL(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -4,21 +4,31 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/xhd2015/arc-orm/engine"
 	"github.com/xhd2015/arc-orm/field"
-	"github.com/xhd2015/arc-orm/sql"
 )
 
-// DeleteByID deletes a record by its ID
+// DeleteByID deletes a record by its ID, returning an error wrapping
+// ErrNoRowsAffected if it does not exist, see ErrNoRowsAffected
 func (o *ORM[T, P]) DeleteByID(ctx context.Context, id int64) error {
 	idCondition, err := o.toIDCondition(id)
 	if err != nil {
 		return fmt.Errorf("failed to convert id to condition: %w", err)
 	}
 
-	return o.deleteBy(ctx, []field.Expr{idCondition})
+	return o.deleteBy(ctx, []field.Expr{idCondition}, []interface{}{id})
 }
 
-// DeleteByID deletes a record by its ID
+// DeleteByKey deletes a record by its primary key of any type, see GetByKey
+func (o *ORM[T, P]) DeleteByKey(ctx context.Context, key interface{}) error {
+	keyCondition, err := o.toKeyCondition(key)
+	if err != nil {
+		return fmt.Errorf("failed to convert key to condition: %w", err)
+	}
+	return o.deleteBy(ctx, []field.Expr{keyCondition}, []interface{}{key})
+}
+
+// DeleteBy deletes the records matching the non-nil fields of condition
 func (o *ORM[T, P]) DeleteBy(ctx context.Context, condition *P) error {
 	if condition == nil {
 		return fmt.Errorf("requires condition")
@@ -29,7 +39,7 @@ func (o *ORM[T, P]) DeleteBy(ctx context.Context, condition *P) error {
 		return fmt.Errorf("failed to convert condition to SQL conditions: %w", err)
 	}
 
-	return o.deleteBy(ctx, sqlConditions)
+	return o.deleteBy(ctx, sqlConditions, nil)
 }
 
 // DeleteByID deletes a record by its ID
@@ -38,28 +48,113 @@ func (o *ORM[T, P]) DeleteWhere(ctx context.Context, conditions ...field.Expr) e
 		return fmt.Errorf("requires conditions")
 	}
 
-	return o.deleteBy(ctx, conditions)
+	return o.deleteBy(ctx, conditions, nil)
 }
 
-func (o *ORM[T, P]) deleteBy(ctx context.Context, conditions []field.Expr) error {
+// deleteBy deletes the records matching the conditions, keys are
+// the primary keys of the records reported to OnWrite, if known
+func (o *ORM[T, P]) deleteBy(ctx context.Context, conditions []field.Expr, keys []interface{}) error {
 	if len(conditions) == 0 {
 		return fmt.Errorf("requires conditions")
 	}
 
-	// Create the SQL Delete builder
-	query, args, err := sql.DeleteFrom(o.table.Name()).
-		Where(conditions...).
-		SQL()
-
+	// Create the SQL Delete builder, the default scope does
+	// not count as a condition under WithSafeMode
+	builder := o.newDelete().Where(conditions...)
+	if scope := o.scoped(ctx, nil); len(scope) > 0 {
+		if _, _, err := builder.SQL(); err != nil {
+			return fmt.Errorf("sql: %w", err)
+		}
+		builder.Where(scope...)
+	}
+	query, args, err := builder.SQL()
 	if err != nil {
 		return fmt.Errorf("sql: %w", err)
 	}
 
 	// Execute the delete
-	err = o.engine.GetEngine().Exec(ctx, query, args)
+	affected, err := o.execAffected(ctx, query, args)
 	if err != nil {
 		return fmt.Errorf("failed to execute DeleteByID: %w", err)
 	}
+	if affected == 0 && len(keys) > 0 {
+		return o.keyError(ErrNoRowsAffected, keys)
+	}
+	o.emitWrite(WriteDelete, keys)
+
+	return nil
+}
 
+// idsChunkSize is the max number of ids in a single IN clause
+const idsChunkSize = 1000
+
+// DeleteByIDs deletes the records by their IDs with DELETE ... WHERE id IN (...),
+// splitting very large id sets into chunks.
+// It returns the number of rows affected, or -1 if the engine
+// does not implement engine.AffectedExecer.
+func (o *ORM[T, P]) DeleteByIDs(ctx context.Context, ids []int64) (int64, error) {
+	idField, err := o.idField()
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for start := 0; start < len(ids); start += idsChunkSize {
+		end := start + idsChunkSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		query, args, err := o.newDelete().
+			Where(o.scoped(ctx, []field.Expr{idField.In(ids[start:end]...)})...).
+			SQL()
+		if err != nil {
+			return 0, fmt.Errorf("sql: %w", err)
+		}
+		affected, err := o.execAffected(ctx, query, args)
+		if err != nil {
+			return 0, fmt.Errorf("failed to execute DeleteByIDs: %w", err)
+		}
+		if affected < 0 || total < 0 {
+			total = -1
+		} else {
+			total += affected
+		}
+	}
+	if len(ids) > 0 {
+		o.emitWrite(WriteDelete, int64Keys(ids))
+	}
+	return total, nil
+}
+
+// execAffected executes the sql and returns the number of rows affected,
+// or -1 if the engine does not report it
+func (o *ORM[T, P]) execAffected(ctx context.Context, query string, args []interface{}) (int64, error) {
+	eng := o.getEngine(ctx)
+	if execer, ok := eng.(engine.AffectedExecer); ok {
+		return execer.ExecAffected(ctx, query, args)
+	}
+	err := eng.Exec(ctx, query, args)
+	if err != nil {
+		return 0, err
+	}
+	return -1, nil
+}
+
+// Truncate empties the table with TRUNCATE TABLE, resetting
+// AUTO_INCREMENT, e.g. before loading test fixtures. It targets the
+// physical table, see WithTable and WithTablePrefix, and is refused
+// under an active default scope since it removes the rows of all scopes.
+func (o *ORM[T, P]) Truncate(ctx context.Context) error {
+	if len(o.scoped(ctx, nil)) > 0 {
+		return fmt.Errorf("Truncate cannot be scoped, use Unscoped")
+	}
+	name := o.physicalTable()
+	if err := field.ValidateTable(name); err != nil {
+		return fmt.Errorf("sql: %w", err)
+	}
+	err := o.getEngine(ctx).Exec(ctx, "TRUNCATE TABLE "+field.QuoteTable(name), nil)
+	if err != nil {
+		return fmt.Errorf("failed to execute Truncate: %w", err)
+	}
+	o.emitWrite(WriteDelete, nil)
 	return nil
 }
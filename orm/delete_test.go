@@ -0,0 +1,182 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sqltest"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// MockAffectedEngine reports every row of the IN clause as affected
+type MockAffectedEngine struct {
+	MockEngine
+}
+
+func (m *MockAffectedEngine) ExecAffected(ctx context.Context, sql string, args []interface{}) (int64, error) {
+	m.ExecCalls = append(m.ExecCalls, ExecCall{SQL: sql, Args: args})
+	return int64(len(args)), nil
+}
+
+func (m *MockAffectedEngine) GetEngine() engine.Engine {
+	return m
+}
+
+func TestDeleteByIDs(t *testing.T) {
+	mockEngine := &MockAffectedEngine{}
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name")
+	orm := &ORM[TestModel, TestModelOptional]{
+		table:  testTable,
+		engine: mockEngine,
+	}
+
+	affected, err := orm.DeleteByIDs(context.Background(), []int64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if affected != 3 {
+		t.Errorf("Expected 3 rows affected, got %d", affected)
+	}
+	if len(mockEngine.ExecCalls) != 1 {
+		t.Fatalf("Expected 1 Exec call, got %d", len(mockEngine.ExecCalls))
+	}
+	expectedSQL := "DELETE FROM `test_table` WHERE `test_table`.`id` IN (?, ?, ?)"
+	if got := mockEngine.ExecCalls[0].SQL; got != expectedSQL {
+		t.Errorf("Expected SQL %q, got %q", expectedSQL, got)
+	}
+}
+
+func TestDeleteByIDs_Chunked(t *testing.T) {
+	mockEngine := &MockAffectedEngine{}
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name")
+	orm := &ORM[TestModel, TestModelOptional]{
+		table:  testTable,
+		engine: mockEngine,
+	}
+
+	ids := make([]int64, 2*idsChunkSize+1)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	affected, err := orm.DeleteByIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if affected != int64(len(ids)) {
+		t.Errorf("Expected %d rows affected, got %d", len(ids), affected)
+	}
+	if len(mockEngine.ExecCalls) != 3 {
+		t.Fatalf("Expected 3 Exec calls, got %d", len(mockEngine.ExecCalls))
+	}
+	if n := strings.Count(mockEngine.ExecCalls[2].SQL, "?"); n != 1 {
+		t.Errorf("Expected last chunk with 1 id, got %d", n)
+	}
+}
+
+func TestDeleteByIDs_EngineWithoutAffected(t *testing.T) {
+	mockEngine := &MockEngine{}
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	orm := &ORM[TestModel, TestModelOptional]{
+		table:  testTable,
+		engine: mockEngine,
+	}
+
+	affected, err := orm.DeleteByIDs(context.Background(), []int64{1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if affected != -1 {
+		t.Errorf("Expected -1 rows affected, got %d", affected)
+	}
+	if len(mockEngine.ExecCalls) != 1 {
+		t.Errorf("Expected 1 Exec call, got %d", len(mockEngine.ExecCalls))
+	}
+}
+
+func TestDeleteByID_NoRowsAffected(t *testing.T) {
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name")
+	testTable.Int64("age")
+
+	mockEngine := &MockIgnoreEngine{affected: 0}
+	orm := &ORM[TestModel, TestModelOptional]{table: testTable, engine: mockEngine}
+	err := orm.DeleteByID(context.Background(), 7)
+	if !errors.Is(err, ErrNoRowsAffected) {
+		t.Fatalf("Expected ErrNoRowsAffected, got %v", err)
+	}
+	if expected := "no rows affected: test_table id=7"; err.Error() != expected {
+		t.Errorf("Expected error %q, got %q", expected, err.Error())
+	}
+
+	// deletes by condition may match nothing
+	name := "Alice"
+	if err := orm.DeleteBy(context.Background(), &TestModelOptional{Name: &name}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestTruncate tests that Truncate targets the physical table on the engine of the context
+func TestTruncate(t *testing.T) {
+	ctx := context.Background()
+	testTable := table.New("test_table")
+	id := testTable.Int64("id")
+	testTable.String("name")
+
+	bound := engine.Recorder(nil)
+	resolved := engine.Recorder(nil)
+	orm, err := bind[joinUser, joinUserOptional](bound, testTable, WithTablePrefix("app_"), WithEngineResolver(func(ctx context.Context) engine.Engine {
+		if ctx.Value(tenantKey{}) != nil {
+			return resolved.GetEngine()
+		}
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	if err := orm.Truncate(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := orm.WithTable("test_table_2025").Truncate(context.WithValue(ctx, tenantKey{}, int64(1))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sqltest.AssertCount(t, bound, 1)
+	sqltest.AssertExecuted(t, bound, "TRUNCATE TABLE `app_test_table`")
+	sqltest.AssertExecuted(t, resolved, "TRUNCATE TABLE `app_test_table_2025`")
+
+	if err := orm.WithTable("test_table`; DROP TABLE x").Truncate(ctx); err == nil {
+		t.Errorf("Expected an invalid table name error")
+	}
+
+	analytics := table.NewIn("analytics", "events")
+	analytics.Int64("id")
+	analytics.String("name")
+	events, err := bind[joinUser, joinUserOptional](bound, analytics)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	if err := events.Truncate(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sqltest.AssertExecuted(t, bound, "TRUNCATE TABLE `analytics`.`events`")
+
+	scoped, err := bind[joinUser, joinUserOptional](bound, testTable, WithDefaultScope(func(ctx context.Context) []field.Expr {
+		return []field.Expr{id.Eq(1)}
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	if err := scoped.Truncate(ctx); err == nil {
+		t.Errorf("Expected Truncate to be refused under a default scope")
+	}
+	sqltest.AssertCount(t, bound, 2)
+}
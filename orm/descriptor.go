@@ -0,0 +1,129 @@
+package orm
+
+import (
+	"reflect"
+
+	"github.com/xhd2015/arc-orm/field"
+)
+
+// modelDescriptor caches the reflection metadata of the model and
+// optional types, computed once at Bind so that hot paths like Insert,
+// UpdateByID and ToConditions don't rebuild it on every call
+type modelDescriptor struct {
+	// tableFields maps columns to table fields
+	tableFields map[string]field.Field
+	// model and optional are the fields of T and P, in declaration order
+	model    []modelField
+	optional []modelField
+	// createTimeColumn and updateTimeColumn are the columns of
+	// T.CreateTime and T.UpdateTime, empty if absent
+	createTimeColumn string
+	updateTimeColumn string
+	// countIndex is the index of T.Count, -1 if absent,
+	// countErr tells why Count queries are not supported
+	countIndex int
+	countErr   error
+	// pkColumn is the primary key column, 'id' by default
+	pkColumn string
+	// uuids are the model fields of auto-generated UUID columns
+	uuids []modelField
+}
+
+// modelField maps a struct field to its column
+type modelField struct {
+	index  int
+	field  reflect.StructField
+	column string
+	// tableField is nil if the table has no such column
+	tableField field.Field
+	// isCount marks the Count field receiving count queries, never a column
+	isCount bool
+}
+
+// describe returns the descriptor computed at Bind, or computes a
+// fresh one for ORM instances not created by Bind
+func (o *ORM[T, P]) describe() *modelDescriptor {
+	if o.descriptor != nil {
+		return o.descriptor
+	}
+	return o.newDescriptor()
+}
+
+// primaryKey returns the primary key given by WithPrimaryKey or
+// declared on the table, nil for the default 'id'
+func (o *ORM[T, P]) primaryKey() field.Field {
+	if o.opts.primaryKey != nil {
+		return o.opts.primaryKey
+	}
+	if pk, ok := o.table.Primary(); ok && len(pk.Fields) == 1 {
+		return pk.Fields[0]
+	}
+	return nil
+}
+
+func (o *ORM[T, P]) newDescriptor() *modelDescriptor {
+	d := &modelDescriptor{
+		tableFields: make(map[string]field.Field),
+		countIndex:  -1,
+		countErr:    ErrMissingCountField,
+		pkColumn:    "id",
+	}
+	if pk := o.primaryKey(); pk != nil {
+		d.pkColumn = pk.Name()
+	}
+	for _, f := range o.table.Fields() {
+		d.tableFields[f.Name()] = f
+	}
+
+	modelType := reflect.TypeOf((*T)(nil)).Elem()
+	if modelType.Kind() == reflect.Struct {
+		for i := 0; i < modelType.NumField(); i++ {
+			f := modelType.Field(i)
+			if Ignored(f) {
+				continue
+			}
+			column := o.opts.naming.ColumnName(f)
+			mf := modelField{
+				index:      i,
+				field:      f,
+				column:     column,
+				tableField: d.tableFields[column],
+				isCount:    f.Name == "Count",
+			}
+			d.model = append(d.model, mf)
+			if u, ok := mf.tableField.(field.UuidField); ok && u.AutoGenerated() {
+				d.uuids = append(d.uuids, mf)
+			}
+			switch f.Name {
+			case "CreateTime":
+				d.createTimeColumn = column
+			case "UpdateTime":
+				d.updateTimeColumn = column
+			case "Count":
+				d.countIndex = i
+				d.countErr = nil
+				if f.Type.Kind() != reflect.Int64 {
+					d.countErr = ErrWrongCountFieldType
+				}
+			}
+		}
+	}
+
+	optionalType := reflect.TypeOf((*P)(nil)).Elem()
+	if optionalType.Kind() == reflect.Struct {
+		for i := 0; i < optionalType.NumField(); i++ {
+			f := optionalType.Field(i)
+			if optionalIgnored[T](f) {
+				continue
+			}
+			column := o.optionalColumnName(f)
+			d.optional = append(d.optional, modelField{
+				index:      i,
+				field:      f,
+				column:     column,
+				tableField: d.tableFields[column],
+			})
+		}
+	}
+	return d
+}
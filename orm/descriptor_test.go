@@ -0,0 +1,74 @@
+package orm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+func TestDescriptor_ComputedAtBind(t *testing.T) {
+	type Legacy struct {
+		Id         int64
+		Name       string `orm:"column:legacy_name"`
+		CreateTime time.Time
+	}
+	type LegacyOptional struct {
+		Id   *int64
+		Name *string
+	}
+	testTable := table.New("legacy")
+	testTable.Int64("id")
+	testTable.String("legacy_name")
+	testTable.Time("create_time")
+
+	orm, err := bind[Legacy, LegacyOptional](&MockEngine{}, testTable)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	d := orm.descriptor
+	if d == nil {
+		t.Fatalf("Expected descriptor computed at bind")
+	}
+	if orm.describe() != d {
+		t.Errorf("Expected describe to return the cached descriptor")
+	}
+	if d.createTimeColumn != "create_time" {
+		t.Errorf("Expected create_time column, got %q", d.createTimeColumn)
+	}
+	if len(d.optional) != 2 || d.optional[1].column != "legacy_name" || d.optional[1].tableField == nil {
+		t.Errorf("Expected optional Name mapped to legacy_name, got %+v", d.optional)
+	}
+}
+
+func TestDescriptor_CountField(t *testing.T) {
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name")
+	testTable.Int64("age")
+
+	orm, err := bind[TestModel, TestModelOptional](&MockEngine{}, testTable)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	d := orm.descriptor
+	if d.countErr != nil || d.countIndex != 3 {
+		t.Errorf("Expected Count field at index 3, got %d %v", d.countIndex, d.countErr)
+	}
+	for _, mf := range d.model {
+		if mf.isCount != (mf.field.Name == "Count") {
+			t.Errorf("Expected only Count marked, got %s marked %v", mf.field.Name, mf.isCount)
+		}
+	}
+
+	type NoCount struct {
+		Id int64
+	}
+	type NoCountOptional struct {
+		Id *int64
+	}
+	noCount := &ORM[NoCount, NoCountOptional]{table: testTable}
+	if err := noCount.describe().countErr; err != ErrMissingCountField {
+		t.Errorf("Expected ErrMissingCountField, got %v", err)
+	}
+}
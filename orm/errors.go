@@ -0,0 +1,47 @@
+package orm
+
+import "fmt"
+
+// NotFoundError is returned by RequireOne when no record matches
+// the query, errors.Is(err, ErrNotFound) reports true for it
+type NotFoundError struct {
+	// Table is the name of the queried table
+	Table string
+	// Conditions are the WHERE conditions of the query with
+	// ? placeholders, so values are kept out of logs
+	Conditions string
+
+	sql  string
+	args []interface{}
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Conditions == "" {
+		return fmt.Sprintf("%v: %s", ErrNotFound, e.Table)
+	}
+	return fmt.Sprintf("%v: %s where %s", ErrNotFound, e.Table, e.Conditions)
+}
+
+// Unwrap returns ErrNotFound
+func (e *NotFoundError) Unwrap() error {
+	return ErrNotFound
+}
+
+// Debug returns the executed SQL with its args for triage,
+// the args may contain sensitive values
+func (e *NotFoundError) Debug() string {
+	return fmt.Sprintf("%s %v", e.sql, e.args)
+}
+
+// keyError wraps err with the table name and the
+// primary keys of the records, if known
+func (o *ORM[T, P]) keyError(err error, keys []interface{}) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: %s", err, o.physicalTable())
+	}
+	column := o.describe().pkColumn
+	if len(keys) == 1 {
+		return fmt.Errorf("%w: %s %s=%v", err, o.physicalTable(), column, keys[0])
+	}
+	return fmt.Errorf("%w: %s %s in %v", err, o.physicalTable(), column, keys)
+}
@@ -0,0 +1,47 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+func TestRequireOne_NotFound(t *testing.T) {
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	name := testTable.String("name")
+	age := testTable.Int64("age")
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			return nil
+		},
+	}
+	orm := &ORM[TestModel, TestModelOptional]{table: testTable, engine: mockEngine}
+
+	_, err := orm.SelectAll().Where(name.Eq("Alice"), age.Gt(18)).RequireOne(context.Background())
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Expected ErrNotFound, got %v", err)
+	}
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("Expected *NotFoundError, got %T", err)
+	}
+	if notFound.Table != "test_table" {
+		t.Errorf("Expected table test_table, got %s", notFound.Table)
+	}
+	expected := "data not found: test_table where `test_table`.`name` = ? AND `test_table`.`age` > ?"
+	if err.Error() != expected {
+		t.Errorf("Expected error:\n%s\ngot:\n%s", expected, err.Error())
+	}
+	expected = "SELECT `test_table`.`id`, `test_table`.`name`, `test_table`.`age` FROM `test_table` WHERE `test_table`.`name` = ? AND `test_table`.`age` > ? LIMIT 1 [Alice 18]"
+	if got := notFound.Debug(); got != expected {
+		t.Errorf("Expected debug:\n%s\ngot:\n%s", expected, got)
+	}
+
+	_, err = orm.SelectAll().RequireOne(context.Background())
+	if expected := "data not found: test_table"; err == nil || err.Error() != expected {
+		t.Errorf("Expected error %q, got %v", expected, err)
+	}
+}
@@ -0,0 +1,93 @@
+package orm
+
+import (
+	"reflect"
+	"sync"
+)
+
+// WriteOp is the kind of write reported by a WriteEvent
+type WriteOp string
+
+const (
+	WriteInsert WriteOp = "insert"
+	// WriteUpsert is reported by InsertOrUpdate, which may insert or update
+	WriteUpsert WriteOp = "upsert"
+	WriteUpdate WriteOp = "update"
+	WriteDelete WriteOp = "delete"
+)
+
+// WriteEvent describes a successful write through the ORM
+type WriteEvent struct {
+	Table string
+	Op    WriteOp
+	// Keys are the primary keys of the written rows, empty
+	// when they are not known, e.g. for UpdateBy and DeleteWhere
+	Keys []interface{}
+}
+
+// writeListeners holds the listeners registered on an ORM, shared by its copies
+type writeListeners struct {
+	mu        sync.RWMutex
+	listeners []func(ev WriteEvent)
+}
+
+// OnWrite registers a listener called after each successful
+// Insert, Update and Delete through the ORM, e.g. to invalidate
+// caches or search indexes centrally. Listeners are called
+// synchronously in the order of registration.
+// Writes by raw SQL are not reported.
+func (o *ORM[T, P]) OnWrite(listener func(ev WriteEvent)) *ORM[T, P] {
+	if o.listeners == nil {
+		o.listeners = &writeListeners{}
+	}
+	o.listeners.mu.Lock()
+	defer o.listeners.mu.Unlock()
+	o.listeners.listeners = append(o.listeners.listeners, listener)
+	return o
+}
+
+// emitWrite reports the write to the listeners
+func (o *ORM[T, P]) emitWrite(op WriteOp, keys []interface{}) {
+	if o.listeners == nil {
+		return
+	}
+	o.listeners.mu.RLock()
+	listeners := o.listeners.listeners
+	o.listeners.mu.RUnlock()
+	if len(listeners) == 0 {
+		return
+	}
+	ev := WriteEvent{Table: o.physicalTable(), Op: op, Keys: keys}
+	for _, listener := range listeners {
+		listener(ev)
+	}
+}
+
+// modelKeys returns the non-zero primary key of the model,
+// or id if the model has none
+func (o *ORM[T, P]) modelKeys(model *T, id int64) []interface{} {
+	d := o.describe()
+	for _, mf := range d.model {
+		if mf.column != d.pkColumn || !mf.field.IsExported() {
+			continue
+		}
+		fv := reflect.ValueOf(model).Elem().Field(mf.index)
+		if !fv.IsZero() {
+			return []interface{}{fv.Interface()}
+		}
+		break
+	}
+	if id != 0 {
+		return []interface{}{id}
+	}
+	return nil
+}
+
+// int64Keys converts ids to event keys
+func int64Keys(ids []int64) []interface{} {
+	keys := make([]interface{}, len(ids))
+	for i, id := range ids {
+		keys[i] = id
+	}
+	return keys
+}
@@ -0,0 +1,49 @@
+package orm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// TestOnWrite tests that successful writes are reported with their primary keys
+func TestOnWrite(t *testing.T) {
+	mockEngine := &MockEngine{}
+	orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](mockEngine, newTimeTestTable())
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	var events []WriteEvent
+	orm.OnWrite(func(ev WriteEvent) {
+		events = append(events, ev)
+	})
+
+	ctx := context.Background()
+	if _, err := orm.Insert(ctx, &TestModelWithTime{Name: "Alice"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	name := "Bob"
+	if err := orm.UpdateByID(ctx, 7, &TestModelWithTimeOptional{Name: &name}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := orm.UpdateBy(ctx, &TestModelWithTimeOptional{Name: &name}, &TestModelWithTimeOptional{Name: &name}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := orm.DeleteByIDs(ctx, []int64{1, 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// failed writes are not reported
+	if err := orm.UpdateByID(ctx, 7, &TestModelWithTimeOptional{}); err == nil {
+		t.Fatalf("expected error for nothing to update")
+	}
+
+	expected := []WriteEvent{
+		{Table: "test_table", Op: WriteInsert, Keys: []interface{}{int64(42)}},
+		{Table: "test_table", Op: WriteUpdate, Keys: []interface{}{int64(7)}},
+		{Table: "test_table", Op: WriteUpdate},
+		{Table: "test_table", Op: WriteDelete, Keys: []interface{}{int64(1), int64(2)}},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected events %v, got %v", expected, events)
+	}
+}
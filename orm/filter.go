@@ -0,0 +1,173 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/field"
+)
+
+// Op is a condition on a single column, used as the field type of
+// filter structs whose field names match the model, e.g.
+//
+//	type UserFilter struct {
+//	    Age  orm.Op
+//	    Name orm.Op
+//	}
+//	users, err := user.ORM.QueryByFilter(ctx, &UserFilter{
+//	    Age:  orm.Between(18, 30),
+//	    Name: orm.In("Alice", "Bob"),
+//	})
+//
+// The zero Op matches everything.
+type Op struct {
+	op     string
+	values []interface{}
+}
+
+// Eq matches column = value
+func Eq(value interface{}) Op { return Op{op: "=", values: []interface{}{value}} }
+
+// Neq matches column != value
+func Neq(value interface{}) Op { return Op{op: "!=", values: []interface{}{value}} }
+
+// Gt matches column > value
+func Gt(value interface{}) Op { return Op{op: ">", values: []interface{}{value}} }
+
+// Gte matches column >= value
+func Gte(value interface{}) Op { return Op{op: ">=", values: []interface{}{value}} }
+
+// Lt matches column < value
+func Lt(value interface{}) Op { return Op{op: "<", values: []interface{}{value}} }
+
+// Lte matches column <= value
+func Lte(value interface{}) Op { return Op{op: "<=", values: []interface{}{value}} }
+
+// Like matches column LIKE pattern
+func Like(pattern string) Op { return Op{op: "LIKE", values: []interface{}{pattern}} }
+
+// Between matches column BETWEEN start AND end
+func Between(start interface{}, end interface{}) Op {
+	return Op{op: "BETWEEN", values: []interface{}{start, end}}
+}
+
+// In matches column IN (values...), requires at least one value
+func In(values ...interface{}) Op { return Op{op: "IN", values: values} }
+
+// IsZero reports whether the op is unset
+func (c Op) IsZero() bool {
+	return c.op == ""
+}
+
+// opCondition applies an op to a table field
+type opCondition struct {
+	field  field.Field
+	op     string
+	values []interface{}
+}
+
+func (c *opCondition) ToSQL() (string, []interface{}, error) {
+	sql, params, err := c.field.ToSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	switch c.op {
+	case "IN":
+		if len(c.values) == 0 {
+			return "", nil, fmt.Errorf("%s: IN requires at least one value", c.field.Name())
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(c.values)), ", ")
+		return sql + " IN (" + placeholders + ")", append(params, c.values...), nil
+	case "BETWEEN":
+		return sql + " BETWEEN ? AND ?", append(params, c.values...), nil
+	}
+	return sql + " " + c.op + " ?", append(params, c.values...), nil
+}
+
+// FilterConditions converts the set Op fields of a filter struct pointer
+// to table-qualified conditions. Fields are matched to columns
+// by the name of the model field, or their own `orm:"column:..."` tag.
+// Fields tagged `orm:"-"` are skipped.
+func (o *ORM[T, P]) FilterConditions(filter interface{}) ([]field.Expr, error) {
+	rv := reflect.ValueOf(filter)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("filter must be a non-nil struct pointer, got %T", filter)
+	}
+	rv = rv.Elem()
+	t := rv.Type()
+	opType := reflect.TypeOf(Op{})
+
+	var conditions []field.Expr
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || Ignored(f) {
+			continue
+		}
+		fv := rv.Field(i)
+		if f.Type == reflect.PtrTo(opType) {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		} else if f.Type != opType {
+			return nil, fmt.Errorf("filter field %s must be orm.Op, got %s", f.Name, f.Type)
+		}
+		op := fv.Interface().(Op)
+		if op.IsZero() {
+			continue
+		}
+		tableField, err := o.tableField(o.optionalColumnName(f))
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, &opCondition{
+			field:  tableField,
+			op:     op.op,
+			values: op.values,
+		})
+	}
+	return conditions, nil
+}
+
+// QueryByFilter returns the records matching the filter, see Op
+func (o *ORM[T, P]) QueryByFilter(ctx context.Context, filter interface{}) ([]*T, error) {
+	conditions, err := o.FilterConditions(filter)
+	if err != nil {
+		return nil, err
+	}
+	return o.SelectAll().Where(conditions...).Query(ctx)
+}
+
+// GetByFilter returns the first record matching the filter, see Op
+// the record must exist, otherwise it will return an error
+func (o *ORM[T, P]) GetByFilter(ctx context.Context, filter interface{}) (*T, error) {
+	conditions, err := o.FilterConditions(filter)
+	if err != nil {
+		return nil, err
+	}
+	return o.get(ctx, conditions, nil)
+}
+
+// UpdateByFilter updates the records matching the filter with the non-nil fields of data
+func (o *ORM[T, P]) UpdateByFilter(ctx context.Context, filter interface{}, data *P) error {
+	conditions, err := o.FilterConditions(filter)
+	if err != nil {
+		return err
+	}
+	if len(conditions) == 0 {
+		return errors.New("requires conditions")
+	}
+	return o.update(ctx, conditions, nil, data)
+}
+
+// DeleteByFilter deletes the records matching the filter
+func (o *ORM[T, P]) DeleteByFilter(ctx context.Context, filter interface{}) error {
+	conditions, err := o.FilterConditions(filter)
+	if err != nil {
+		return err
+	}
+	return o.deleteBy(ctx, conditions, nil)
+}
@@ -0,0 +1,139 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+type testModelFilter struct {
+	Id   *Op
+	Name Op
+	Age  Op
+}
+
+func newFilterTestORM(mockEngine *MockQueryEngine) *ORM[TestModel, TestModelOptional] {
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name")
+	testTable.Int64("age")
+	return &ORM[TestModel, TestModelOptional]{
+		table:  testTable,
+		engine: mockEngine,
+	}
+}
+
+func TestToConditions_Qualified(t *testing.T) {
+	orm := newFilterTestORM(&MockQueryEngine{})
+
+	name := "Alice"
+	conditions, err := orm.ToConditions(&TestModelOptional{Name: &name})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conditions) != 1 {
+		t.Fatalf("Expected 1 condition, got %d", len(conditions))
+	}
+	sqlStr, args, err := conditions[0].ToSQL()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sqlStr != "`test_table`.`name` = ?" || len(args) != 1 || args[0] != "Alice" {
+		t.Errorf("Unexpected condition: %s %v", sqlStr, args)
+	}
+}
+
+func TestFilterConditions(t *testing.T) {
+	orm := newFilterTestORM(&MockQueryEngine{})
+
+	tests := []struct {
+		name         string
+		filter       *testModelFilter
+		expectedSQL  []string
+		expectedArgs []interface{}
+	}{
+		{
+			name:   "zero ops skipped",
+			filter: &testModelFilter{},
+		},
+		{
+			name:         "range and in",
+			filter:       &testModelFilter{Age: Between(18, 30), Name: In("Alice", "Bob")},
+			expectedSQL:  []string{"`test_table`.`name` IN (?, ?)", "`test_table`.`age` BETWEEN ? AND ?"},
+			expectedArgs: []interface{}{"Alice", "Bob", 18, 30},
+		},
+		{
+			name:         "pointer op",
+			filter:       &testModelFilter{Id: &Op{op: ">", values: []interface{}{int64(5)}}, Age: Lte(60)},
+			expectedSQL:  []string{"`test_table`.`id` > ?", "`test_table`.`age` <= ?"},
+			expectedArgs: []interface{}{int64(5), 60},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conditions, err := orm.FilterConditions(tt.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(conditions) != len(tt.expectedSQL) {
+				t.Fatalf("Expected %d conditions, got %d", len(tt.expectedSQL), len(conditions))
+			}
+			var args []interface{}
+			for i, cond := range conditions {
+				sqlStr, condArgs, err := cond.ToSQL()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if sqlStr != tt.expectedSQL[i] {
+					t.Errorf("Expected %s, got %s", tt.expectedSQL[i], sqlStr)
+				}
+				args = append(args, condArgs...)
+			}
+			for i, arg := range tt.expectedArgs {
+				if args[i] != arg {
+					t.Errorf("Expected arg[%d] %v, got %v", i, arg, args[i])
+				}
+			}
+		})
+	}
+}
+
+func TestFilterConditions_Invalid(t *testing.T) {
+	orm := newFilterTestORM(&MockQueryEngine{})
+
+	_, err := orm.FilterConditions(&struct{ Name string }{})
+	if err == nil {
+		t.Errorf("Expected error for non-Op field")
+	}
+	_, err = orm.FilterConditions(&struct{ Unknown Op }{Unknown: Eq(1)})
+	if err == nil {
+		t.Errorf("Expected error for unknown column")
+	}
+	conditions, err := orm.FilterConditions(&testModelFilter{Name: In()})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := conditions[0].ToSQL(); err == nil {
+		t.Errorf("Expected error for empty IN")
+	}
+}
+
+func TestQueryByFilter(t *testing.T) {
+	var gotSQL string
+	orm := newFilterTestORM(&MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			gotSQL = sql
+			return nil
+		},
+	})
+
+	_, err := orm.QueryByFilter(context.Background(), &testModelFilter{Age: Gt(18)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedSQL := "SELECT `test_table`.`id`, `test_table`.`name`, `test_table`.`age` FROM `test_table` WHERE `test_table`.`age` > ?"
+	if gotSQL != expectedSQL {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expectedSQL, gotSQL)
+	}
+}
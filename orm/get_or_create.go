@@ -0,0 +1,61 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// GetOrCreate returns the first record matching the condition,
+// inserting create if there is none. The returned bool reports
+// whether the record was created.
+// If the insert fails because a concurrent caller created the
+// record first, e.g. on a unique key, the record is queried again,
+// so the condition should cover the unique key for this to be race-free.
+func (o *ORM[T, P]) GetOrCreate(ctx context.Context, condition *P, create *T) (*T, bool, error) {
+	if condition == nil {
+		return nil, false, fmt.Errorf("requires condition")
+	}
+	if create == nil {
+		return nil, false, errors.New("model cannot be nil")
+	}
+
+	sqlConditions, err := o.ToConditions(condition)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to convert condition to SQL conditions: %w", err)
+	}
+	if len(sqlConditions) == 0 {
+		return nil, false, fmt.Errorf("requires non-empty condition")
+	}
+
+	existing, err := o.first(ctx, sqlConditions)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		return existing, false, nil
+	}
+
+	id, insertErr := o.Insert(ctx, create)
+	if insertErr != nil {
+		// a concurrent caller may have created it in between
+		existing, err := o.first(ctx, sqlConditions)
+		if err == nil && existing != nil {
+			return existing, false, nil
+		}
+		return nil, false, insertErr
+	}
+
+	// re-read by the primary key of any type, see WithPrimaryKey,
+	// or by the condition if the key is unknown
+	var created *T
+	if keys := o.modelKeys(create, id); len(keys) > 0 {
+		created, err = o.GetByKey(ctx, keys[0])
+	} else if created, err = o.first(ctx, sqlConditions); err == nil && created == nil {
+		err = ErrNotFound
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get created record: %w", err)
+	}
+	return created, true, nil
+}
@@ -0,0 +1,164 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// failInsertEngine fails every insert as if a unique key was violated
+type failInsertEngine struct {
+	MockQueryEngine
+}
+
+func (m *failInsertEngine) ExecInsert(ctx context.Context, sql string, args []interface{}) (int64, error) {
+	m.ExecInsertCalls = append(m.ExecInsertCalls, ExecInsertCall{SQL: sql, Args: args})
+	return 0, errors.New("Duplicate entry 'Alice' for key 'name'")
+}
+
+func (m *failInsertEngine) GetEngine() engine.Engine {
+	return m
+}
+
+func newGetOrCreateTestORM(factory engine.Factory) *ORM[TestModel, TestModelOptional] {
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name")
+	testTable.Int64("age")
+	return &ORM[TestModel, TestModelOptional]{
+		table:  testTable,
+		engine: factory,
+	}
+}
+
+func TestGetOrCreate_Existing(t *testing.T) {
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			*result.(*[]*TestModel) = []*TestModel{{Id: 7, Name: "Alice"}}
+			return nil
+		},
+	}
+	orm := newGetOrCreateTestORM(mockEngine)
+
+	name := "Alice"
+	got, created, err := orm.GetOrCreate(context.Background(), &TestModelOptional{Name: &name}, &TestModel{Name: name})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created {
+		t.Errorf("Expected existing record, got created")
+	}
+	if got.Id != 7 {
+		t.Errorf("Expected id 7, got %d", got.Id)
+	}
+	if len(mockEngine.ExecInsertCalls) != 0 {
+		t.Errorf("Expected no insert, got %d", len(mockEngine.ExecInsertCalls))
+	}
+}
+
+func TestGetOrCreate_Created(t *testing.T) {
+	var queries []string
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			queries = append(queries, sql)
+			if strings.Contains(sql, "`id` = ?") {
+				*result.(*[]*TestModel) = []*TestModel{{Id: args[0].(int64), Name: "Alice"}}
+			}
+			return nil
+		},
+	}
+	orm := newGetOrCreateTestORM(mockEngine)
+
+	name := "Alice"
+	got, created, err := orm.GetOrCreate(context.Background(), &TestModelOptional{Name: &name}, &TestModel{Name: name})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !created {
+		t.Errorf("Expected created record")
+	}
+	if got.Id != 42 {
+		t.Errorf("Expected id 42, got %d", got.Id)
+	}
+	if len(mockEngine.ExecInsertCalls) != 1 {
+		t.Errorf("Expected 1 insert, got %d", len(mockEngine.ExecInsertCalls))
+	}
+	if len(queries) != 2 {
+		t.Errorf("Expected 2 queries, got %v", queries)
+	}
+}
+
+// TestGetOrCreate_PrimaryKey tests re-reading the created record by a string primary key
+func TestGetOrCreate_PrimaryKey(t *testing.T) {
+	users := table.New("users")
+	userUUID := users.String("user_uuid")
+	users.String("name")
+
+	var queries []string
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			queries = append(queries, sql)
+			if strings.Contains(sql, "`user_uuid` = ?") {
+				*result.(*[]*uuidUser) = []*uuidUser{{UserUuid: args[0].(string), Name: "Alice"}}
+			}
+			return nil
+		},
+	}
+	orm, err := bind[uuidUser, uuidUserOptional](mockEngine, users, WithPrimaryKey(userUUID))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	name := "Alice"
+	got, created, err := orm.GetOrCreate(context.Background(), &uuidUserOptional{Name: &name}, &uuidUser{UserUuid: "u-1", Name: name})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !created || got.UserUuid != "u-1" {
+		t.Errorf("Expected created u-1, got %+v (created %v)", got, created)
+	}
+	expected := "SELECT `users`.`user_uuid`, `users`.`name` FROM `users` WHERE `users`.`user_uuid` = ? LIMIT 1"
+	if len(queries) != 2 || queries[1] != expected {
+		t.Errorf("Expected the created record queried by key, got %q", queries)
+	}
+}
+
+func TestGetOrCreate_ConcurrentlyCreated(t *testing.T) {
+	var queryCount int
+	mockEngine := &failInsertEngine{}
+	mockEngine.QueryFunc = func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+		queryCount++
+		// created by another caller after the first query
+		if queryCount > 1 {
+			*result.(*[]*TestModel) = []*TestModel{{Id: 9, Name: "Alice"}}
+		}
+		return nil
+	}
+	orm := newGetOrCreateTestORM(mockEngine)
+
+	name := "Alice"
+	got, created, err := orm.GetOrCreate(context.Background(), &TestModelOptional{Name: &name}, &TestModel{Name: name})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created {
+		t.Errorf("Expected existing record, got created")
+	}
+	if got.Id != 9 {
+		t.Errorf("Expected id 9, got %d", got.Id)
+	}
+}
+
+func TestGetOrCreate_InsertError(t *testing.T) {
+	orm := newGetOrCreateTestORM(&failInsertEngine{})
+
+	name := "Alice"
+	_, _, err := orm.GetOrCreate(context.Background(), &TestModelOptional{Name: &name}, &TestModel{Name: name})
+	if err == nil || !strings.Contains(err.Error(), "Duplicate entry") {
+		t.Errorf("Expected insert error, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package orm
+
+// Validator is implemented by models checking their domain
+// invariants, called before the model is inserted or updated
+type Validator interface {
+	Validate() error
+}
+
+// InsertValidator is implemented by models checking their
+// invariants before inserts, taking precedence over Validator
+type InsertValidator interface {
+	ValidateForInsert() error
+}
+
+// UpdateValidator is implemented by models and optional models checking
+// their invariants before updates, taking precedence over Validator
+type UpdateValidator interface {
+	ValidateForUpdate() error
+}
+
+// validateForInsert calls the insert validation hook of the model if any
+func validateForInsert(model interface{}) error {
+	if v, ok := model.(InsertValidator); ok {
+		return v.ValidateForInsert()
+	}
+	if v, ok := model.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// validateForUpdate calls the update validation hook of the model if any
+func validateForUpdate(model interface{}) error {
+	if v, ok := model.(UpdateValidator); ok {
+		return v.ValidateForUpdate()
+	}
+	if v, ok := model.(Validator); ok {
+		return v.Validate()
+	}
+	return nil
+}
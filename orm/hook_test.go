@@ -0,0 +1,66 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+var errEmptyName = errors.New("name is required")
+
+type hookedModel struct {
+	Id   int64
+	Name string
+}
+
+func (m *hookedModel) Validate() error {
+	if m.Name == "" {
+		return errEmptyName
+	}
+	return nil
+}
+
+type hookedModelOptional struct {
+	Id   *int64
+	Name *string
+}
+
+func (m *hookedModelOptional) ValidateForUpdate() error {
+	if m.Name != nil && *m.Name == "" {
+		return errEmptyName
+	}
+	return nil
+}
+
+func TestValidationHooks(t *testing.T) {
+	testTable := table.New("users")
+	testTable.Int64("id")
+	testTable.String("name")
+
+	mockEngine := &MockEngine{}
+	orm := &ORM[hookedModel, hookedModelOptional]{
+		table:  testTable,
+		engine: mockEngine,
+	}
+	ctx := context.Background()
+
+	if _, err := orm.Insert(ctx, &hookedModel{}); !errors.Is(err, errEmptyName) {
+		t.Errorf("Expected Insert to call Validate, got %v", err)
+	}
+	if err := orm.UpdateModelByID(ctx, 1, &hookedModel{}); !errors.Is(err, errEmptyName) {
+		t.Errorf("Expected UpdateModelByID to call Validate, got %v", err)
+	}
+	empty := ""
+	if err := orm.UpdateByID(ctx, 1, &hookedModelOptional{Name: &empty}); !errors.Is(err, errEmptyName) {
+		t.Errorf("Expected UpdateByID to call ValidateForUpdate, got %v", err)
+	}
+	if len(mockEngine.ExecCalls) != 0 || len(mockEngine.ExecInsertCalls) != 0 {
+		t.Fatalf("Expected no statement executed")
+	}
+
+	if _, err := orm.Insert(ctx, &hookedModel{Name: "Alice"}); err != nil {
+		t.Errorf("Expected valid insert, got %v", err)
+	}
+}
@@ -7,36 +7,118 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/xhd2015/arc-orm/engine"
 	"github.com/xhd2015/arc-orm/field"
 	"github.com/xhd2015/arc-orm/sql"
 	"github.com/xhd2015/arc-orm/sql/expr"
-	"github.com/xhd2015/less-gen/strcase"
 )
 
-// Insert adds a new record to the database and returns the generated ID
-func (o *ORM[T, P]) Insert(ctx context.Context, model *T) (int64, error) {
+// Insert adds a new record to the database and returns the generated ID,
+// which is also set on a zero integer primary key of the model
+func (o *ORM[T, P]) Insert(ctx context.Context, model *T) (_ int64, err error) {
 	// Use reflection to extract field values from the model
 	if model == nil {
 		return 0, errors.New("model cannot be nil")
 	}
 
+	keys := o.generateKeys(model)
+	defer func() {
+		if err != nil {
+			o.resetKeys(model, keys)
+		}
+	}()
+	builder, err := o.insertBuilder(model)
+	if err != nil {
+		return 0, err
+	}
+
+	// Generate the SQL and args
+	query, args, err := builder.SQL()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build insert SQL: %w", err)
+	}
+
+	if keys.hasID {
+		err = o.getEngine(ctx).Exec(ctx, query, args)
+		if err != nil {
+			return 0, fmt.Errorf("failed to execute Insert: %w", err)
+		}
+		o.emitWrite(WriteInsert, o.modelKeys(model, keys.id))
+		return keys.id, nil
+	}
+
+	// Execute the insert and get the ID
+	id, err := o.getEngine(ctx).ExecInsert(ctx, query, args)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute Insert: %w", err)
+	}
+	o.fillID(model, id)
+	o.emitWrite(WriteInsert, o.modelKeys(model, id))
+
+	return id, nil
+}
+
+// insertBuilder creates the INSERT builder setting the columns of the model,
+// after the validation hooks of the model
+func (o *ORM[T, P]) insertBuilder(model *T) (*sql.InsertIntoBuilder, error) {
+	row, err := o.insertRow(model)
+	if err != nil {
+		return nil, err
+	}
+	builder := o.newInsert()
+	for i, f := range row.fields {
+		builder.Set(f, row.values[i])
+	}
+	return builder, nil
+}
+
+// insertRow is the columns of a model to insert with their values
+type insertRow struct {
+	fields []field.Field
+	values []expr.Expr
+}
+
+func (r *insertRow) set(f field.Field, value expr.Expr) {
+	r.fields = append(r.fields, f)
+	r.values = append(r.values, value)
+}
+
+// sameColumns reports whether the rows insert the same columns
+func (r *insertRow) sameColumns(other *insertRow) bool {
+	if len(r.fields) != len(other.fields) {
+		return false
+	}
+	for i, f := range r.fields {
+		if f.Name() != other.fields[i].Name() {
+			return false
+		}
+	}
+	return true
+}
+
+// insertRow returns the columns of the model to insert,
+// after the validation hooks of the model
+func (o *ORM[T, P]) insertRow(model *T) (*insertRow, error) {
+	if err := validateForInsert(model); err != nil {
+		return nil, err
+	}
+	if mapper, ok := interface{}(model).(ColumnMapper); ok {
+		return o.mappedInsertRow(mapper)
+	}
+
 	// Get the reflect.Value of the model struct (dereference the pointer)
 	v := reflect.ValueOf(model).Elem()
-	t := v.Type()
 
-	// Create the SQL Insert builder
-	builder := sql.InsertInto(o.table.Name())
+	row := &insertRow{}
 
-	// Map struct fields to table fields
-	tableFields := make(map[string]field.Field)
-	for _, f := range o.table.Fields() {
-		tableFields[f.Name()] = f
-	}
+	// Use a single timestamp for all auto-filled time fields
+	now := o.now()
+	var checker constraintChecker
 
 	// Iterate through the struct fields and add them to the builder
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		fieldType := t.Field(i)
+	for _, mf := range o.describe().model {
+		field := v.Field(mf.index)
+		fieldType := mf.field
 
 		// Skip unexported fields
 		if !fieldType.IsExported() {
@@ -44,91 +126,309 @@ func (o *ORM[T, P]) Insert(ctx context.Context, model *T) (int64, error) {
 		}
 
 		// Skip Count field (if present)
-		if fieldType.Name == "Count" {
+		if mf.isCount {
 			continue
 		}
 
-		// Convert field name to snake_case
-		fieldName := strcase.CamelToSnake(fieldType.Name)
 		// Get the corresponding table field
-		tableField, exists := tableFields[fieldName]
-		if !exists {
-			return 0, fmt.Errorf("field %s not found in table %s", fieldName, o.table.Name())
+		fieldName := mf.column
+		tableField := mf.tableField
+		if tableField == nil {
+			return nil, fmt.Errorf("field %s not found in table %s", fieldName, o.table.Name())
+		}
+
+		if def, ok := o.insertDefault(fieldName, tableField, field); ok {
+			field = def
 		}
 
 		// Handle pointer types - skip nil pointers (let DB use NULL default)
 		if field.Kind() == reflect.Ptr {
 			if field.IsNil() {
+				checker.check(tableField, nil)
 				continue
 			}
 			// Dereference the pointer for conversion
 			field = field.Elem()
 		}
 
-		// Convert Go value to SQL value based on type
-		var sqlValue expr.Expr
-		var isZero bool
-		switch field.Kind() {
-		case reflect.String:
-			sqlValue = sql.String(field.String())
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			val := field.Int()
-			isZero = val == 0
-			sqlValue = sql.Int64(val)
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			val := field.Uint()
-			isZero = val == 0
-			sqlValue = sql.Int64(val)
-		case reflect.Float64, reflect.Float32:
-			val := field.Float()
-			isZero = val == 0
-			sqlValue = sql.Float64(val)
-		case reflect.Bool:
-			sqlValue = sql.Bool(field.Bool())
-		case reflect.Struct:
-			// Handle time.Time specially
-			if field.Type().String() == "time.Time" {
-				timeValue := field.Interface().(time.Time)
-
-				// Auto-fill CreateTime and UpdateTime with current time if they're zero
-				if (fieldType.Name == "CreateTime" || fieldType.Name == "UpdateTime") && timeValue.IsZero() {
-					timeValue = time.Now()
-				}
-
-				// Skip zero time values to let DB use default/NULL
-				if timeValue.IsZero() {
-					continue
-				}
-
-				sqlValue = sql.Time(timeValue)
+		// Handle time.Time specially
+		if timeValue, ok := field.Interface().(time.Time); ok {
+			// Auto-fill CreateTime and UpdateTime with current time if they're zero
+			if (fieldType.Name == "CreateTime" || fieldType.Name == "UpdateTime") && timeValue.IsZero() {
+				timeValue = now
 			}
+
+			// Skip zero time values to let DB use default/NULL
+			if timeValue.IsZero() {
+				continue
+			}
+			field = reflect.ValueOf(timeValue)
 		}
 
-		// skip id when it is zero
-		if fieldName == "id" && isZero {
+		// Convert Go value to SQL value based on type
+		sqlValue, isZero := toSQLValue(field)
+
+		// skip the primary key when it is zero
+		if fieldName == o.describe().pkColumn && isZero {
 			continue
 		}
 
 		// Skip if we couldn't convert the value
 		if sqlValue == nil {
-			return 0, fmt.Errorf("failed to convert field %s to SQL value: %s", fieldType.Name, field.Type())
+			return nil, fmt.Errorf("failed to convert field %s to SQL value: %s", fieldType.Name, field.Type())
 		}
 
-		// Add to the builder
-		builder.Set(tableField, sqlValue)
+		// Add to the row
+		checker.check(tableField, field.Interface())
+		row.set(tableField, sqlValue)
+	}
+
+	if err := checker.err(o.table.Name()); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// fillID sets a zero integer primary key of the model to id
+func (o *ORM[T, P]) fillID(model *T, id int64) {
+	if fv, ok := o.modelIDValue(model); ok && fv.Int() == 0 {
+		fv.SetInt(id)
+	}
+}
+
+// modelIDValue returns the integer primary key field of the model
+func (o *ORM[T, P]) modelIDValue(model *T) (reflect.Value, bool) {
+	d := o.describe()
+	for _, mf := range d.model {
+		if mf.column != d.pkColumn || !mf.field.IsExported() {
+			continue
+		}
+		fv := reflect.ValueOf(model).Elem().Field(mf.index)
+		switch fv.Kind() {
+		case reflect.Int, reflect.Int64:
+			return fv, true
+		}
+		return reflect.Value{}, false
+	}
+	return reflect.Value{}, false
+}
+
+// generatedKeys are the keys generated for a model by generateKeys
+type generatedKeys struct {
+	// id is the id generated by WithIDGenerator, if hasID
+	id    int64
+	hasID bool
+	uuids []generatedUUID
+}
+
+// generateKeys sets a zero integer primary key of the model from the
+// WithIDGenerator generator and the empty auto-generated UUIDs.
+// The keys are set before the INSERT is built from the model, so
+// callers restore them by resetKeys if the model is not inserted.
+func (o *ORM[T, P]) generateKeys(model *T) generatedKeys {
+	keys := generatedKeys{uuids: o.generateUUIDs(model)}
+	if o.opts.idGenerator == nil {
+		return keys
+	}
+	fv, ok := o.modelIDValue(model)
+	if !ok || fv.Int() != 0 {
+		return keys
+	}
+	keys.id = o.opts.idGenerator()
+	keys.hasID = true
+	fv.SetInt(keys.id)
+	return keys
+}
+
+// resetKeys restores the keys of a model not inserted after
+// generateKeys, so inserting it again generates new ones
+func (o *ORM[T, P]) resetKeys(model *T, keys generatedKeys) {
+	if keys.hasID {
+		if fv, ok := o.modelIDValue(model); ok {
+			fv.SetInt(0)
+		}
+	}
+	o.resetUUIDs(model, keys.uuids)
+}
+
+// insertDefault returns the declared default of the column if
+// WithInsertDefaults is enabled and the value is zero.
+// Auto-filled columns are left alone.
+func (o *ORM[T, P]) insertDefault(column string, tableField field.Field, value reflect.Value) (reflect.Value, bool) {
+	if !o.opts.fillDefaults {
+		return value, false
+	}
+	d := o.describe()
+	if column == d.pkColumn || column == d.createTimeColumn || column == d.updateTimeColumn {
+		return value, false
+	}
+	if value.IsValid() && !value.IsZero() {
+		return value, false
+	}
+	defaulted, ok := tableField.(field.Defaulted)
+	if !ok {
+		return value, false
+	}
+	def, ok := defaulted.DefaultValue()
+	if !ok {
+		return value, false
+	}
+	return reflect.ValueOf(def), true
+}
+
+// toSQLValue converts a non-pointer model field value to a SQL value,
+// reporting whether it is a zero number. The value is nil if unsupported.
+func toSQLValue(field reflect.Value) (expr.Expr, bool) {
+	switch field.Kind() {
+	case reflect.String:
+		return sql.String(field.String()), false
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val := field.Int()
+		return sql.Int64(val), val == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		val := field.Uint()
+		return sql.Int64(val), val == 0
+	case reflect.Float64, reflect.Float32:
+		val := field.Float()
+		return sql.Float64(val), val == 0
+	case reflect.Bool:
+		return sql.Bool(field.Bool()), false
+	case reflect.Struct:
+		// Handle time.Time specially
+		if t, ok := field.Interface().(time.Time); ok {
+			return sql.Time(t), false
+		}
+	case reflect.Array:
+		if u, ok := uuidSQL(field.Interface()); ok {
+			return u, false
+		}
+	}
+	return nil, false
+}
+
+// InsertIgnore inserts the model with INSERT IGNORE, reporting whether a row
+// was inserted or skipped because it conflicts with an existing one on a unique key.
+// Engines not implementing engine.AffectedExecer are assumed to
+// return a zero insert id for skipped rows.
+func (o *ORM[T, P]) InsertIgnore(ctx context.Context, model *T) (inserted bool, err error) {
+	if model == nil {
+		return false, errors.New("model cannot be nil")
+	}
+
+	keys := o.generateKeys(model)
+	defer func() {
+		if !inserted {
+			o.resetKeys(model, keys)
+		}
+	}()
+	builder, err := o.insertBuilder(model)
+	if err != nil {
+		return false, err
+	}
+	query, args, err := builder.Ignore().SQL()
+	if err != nil {
+		return false, fmt.Errorf("failed to build insert SQL: %w", err)
+	}
+
+	eng := o.getEngine(ctx)
+	if execer, ok := eng.(engine.AffectedExecer); ok {
+		affected, err := execer.ExecAffected(ctx, query, args)
+		if err != nil {
+			return false, fmt.Errorf("failed to execute InsertIgnore: %w", err)
+		}
+		if affected > 0 {
+			o.emitWrite(WriteInsert, o.modelKeys(model, 0))
+		}
+		return affected > 0, nil
+	}
+	id, err := eng.ExecInsert(ctx, query, args)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute InsertIgnore: %w", err)
+	}
+	if id != 0 {
+		o.emitWrite(WriteInsert, o.modelKeys(model, id))
+	}
+	return id != 0, nil
+}
+
+// InsertOrUpdate inserts the model, or if it conflicts with an existing
+// row on a unique key, updates that row with the non-nil fields of
+// updateOnConflict (INSERT ... ON DUPLICATE KEY UPDATE).
+// CreateTime is only set on insert, UpdateTime is set to now on
+// update unless given. A nil updateOnConflict leaves the existing row unchanged.
+// It returns the ID of the inserted or existing row.
+// The conflicting row is matched by the unique key alone, so under an
+// active default scope ErrScopedUpsert is returned, see Unscoped.
+func (o *ORM[T, P]) InsertOrUpdate(ctx context.Context, model *T, updateOnConflict *P) (_ int64, err error) {
+	if model == nil {
+		return 0, errors.New("model cannot be nil")
+	}
+	if len(o.scoped(ctx, nil)) > 0 {
+		return 0, ErrScopedUpsert
+	}
+
+	keys := o.generateKeys(model)
+	defer func() {
+		if err != nil {
+			o.resetKeys(model, keys)
+		}
+	}()
+	builder, err := o.insertBuilder(model)
+	if err != nil {
+		return 0, err
+	}
+
+	var hasUpdate bool
+	// make the driver report the id of the existing row on update
+	d := o.describe()
+	if f, err := o.idField(); err == nil {
+		var id sql.Expr = f
+		if o.tableAlias() != "" {
+			// the logical name qualifying f is not a table of the INSERT
+			id = columnRef(f.Name())
+		}
+		builder.OnDuplicateKeyUpdate(f, sql.Func("LAST_INSERT_ID", id))
+		hasUpdate = true
+	}
+	if updateOnConflict != nil {
+		createTimeColumn := d.createTimeColumn
+		sets, updateTimeField, err := o.updateSets(updateOnConflict)
+		if err != nil {
+			return 0, err
+		}
+		for _, set := range sets {
+			if set.field.Name() == createTimeColumn {
+				continue
+			}
+			builder.OnDuplicateKeyUpdate(set.field, set.value)
+			hasUpdate = true
+		}
+		if updateTimeField != nil {
+			builder.OnDuplicateKeyUpdate(updateTimeField, sql.Time(o.now()))
+			hasUpdate = true
+		}
+	}
+	if !hasUpdate {
+		return 0, ErrNothingToUpdate
 	}
 
-	// Generate the SQL and args
 	query, args, err := builder.SQL()
 	if err != nil {
 		return 0, fmt.Errorf("failed to build insert SQL: %w", err)
 	}
 
-	// Execute the insert and get the ID
-	id, err := o.engine.GetEngine().ExecInsert(ctx, query, args)
+	id, err := o.getEngine(ctx).ExecInsert(ctx, query, args)
 	if err != nil {
-		return 0, fmt.Errorf("failed to execute Insert: %w", err)
+		return 0, fmt.Errorf("failed to execute InsertOrUpdate: %w", err)
+	}
+	if keys.hasID && id != 0 && id != keys.id {
+		// the existing row was updated, the generated keys were not used
+		o.resetUUIDs(model, keys.uuids)
+		if fv, ok := o.modelIDValue(model); ok {
+			fv.SetInt(id)
+		}
 	}
+	o.emitWrite(WriteUpsert, o.modelKeys(model, id))
 
 	return id, nil
 }
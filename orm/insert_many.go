@@ -0,0 +1,303 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/sql"
+)
+
+// InsertMany inserts the models with multi-row INSERT statements,
+// following the rules of Insert for each model. Consecutive models
+// inserting the same columns share a statement, up to sql.MaxParams
+// values, models differing e.g. by nil pointers start a new one, run
+// it in a transaction (see RunInTx) if they must be inserted atomically.
+// Generated ids are not set on the models, use WithIDGenerator or
+// UUID keys if they are needed.
+func (o *ORM[T, P]) InsertMany(ctx context.Context, models []*T) error {
+	_, err := o.insertMany(ctx, models)
+	return err
+}
+
+// insertMany inserts the models like InsertMany, returning the number
+// of models inserted by the statements executed before an error
+func (o *ORM[T, P]) insertMany(ctx context.Context, models []*T) (int, error) {
+	inserted, keys, err := o.insertRows(ctx, models)
+	if inserted > 0 {
+		o.emitWrite(WriteInsert, keys)
+	}
+	return inserted, err
+}
+
+// insertRows inserts the models like insertMany without reporting
+// the write, returning the keys of the models inserted
+func (o *ORM[T, P]) insertRows(ctx context.Context, models []*T) (inserted int, keys []interface{}, err error) {
+	if len(models) == 0 {
+		return 0, nil, nil
+	}
+	generated := make([]generatedKeys, len(models))
+	defer func() {
+		if err == nil {
+			return
+		}
+		for i := inserted; i < len(models); i++ {
+			o.resetKeys(models[i], generated[i])
+		}
+	}()
+	rows := make([]*insertRow, len(models))
+	for i, model := range models {
+		if model == nil {
+			return 0, nil, fmt.Errorf("model %d cannot be nil", i)
+		}
+		generated[i] = o.generateKeys(model)
+		row, err := o.insertRow(model)
+		if err != nil {
+			return 0, nil, fmt.Errorf("model %d: %w", i, err)
+		}
+		rows[i] = row
+	}
+
+	for start := 0; start < len(rows); {
+		maxRows := len(rows)
+		if n := len(rows[start].fields); n > 0 {
+			maxRows = sql.MaxParams / n
+		}
+		end := start + 1
+		for end < len(rows) && end-start < maxRows && rows[end].sameColumns(rows[start]) {
+			end++
+		}
+		builder := o.newInsert().Columns(rows[start].fields...)
+		for _, row := range rows[start:end] {
+			builder.Row(row.values...)
+		}
+		query, args, err := builder.SQL()
+		if err != nil {
+			return start, keys, fmt.Errorf("failed to build insert SQL: %w", err)
+		}
+		if err := o.getEngine(ctx).Exec(ctx, query, args); err != nil {
+			return start, keys, fmt.Errorf("failed to execute InsertMany: %w", err)
+		}
+		for _, model := range models[start:end] {
+			keys = append(keys, o.modelKeys(model, 0)...)
+		}
+		start = end
+	}
+	return len(models), keys, nil
+}
+
+// ChunkOptions configures InsertManyChunked
+type ChunkOptions struct {
+	// ContinueOnError inserts the remaining chunks after
+	// a chunk failed, instead of stopping at the failure
+	ContinueOnError bool
+}
+
+// ChunkResult is the outcome of a chunk inserted by InsertManyChunked
+type ChunkResult struct {
+	// Offset is the index of the first model of the chunk
+	Offset int
+	// Count is the number of models in the chunk
+	Count int
+	// Inserted is the number of models of the chunk inserted, less
+	// than Count if Err is set: 0 with a transaction per chunk, or
+	// the models of the statements executed before the failed one
+	Inserted int
+	Err      error
+}
+
+// InsertManyChunked inserts the models by InsertMany in chunks of at
+// most chunkSize models, returning the result of each attempted chunk,
+// so tolerant imports can report or retry the failed ones:
+//
+//	results, err := user.ORM.InsertManyChunked(ctx, users, 500, orm.ChunkOptions{ContinueOnError: true})
+//	for _, r := range results {
+//	    if r.Err != nil {
+//	        log.Printf("users %d-%d: %v", r.Offset+r.Inserted, r.Offset+r.Count-1, r.Err)
+//	    }
+//	}
+//
+// Each chunk runs in its own transaction if the engine implements
+// engine.TxBeginner, so a failed chunk inserts nothing and its writes are
+// not reported to OnWrite listeners until it is committed. Otherwise a chunk
+// split into several statements, see InsertMany, may be partially
+// inserted, as reported by Inserted.
+// The error reports the first failed chunk, if any.
+func (o *ORM[T, P]) InsertManyChunked(ctx context.Context, models []*T, chunkSize int, opts ChunkOptions) ([]ChunkResult, error) {
+	if chunkSize <= 0 {
+		return nil, errors.New("chunk size must be positive")
+	}
+	var results []ChunkResult
+	var failed int
+	var firstErr error
+	for offset := 0; offset < len(models); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(models) {
+			end = len(models)
+		}
+		inserted, err := o.insertChunk(ctx, models[offset:end])
+		results = append(results, ChunkResult{Offset: offset, Count: end - offset, Inserted: inserted, Err: err})
+		if err == nil {
+			continue
+		}
+		failed++
+		if firstErr == nil {
+			firstErr = fmt.Errorf("chunk at offset %d: %w", offset, err)
+		}
+		if !opts.ContinueOnError {
+			break
+		}
+	}
+	if firstErr != nil {
+		return results, fmt.Errorf("failed to insert %d of %d chunks, %w", failed, len(results), firstErr)
+	}
+	return results, nil
+}
+
+// insertChunk inserts the models of a chunk by InsertMany, in
+// a transaction if the engine supports it, returning the number
+// of models inserted
+func (o *ORM[T, P]) insertChunk(ctx context.Context, models []*T) (int, error) {
+	eng := o.baseEngine(ctx)
+	if _, ok := eng.(engine.TxBeginner); !ok {
+		return o.insertMany(ctx, models)
+	}
+	// keys are generated outside the transaction, so they
+	// are reset if it is rolled back
+	generated := make([]generatedKeys, len(models))
+	for i, model := range models {
+		if model != nil {
+			generated[i] = o.generateKeys(model)
+		}
+	}
+	// the write is reported only once the transaction is committed
+	var keys []interface{}
+	err := RunInTx(ctx, engine.Getter(func() engine.Engine { return eng }), func(ctx context.Context, tx engine.Tx) error {
+		var err error
+		_, keys, err = o.WithEngine(tx).insertRows(ctx, models)
+		return err
+	})
+	if err != nil {
+		for i, model := range models {
+			if model != nil {
+				o.resetKeys(model, generated[i])
+			}
+		}
+		return 0, err
+	}
+	o.emitWrite(WriteInsert, keys)
+	return len(models), nil
+}
+
+// StreamError is returned by InsertStream when it stops before the
+// channel is closed, with the models received but not inserted
+type StreamError[T any] struct {
+	// Pending are the models received from the
+	// channel but not inserted, in order
+	Pending []*T
+	Err     error
+}
+
+func (e *StreamError[T]) Error() string {
+	return fmt.Sprintf("%v, %d received models not inserted", e.Err, len(e.Pending))
+}
+
+// Unwrap returns the error stopping InsertStream, e.g. context.Canceled
+func (e *StreamError[T]) Unwrap() error {
+	return e.Err
+}
+
+// StreamOptions configures InsertStream
+type StreamOptions struct {
+	// BatchSize is the number of models inserted
+	// by one statement, 500 if zero
+	BatchSize int
+	// FlushInterval is the longest a model waits for its
+	// batch to fill before it is inserted, 1s if zero
+	FlushInterval time.Duration
+}
+
+// InsertStream inserts the models received from the channel in batches
+// by InsertMany, flushing a batch when it is full or when FlushInterval
+// passed since its first model, until the channel is closed, e.g. for
+// ingestion pipelines fed by queues:
+//
+//	n, err := event.ORM.InsertStream(ctx, events, orm.StreamOptions{BatchSize: 1000, FlushInterval: time.Second})
+//
+// It returns the number of models inserted, and stops at the first
+// failed batch or when ctx is done, leaving the models still in the
+// channel to the caller. The models received but not inserted are
+// returned by a *StreamError, e.g. to insert them once more on shutdown:
+//
+//	var streamErr *orm.StreamError[event.Event]
+//	if errors.As(err, &streamErr) {
+//	    err = event.ORM.InsertMany(context.Background(), streamErr.Pending)
+//	}
+func (o *ORM[T, P]) InsertStream(ctx context.Context, models <-chan *T, opts StreamOptions) (int, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = 500
+	}
+	interval := opts.FlushInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	var inserted int
+	batch := make([]*T, 0, batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		n, err := o.insertMany(ctx, batch)
+		inserted += n
+		if err != nil {
+			return &StreamError[T]{
+				Pending: append([]*T(nil), batch[n:]...),
+				Err:     fmt.Errorf("failed to insert batch of %d after %d inserted: %w", len(batch), inserted, err),
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+
+	// timer is pending while the batch is not empty
+	var timer *time.Timer
+	var timeout <-chan time.Time
+	stopTimer := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+	}
+	defer stopTimer()
+	for {
+		select {
+		case <-ctx.Done():
+			return inserted, &StreamError[T]{Pending: batch, Err: ctx.Err()}
+		case <-timeout:
+			timer, timeout = nil, nil
+			if err := flush(); err != nil {
+				return inserted, err
+			}
+		case model, ok := <-models:
+			if !ok {
+				return inserted, flush()
+			}
+			batch = append(batch, model)
+			if len(batch) == 1 {
+				timer = time.NewTimer(interval)
+				timeout = timer.C
+			}
+			if len(batch) < batchSize {
+				continue
+			}
+			stopTimer()
+			if err := flush(); err != nil {
+				return inserted, err
+			}
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/sql"
+)
+
+// failingEngine fails the statements inserting the name
+type failingEngine struct {
+	MockEngine
+	name string
+}
+
+func (m *failingEngine) GetEngine() engine.Engine {
+	return m
+}
+
+func (m *failingEngine) Exec(ctx context.Context, sql string, args []interface{}) error {
+	for _, arg := range args {
+		if arg == m.name {
+			return errors.New("duplicate entry")
+		}
+	}
+	return m.MockEngine.Exec(ctx, sql, args)
+}
+
+func (m *failingEngine) ExecInsert(ctx context.Context, sql string, args []interface{}) (int64, error) {
+	for _, arg := range args {
+		if arg == m.name {
+			return 0, errors.New("duplicate entry")
+		}
+	}
+	return m.MockEngine.ExecInsert(ctx, sql, args)
+}
+
+func TestInsertMany(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockEngine := &MockEngine{}
+	orm := newInsertOrUpdateTestORM(mockEngine, now)
+
+	err := orm.InsertMany(context.Background(), []*TestModelWithTime{
+		{Name: "Alice", Age: 30},
+		{Name: "Bob", Age: 25},
+		{Id: 7, Name: "Carol", Age: 40},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		"INSERT INTO `tasks` (`name`, `age`, `create_time`, `update_time`) VALUES (?, ?, ?, ?), (?, ?, ?, ?)",
+		"INSERT INTO `tasks` (`id`, `name`, `age`, `create_time`, `update_time`) VALUES (?, ?, ?, ?, ?)",
+	}
+	if len(mockEngine.ExecCalls) != len(expected) {
+		t.Fatalf("Expected %d Exec calls, got %v", len(expected), mockEngine.ExecCalls)
+	}
+	for i, call := range mockEngine.ExecCalls {
+		if call.SQL != expected[i] {
+			t.Errorf("Expected SQL:\n%s\ngot:\n%s", expected[i], call.SQL)
+		}
+	}
+	if args := mockEngine.ExecCalls[0].Args; len(args) != 8 || args[0] != "Alice" || args[4] != "Bob" || args[7] != now {
+		t.Errorf("Unexpected args %v", args)
+	}
+
+	if err := orm.InsertMany(context.Background(), []*TestModelWithTime{{Name: "Dave"}, nil}); err == nil || err.Error() != "model 1 cannot be nil" {
+		t.Errorf("Expected nil model error, got %v", err)
+	}
+	if len(mockEngine.ExecCalls) != 2 {
+		t.Errorf("Expected nothing inserted on invalid models, got %d Exec calls", len(mockEngine.ExecCalls))
+	}
+}
+
+func TestInsertManyChunked(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	eng := &failingEngine{name: "Bob"}
+	orm := newInsertOrUpdateTestORM(&MockEngine{}, now)
+	orm.engine = eng
+
+	models := []*TestModelWithTime{{Name: "Alice"}, {Name: "Bob"}, {Name: "Carol"}, {Name: "Dave"}, {Name: "Eve"}}
+	results, err := orm.InsertManyChunked(context.Background(), models, 2, ChunkOptions{})
+	if err == nil || !strings.Contains(err.Error(), "failed to insert 1 of 1 chunks, chunk at offset 0") {
+		t.Errorf("Expected the first chunk to fail, got %v", err)
+	}
+	if len(results) != 1 || results[0].Err == nil || len(eng.ExecCalls) != 0 {
+		t.Errorf("Expected to stop after the failed chunk, got %+v", results)
+	}
+
+	eng.ExecCalls = nil
+	results, err = orm.InsertManyChunked(context.Background(), models, 2, ChunkOptions{ContinueOnError: true})
+	if err == nil || !strings.Contains(err.Error(), "failed to insert 1 of 3 chunks") {
+		t.Errorf("Expected 1 of 3 chunks to fail, got %v", err)
+	}
+	expected := []ChunkResult{{Offset: 0, Count: 2}, {Offset: 2, Count: 2}, {Offset: 4, Count: 1}}
+	if len(results) != len(expected) {
+		t.Fatalf("Expected %d results, got %+v", len(expected), results)
+	}
+	for i, r := range results {
+		if r.Offset != expected[i].Offset || r.Count != expected[i].Count || (r.Err != nil) != (i == 0) {
+			t.Errorf("Expected result %d %+v, got %+v", i, expected[i], r)
+		}
+	}
+	if len(eng.ExecCalls) != 2 {
+		t.Errorf("Expected the other chunks inserted, got %d Exec calls", len(eng.ExecCalls))
+	}
+
+	if _, err := orm.InsertManyChunked(context.Background(), models, 0, ChunkOptions{}); err == nil {
+		t.Errorf("Expected an error for a zero chunk size")
+	}
+}
+
+// TestInsertManyChunked_Partial tests reporting the models of a failed
+// chunk inserted by the statements before the failed one
+func TestInsertManyChunked_Partial(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	eng := &failingEngine{name: "Bob"}
+	orm := newInsertOrUpdateTestORM(&MockEngine{}, now)
+	orm.engine = eng
+
+	// 4 columns per model, the last model is inserted by its own statement
+	models := make([]*TestModelWithTime, sql.MaxParams/4+1)
+	for i := range models {
+		models[i] = &TestModelWithTime{Name: "user"}
+	}
+	models[len(models)-1].Name = "Bob"
+	results, err := orm.InsertManyChunked(context.Background(), models, len(models), ChunkOptions{})
+	if err == nil {
+		t.Fatal("Expected the chunk to fail")
+	}
+	if len(results) != 1 || results[0].Inserted != len(models)-1 {
+		t.Errorf("Expected %d models inserted before the failure, got %+v", len(models)-1, results)
+	}
+}
+
+// failingTxEngine begins transactions failing the statements inserting the name
+type failingTxEngine struct {
+	failingEngine
+	commits   int
+	rollbacks int
+}
+
+func (m *failingTxEngine) GetEngine() engine.Engine {
+	return m
+}
+
+func (m *failingTxEngine) BeginTx(ctx context.Context, opts engine.TxOptions) (engine.Tx, error) {
+	return &failingTx{failingEngine: &m.failingEngine, engine: m}, nil
+}
+
+type failingTx struct {
+	*failingEngine
+	engine *failingTxEngine
+}
+
+func (t *failingTx) GetEngine() engine.Engine {
+	return t
+}
+
+func (t *failingTx) Commit() error {
+	t.engine.commits++
+	return nil
+}
+
+func (t *failingTx) Rollback() error {
+	t.engine.rollbacks++
+	return nil
+}
+
+// TestInsertManyChunked_Tx tests that each chunk runs in its own transaction
+func TestInsertManyChunked_Tx(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	eng := &failingTxEngine{failingEngine: failingEngine{name: "Bob"}}
+	orm := newInsertOrUpdateTestORM(&MockEngine{}, now)
+	orm.engine = eng
+
+	models := []*TestModelWithTime{{Name: "Alice"}, {Name: "Bob"}, {Name: "Carol"}}
+	results, err := orm.InsertManyChunked(context.Background(), models, 2, ChunkOptions{ContinueOnError: true})
+	if err == nil {
+		t.Fatal("Expected the first chunk to fail")
+	}
+	if len(results) != 2 || results[0].Err == nil || results[0].Inserted != 0 || results[1].Err != nil || results[1].Inserted != 1 {
+		t.Errorf("Unexpected results: %+v", results)
+	}
+	if eng.rollbacks != 1 || eng.commits != 1 {
+		t.Errorf("Expected 1 rollback and 1 commit, got %d and %d", eng.rollbacks, eng.commits)
+	}
+}
+
+// TestInsertManyChunked_TxEvents tests that writes of a chunk
+// are reported only once its transaction is committed
+func TestInsertManyChunked_TxEvents(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	eng := &failingTxEngine{failingEngine: failingEngine{name: "Bob"}}
+	orm := newInsertOrUpdateTestORM(&MockEngine{}, now)
+	orm.engine = eng
+	var events []WriteEvent
+	orm.OnWrite(func(ev WriteEvent) {
+		events = append(events, ev)
+	})
+
+	// Alice and Bob differ by the id, so Alice is inserted
+	// by a statement of its own before Bob fails
+	models := []*TestModelWithTime{{Name: "Alice"}, {Id: 5, Name: "Bob"}, {Id: 7, Name: "Carol"}}
+	_, err := orm.InsertManyChunked(context.Background(), models, 2, ChunkOptions{ContinueOnError: true})
+	if err == nil {
+		t.Fatal("Expected the first chunk to fail")
+	}
+	expected := []WriteEvent{
+		{Table: "tasks", Op: WriteInsert, Keys: []interface{}{int64(7)}},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Errorf("Expected events %v, got %v", expected, events)
+	}
+}
+
+// notifyEngine reports the statements it executes
+type notifyEngine struct {
+	MockEngine
+	execs chan []interface{}
+}
+
+func (m *notifyEngine) GetEngine() engine.Engine {
+	return m
+}
+
+func (m *notifyEngine) Exec(ctx context.Context, sql string, args []interface{}) error {
+	m.execs <- args
+	return nil
+}
+
+func TestInsertStream(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockEngine := &MockEngine{}
+	orm := newInsertOrUpdateTestORM(mockEngine, now)
+
+	models := make(chan *TestModelWithTime, 5)
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dave", "Eve"} {
+		models <- &TestModelWithTime{Name: name}
+	}
+	close(models)
+	n, err := orm.InsertStream(context.Background(), models, StreamOptions{BatchSize: 2, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 5 {
+		t.Errorf("Expected 5 inserted, got %d", n)
+	}
+	expected := []int{8, 8, 4}
+	if len(mockEngine.ExecCalls) != len(expected) {
+		t.Fatalf("Expected %d batches, got %d", len(expected), len(mockEngine.ExecCalls))
+	}
+	for i, call := range mockEngine.ExecCalls {
+		if len(call.Args) != expected[i] {
+			t.Errorf("Expected batch %d with %d args, got %v", i, expected[i], call.Args)
+		}
+	}
+}
+
+func TestInsertStream_FlushInterval(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	eng := &notifyEngine{execs: make(chan []interface{}, 1)}
+	orm := newInsertOrUpdateTestORM(&MockEngine{}, now)
+	orm.engine = eng
+
+	models := make(chan *TestModelWithTime)
+	done := make(chan error, 1)
+	go func() {
+		_, err := orm.InsertStream(context.Background(), models, StreamOptions{BatchSize: 100, FlushInterval: 10 * time.Millisecond})
+		done <- err
+	}()
+
+	models <- &TestModelWithTime{Name: "Alice"}
+	select {
+	case args := <-eng.execs:
+		if len(args) != 4 || args[0] != "Alice" {
+			t.Errorf("Expected Alice inserted, got %v", args)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the partial batch flushed after the interval")
+	}
+
+	close(models)
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	select {
+	case args := <-eng.execs:
+		t.Errorf("Expected nothing left to insert, got %v", args)
+	default:
+	}
+}
+
+// TestInsertStream_Cancel tests returning the received models not inserted when ctx is done
+func TestInsertStream_Cancel(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockEngine := &MockEngine{}
+	orm := newInsertOrUpdateTestORM(mockEngine, now)
+
+	models := make(chan *TestModelWithTime, 3)
+	for _, name := range []string{"Alice", "Bob", "Carol"} {
+		models <- &TestModelWithTime{Name: name}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	var n int
+	go func() {
+		var err error
+		n, err = orm.InsertStream(ctx, models, StreamOptions{BatchSize: 2, FlushInterval: time.Hour})
+		done <- err
+	}()
+	// wait for the first batch, Carol stays in the partial batch
+	deadline := time.Now().Add(time.Second)
+	for len(models) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	err := <-done
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	var streamErr *StreamError[TestModelWithTime]
+	if !errors.As(err, &streamErr) {
+		t.Fatalf("Expected a StreamError, got %T", err)
+	}
+	if n != 2 || len(streamErr.Pending) != 1 || streamErr.Pending[0].Name != "Carol" {
+		t.Errorf("Expected 2 inserted and Carol pending, got %d %+v", n, streamErr.Pending)
+	}
+}
+
+// TestInsertStream_Failed tests returning the models of the failed batch
+func TestInsertStream_Failed(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	eng := &failingEngine{name: "Carol"}
+	orm := newInsertOrUpdateTestORM(&MockEngine{}, now)
+	orm.engine = eng
+
+	models := make(chan *TestModelWithTime, 4)
+	for _, name := range []string{"Alice", "Bob", "Carol", "Dave"} {
+		models <- &TestModelWithTime{Name: name}
+	}
+	close(models)
+	n, err := orm.InsertStream(context.Background(), models, StreamOptions{BatchSize: 2, FlushInterval: time.Hour})
+	var streamErr *StreamError[TestModelWithTime]
+	if !errors.As(err, &streamErr) {
+		t.Fatalf("Expected a StreamError, got %v", err)
+	}
+	if n != 2 || len(streamErr.Pending) != 2 || streamErr.Pending[0].Name != "Carol" || streamErr.Pending[1].Name != "Dave" {
+		t.Errorf("Expected Carol and Dave pending after 2 inserted, got %d %+v", n, streamErr.Pending)
+	}
+}
+
+func TestInsertMany_MaxParams(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockEngine := &MockEngine{}
+	orm := newInsertOrUpdateTestORM(mockEngine, now)
+
+	// 4 columns per model
+	models := make([]*TestModelWithTime, sql.MaxParams/4+1)
+	for i := range models {
+		models[i] = &TestModelWithTime{Name: "user"}
+	}
+	if err := orm.InsertMany(context.Background(), models); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mockEngine.ExecCalls) != 2 {
+		t.Fatalf("Expected 2 Exec calls, got %d", len(mockEngine.ExecCalls))
+	}
+	if n := len(mockEngine.ExecCalls[0].Args); n > sql.MaxParams {
+		t.Errorf("Expected at most %d args, got %d", sql.MaxParams, n)
+	}
+	if n := len(mockEngine.ExecCalls[1].Args); n != 4 {
+		t.Errorf("Expected the last model in its own statement, got %d args", n)
+	}
+}
@@ -0,0 +1,193 @@
+package orm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+func newInsertOrUpdateTestORM(mockEngine *MockEngine, now time.Time) *ORM[TestModelWithTime, TestModelWithTimeOptional] {
+	testTable := table.New("tasks")
+	testTable.Int64("id")
+	testTable.String("name")
+	testTable.Int64("age")
+	testTable.Time("create_time")
+	testTable.Time("update_time")
+	return &ORM[TestModelWithTime, TestModelWithTimeOptional]{
+		table:  testTable,
+		engine: mockEngine,
+		opts:   options{clock: func() time.Time { return now }},
+	}
+}
+
+func TestInsertOrUpdate(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockEngine := &MockEngine{}
+	orm := newInsertOrUpdateTestORM(mockEngine, now)
+
+	age := 31
+	id, err := orm.InsertOrUpdate(context.Background(), &TestModelWithTime{Name: "Alice", Age: 30}, &TestModelWithTimeOptional{
+		Age:        &age,
+		CreateTime: &now,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 42 {
+		t.Errorf("Expected ID 42, got %d", id)
+	}
+	if len(mockEngine.ExecInsertCalls) != 1 {
+		t.Fatalf("Expected 1 ExecInsert call, got %d", len(mockEngine.ExecInsertCalls))
+	}
+	call := mockEngine.ExecInsertCalls[0]
+	expectedSQL := "INSERT INTO `tasks` SET `name`=?, `age`=?, `create_time`=?, `update_time`=? ON DUPLICATE KEY UPDATE `id`=LAST_INSERT_ID(`tasks`.`id`), `age`=?, `update_time`=?"
+	if call.SQL != expectedSQL {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expectedSQL, call.SQL)
+	}
+	expectedArgs := []interface{}{"Alice", int64(30), now, now, int64(31), now}
+	if len(call.Args) != len(expectedArgs) {
+		t.Fatalf("Expected args %v, got %v", expectedArgs, call.Args)
+	}
+	for i, arg := range expectedArgs {
+		if call.Args[i] != arg {
+			t.Errorf("Expected arg[%d] %v, got %v", i, arg, call.Args[i])
+		}
+	}
+}
+
+func TestInsertOrUpdate_NilUpdate(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockEngine := &MockEngine{}
+	orm := newInsertOrUpdateTestORM(mockEngine, now)
+
+	_, err := orm.InsertOrUpdate(context.Background(), &TestModelWithTime{Name: "Alice"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedSQL := "INSERT INTO `tasks` SET `name`=?, `age`=?, `create_time`=?, `update_time`=? ON DUPLICATE KEY UPDATE `id`=LAST_INSERT_ID(`tasks`.`id`)"
+	if got := mockEngine.ExecInsertCalls[0].SQL; got != expectedSQL {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expectedSQL, got)
+	}
+}
+
+// MockIgnoreEngine reports rows affected of INSERT IGNORE
+type MockIgnoreEngine struct {
+	MockEngine
+	affected int64
+}
+
+func (m *MockIgnoreEngine) ExecAffected(ctx context.Context, sql string, args []interface{}) (int64, error) {
+	m.ExecCalls = append(m.ExecCalls, ExecCall{SQL: sql, Args: args})
+	return m.affected, nil
+}
+
+func (m *MockIgnoreEngine) GetEngine() engine.Engine {
+	return m
+}
+
+func TestInsertIgnore(t *testing.T) {
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name")
+	testTable.Int64("age")
+
+	for _, affected := range []int64{0, 1} {
+		mockEngine := &MockIgnoreEngine{affected: affected}
+		orm := &ORM[TestModel, TestModelOptional]{table: testTable, engine: mockEngine}
+
+		inserted, err := orm.InsertIgnore(context.Background(), &TestModel{Name: "Alice"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if inserted != (affected == 1) {
+			t.Errorf("Expected inserted %v for %d rows affected", affected == 1, affected)
+		}
+		expectedSQL := "INSERT IGNORE INTO `test_table` SET `name`=?, `age`=?"
+		if got := mockEngine.ExecCalls[0].SQL; got != expectedSQL {
+			t.Errorf("Expected SQL %q, got %q", expectedSQL, got)
+		}
+	}
+}
+
+func TestInsertIgnore_InsertID(t *testing.T) {
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name")
+	testTable.Int64("age")
+	mockEngine := &MockEngine{}
+	orm := &ORM[TestModel, TestModelOptional]{table: testTable, engine: mockEngine}
+
+	inserted, err := orm.InsertIgnore(context.Background(), &TestModel{Name: "Alice"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !inserted {
+		t.Errorf("Expected inserted for non-zero insert id")
+	}
+}
+
+func TestInsert_Defaults(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	testTable := table.New("tasks")
+	testTable.Int64("id")
+	testTable.String("name").Default("unnamed")
+	testTable.Int64("age").Default(18)
+	testTable.Time("create_time")
+	testTable.Time("update_time")
+	newORM := func(mockEngine *MockEngine, opts options) *ORM[TestModelWithTime, TestModelWithTimeOptional] {
+		opts.clock = func() time.Time { return now }
+		return &ORM[TestModelWithTime, TestModelWithTimeOptional]{table: testTable, engine: mockEngine, opts: opts}
+	}
+
+	mockEngine := &MockEngine{}
+	_, err := newORM(mockEngine, options{fillDefaults: true}).Insert(context.Background(), &TestModelWithTime{Age: 30})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	call := mockEngine.ExecInsertCalls[0]
+	expectedArgs := []interface{}{"unnamed", int64(30), now, now}
+	if len(call.Args) != len(expectedArgs) {
+		t.Fatalf("Expected args %v, got %v", expectedArgs, call.Args)
+	}
+	for i, arg := range expectedArgs {
+		if call.Args[i] != arg {
+			t.Errorf("Expected arg[%d] %v, got %v", i, arg, call.Args[i])
+		}
+	}
+
+	// defaults are not filled unless opted in
+	mockEngine = &MockEngine{}
+	_, err = newORM(mockEngine, options{}).Insert(context.Background(), &TestModelWithTime{Age: 30})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := mockEngine.ExecInsertCalls[0].Args[0]; got != "" {
+		t.Errorf("Expected empty name, got %v", got)
+	}
+}
+
+func TestInsert_FillID(t *testing.T) {
+	mockEngine := &MockEngine{}
+	orm := newInsertOrUpdateTestORM(mockEngine, time.Now())
+
+	model := &TestModelWithTime{Name: "Alice"}
+	id, err := orm.Insert(context.Background(), model)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != 42 || model.Id != 42 {
+		t.Errorf("Expected id 42 filled into the model, got %d (model %d)", id, model.Id)
+	}
+
+	// given ids are kept
+	model = &TestModelWithTime{Id: 7, Name: "Bob"}
+	if _, err := orm.Insert(context.Background(), model); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if model.Id != 7 {
+		t.Errorf("Expected given id 7 to be kept, got %d", model.Id)
+	}
+}
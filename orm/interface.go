@@ -0,0 +1,61 @@
+package orm
+
+import (
+	"context"
+
+	"github.com/xhd2015/arc-orm/field"
+)
+
+// Interface is the method set of ORM executing statements, so service
+// layers can depend on it and tests can substitute fakes. Builders like
+// SelectAll are left out, as fakes can not return them.
+// A fake may embed the interface and override only the methods it needs:
+//
+//	type fakeUsers struct {
+//	    orm.Interface[User, UserOptional]
+//	    users map[int64]*User
+//	}
+//
+//	func (f *fakeUsers) GetByID(ctx context.Context, id int64) (*User, error) { ... }
+type Interface[T any, P any] interface {
+	Insert(ctx context.Context, model *T) (int64, error)
+	InsertMany(ctx context.Context, models []*T) error
+	InsertManyChunked(ctx context.Context, models []*T, chunkSize int, opts ChunkOptions) ([]ChunkResult, error)
+	InsertStream(ctx context.Context, models <-chan *T, opts StreamOptions) (int, error)
+	LoadData(ctx context.Context, models []*T) error
+	InsertIgnore(ctx context.Context, model *T) (bool, error)
+	InsertOrUpdate(ctx context.Context, model *T, updateOnConflict *P) (int64, error)
+	GetOrCreate(ctx context.Context, condition *P, create *T) (*T, bool, error)
+
+	GetByID(ctx context.Context, id int64) (*T, error)
+	FindByID(ctx context.Context, id int64) (*T, error)
+	GetByKey(ctx context.Context, key interface{}) (*T, error)
+	GetBy(ctx context.Context, condition *P) (*T, error)
+	GetByIDs(ctx context.Context, ids []int64) (map[int64]*T, error)
+	ListByIDs(ctx context.Context, ids []int64) ([]*T, error)
+	FindByExample(ctx context.Context, example *T, include ...field.Field) ([]*T, error)
+	QuerySQL(ctx context.Context, sql string, args []interface{}) ([]*T, error)
+	QueryByFilter(ctx context.Context, filter interface{}) ([]*T, error)
+	GetByFilter(ctx context.Context, filter interface{}) (*T, error)
+
+	UpdateByID(ctx context.Context, id int64, data *P) error
+	UpdateByKey(ctx context.Context, key interface{}, data *P) error
+	UpdateModelByID(ctx context.Context, id int64, model *T, opts ...UpdateOption) error
+	UpdateBy(ctx context.Context, condition *P, data *P) error
+	UpdateManyByID(ctx context.Context, updates map[int64]*P) error
+	UpdateByFilter(ctx context.Context, filter interface{}, data *P) error
+
+	DeleteByID(ctx context.Context, id int64) error
+	DeleteByKey(ctx context.Context, key interface{}) error
+	DeleteBy(ctx context.Context, condition *P) error
+	DeleteWhere(ctx context.Context, conditions ...field.Expr) error
+	DeleteByIDs(ctx context.Context, ids []int64) (int64, error)
+	DeleteByFilter(ctx context.Context, filter interface{}) error
+
+	Sum(ctx context.Context, f field.Field, conditions ...field.Expr) (float64, error)
+	Avg(ctx context.Context, f field.Field, conditions ...field.Expr) (float64, error)
+	Min(ctx context.Context, f field.Field, conditions ...field.Expr) (float64, error)
+	Max(ctx context.Context, f field.Field, conditions ...field.Expr) (float64, error)
+}
+
+var _ Interface[struct{}, struct{}] = (*ORM[struct{}, struct{}])(nil)
@@ -0,0 +1,53 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// fakeTestModels overrides the methods used by the code under test
+type fakeTestModels struct {
+	Interface[TestModel, TestModelOptional]
+	models map[int64]*TestModel
+}
+
+func (f *fakeTestModels) GetByID(ctx context.Context, id int64) (*TestModel, error) {
+	if model, ok := f.models[id]; ok {
+		return model, nil
+	}
+	return nil, ErrNotFound
+}
+
+func nameOf(ctx context.Context, models Interface[TestModel, TestModelOptional], id int64) (string, error) {
+	model, err := models.GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	return model.Name, nil
+}
+
+func TestInterface(t *testing.T) {
+	fake := &fakeTestModels{models: map[int64]*TestModel{1: {Id: 1, Name: "Alice"}}}
+	name, err := nameOf(context.Background(), fake, 1)
+	if err != nil || name != "Alice" {
+		t.Errorf("Expected Alice from the fake, got %q, %v", name, err)
+	}
+
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name")
+	testTable.Int64("age")
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			*result.(*[]*TestModel) = []*TestModel{{Id: 2, Name: "Bob"}}
+			return nil
+		},
+	}
+	var models Interface[TestModel, TestModelOptional] = Bind[TestModel, TestModelOptional](mockEngine, testTable)
+	name, err = nameOf(context.Background(), models, 2)
+	if err != nil || name != "Bob" {
+		t.Errorf("Expected Bob from the ORM, got %q, %v", name, err)
+	}
+}
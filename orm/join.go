@@ -0,0 +1,184 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sql"
+	"github.com/xhd2015/arc-orm/sql/expr"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// Pair is a row of a two-table join scanned into both models
+type Pair[T1 any, T2 any] struct {
+	First  *T1
+	Second *T2
+}
+
+// Join2Builder builds an inner join of two tables whose rows
+// are scanned into both models, see Join2
+type Join2Builder[T1 any, P1 any, T2 any, P2 any] struct {
+	first      *ORM[T1, P1]
+	second     *ORM[T2, P2]
+	condition  field.Expr
+	exprs      []sql.Expr
+	conditions []field.Expr
+	orderBys   []expr.Expr
+	limit      int
+	offset     int
+	scanType   reflect.Type
+	firstIdx   []joinColumn
+	secondIdx  []joinColumn
+	err        error
+}
+
+// joinColumn maps a model field to the field of the scanned row
+type joinColumn struct {
+	modelIndex int
+	rowIndex   int
+}
+
+// Join2 selects the columns of both tables aliased as
+// first__<column> and second__<column> and scans each row into both models.
+// The two tables must be distinct.
+// Example:
+//
+//	pairs, err := orm.Join2(user.ORM, post.ORM).
+//	    On(post.UserId.EqField(user.Id)).
+//	    Where(user.Age.Gt(18)).
+//	    Query(ctx)
+func Join2[T1 any, P1 any, T2 any, P2 any](first *ORM[T1, P1], second *ORM[T2, P2]) *Join2Builder[T1, P1, T2, P2] {
+	j := &Join2Builder[T1, P1, T2, P2]{
+		first:  first,
+		second: second,
+	}
+	if first.table.Name() == second.table.Name() {
+		j.err = fmt.Errorf("Join2 requires distinct tables, got %s twice", first.table.Name())
+		return j
+	}
+
+	var exprs []sql.Expr
+	var rowFields []reflect.StructField
+	addColumns := func(modelType reflect.Type, tbl table.Table, naming NamingStrategy, prefix string) []joinColumn {
+		tableFields := make(map[string]field.Field)
+		for _, f := range tbl.Fields() {
+			tableFields[f.Name()] = f
+		}
+		var columns []joinColumn
+		for i := 0; i < modelType.NumField(); i++ {
+			f := modelType.Field(i)
+			if !f.IsExported() || Ignored(f) {
+				continue
+			}
+			tableField, ok := tableFields[naming.ColumnName(f)]
+			if !ok {
+				continue
+			}
+			alias := prefix + "__" + tableField.Name()
+			exprs = append(exprs, field.As(tableField, alias))
+			columns = append(columns, joinColumn{modelIndex: i, rowIndex: len(rowFields)})
+			rowFields = append(rowFields, reflect.StructField{
+				Name: fmt.Sprintf("F%d", len(rowFields)),
+				Type: f.Type,
+				Tag:  reflect.StructTag(fmt.Sprintf(`orm:"column:%s" json:"%s"`, alias, alias)),
+			})
+		}
+		return columns
+	}
+	j.firstIdx = addColumns(reflect.TypeOf((*T1)(nil)).Elem(), first.table, first.opts.naming, "first")
+	j.secondIdx = addColumns(reflect.TypeOf((*T2)(nil)).Elem(), second.table, second.opts.naming, "second")
+	j.scanType = reflect.StructOf(rowFields)
+	j.exprs = exprs
+	return j
+}
+
+// On sets the join condition, required before querying
+func (j *Join2Builder[T1, P1, T2, P2]) On(condition field.Expr) *Join2Builder[T1, P1, T2, P2] {
+	j.condition = condition
+	return j
+}
+
+func (j *Join2Builder[T1, P1, T2, P2]) Where(conditions ...field.Expr) *Join2Builder[T1, P1, T2, P2] {
+	j.conditions = append(j.conditions, conditions...)
+	return j
+}
+
+func (j *Join2Builder[T1, P1, T2, P2]) OrderBy(orderFields ...expr.Expr) *Join2Builder[T1, P1, T2, P2] {
+	j.orderBys = append(j.orderBys, orderFields...)
+	return j
+}
+
+func (j *Join2Builder[T1, P1, T2, P2]) Limit(limit int) *Join2Builder[T1, P1, T2, P2] {
+	j.limit = limit
+	return j
+}
+
+func (j *Join2Builder[T1, P1, T2, P2]) Offset(offset int) *Join2Builder[T1, P1, T2, P2] {
+	j.offset = offset
+	return j
+}
+
+// SQL generates the SQL string and parameters
+func (j *Join2Builder[T1, P1, T2, P2]) SQL() (string, []interface{}, error) {
+	return j.buildSQL(j.conditions, j.limit)
+}
+
+func (j *Join2Builder[T1, P1, T2, P2]) buildSQL(conditions []field.Expr, limit int) (string, []interface{}, error) {
+	if j.err != nil {
+		return "", nil, j.err
+	}
+	if j.condition == nil {
+		return "", nil, fmt.Errorf("Join2 requires On condition")
+	}
+	return j.first.newSelect(j.exprs...).
+		JoinAs(j.second.physicalTable(), j.second.tableAlias(), j.condition).
+		Where(conditions...).
+		OrderBy(j.orderBys...).
+		Limit(limit).
+		Offset(j.offset).
+		SQL()
+}
+
+// Query executes the join and returns the rows scanned into both models
+// Both tables are restricted by their default scopes, see WithDefaultScope.
+func (j *Join2Builder[T1, P1, T2, P2]) Query(ctx context.Context) ([]Pair[T1, T2], error) {
+	conditions := j.second.scoped(ctx, j.first.scoped(ctx, j.conditions))
+	limit := j.limit
+	// limited by WithMaxRows of the first ORM, see limitRows
+	limited := limit <= 0 && j.first.opts.maxRows > 0
+	if limited {
+		limit = j.first.opts.maxRows + 1
+	}
+	query, args, err := j.buildSQL(conditions, limit)
+	if err != nil {
+		return nil, err
+	}
+	rows := reflect.New(reflect.SliceOf(reflect.PtrTo(j.scanType)))
+	err = j.first.getEngine(ctx).Query(ctx, query, args, rows.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute Join2: %w", err)
+	}
+
+	rowsV := rows.Elem()
+	if err := j.first.checkRows(limited, rowsV.Len()); err != nil {
+		return nil, err
+	}
+	pairs := make([]Pair[T1, T2], 0, rowsV.Len())
+	for i := 0; i < rowsV.Len(); i++ {
+		row := rowsV.Index(i).Elem()
+		first := new(T1)
+		second := new(T2)
+		copyJoinColumns(reflect.ValueOf(first).Elem(), row, j.firstIdx)
+		copyJoinColumns(reflect.ValueOf(second).Elem(), row, j.secondIdx)
+		pairs = append(pairs, Pair[T1, T2]{First: first, Second: second})
+	}
+	return pairs, nil
+}
+
+func copyJoinColumns(model reflect.Value, row reflect.Value, columns []joinColumn) {
+	for _, c := range columns {
+		model.Field(c.modelIndex).Set(row.Field(c.rowIndex))
+	}
+}
@@ -0,0 +1,108 @@
+package orm
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+type joinUser struct {
+	Id   int64
+	Name string
+}
+
+type joinUserOptional struct {
+	Id   *int64
+	Name *string
+}
+
+type joinPost struct {
+	Id     int64
+	UserId int64
+	Title  string
+}
+
+type joinPostOptional struct {
+	Id     *int64
+	UserId *int64
+	Title  *string
+}
+
+func TestJoin2(t *testing.T) {
+	userTable := table.New("users")
+	userID := userTable.Int64("id")
+	userName := userTable.String("name")
+	postTable := table.New("posts")
+	postTable.Int64("id")
+	postUserID := postTable.Int64("user_id")
+	postTable.String("title")
+
+	var gotSQL string
+	var gotArgs []interface{}
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			gotSQL = sql
+			gotArgs = args
+			// simulate an engine scanning rows by column name
+			rows := reflect.ValueOf(result).Elem()
+			rowType := rows.Type().Elem().Elem()
+			values := map[string]interface{}{
+				"first__id":       int64(1),
+				"first__name":     "Alice",
+				"second__id":      int64(10),
+				"second__user_id": int64(1),
+				"second__title":   "Hello",
+			}
+			row := reflect.New(rowType)
+			for i := 0; i < rowType.NumField(); i++ {
+				row.Elem().Field(i).Set(reflect.ValueOf(values[ColumnName(rowType.Field(i))]))
+			}
+			rows.Set(reflect.Append(rows, row))
+			return nil
+		},
+	}
+	users := &ORM[joinUser, joinUserOptional]{table: userTable, engine: mockEngine}
+	posts := &ORM[joinPost, joinPostOptional]{table: postTable, engine: mockEngine}
+
+	pairs, err := Join2(users, posts).
+		On(postUserID.EqField(userID)).
+		Where(userName.Eq("Alice")).
+		Limit(10).
+		Query(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedSQL := "SELECT `users`.`id` AS `first__id`, `users`.`name` AS `first__name`, `posts`.`id` AS `second__id`, `posts`.`user_id` AS `second__user_id`, `posts`.`title` AS `second__title` FROM `users` JOIN `posts` ON `posts`.`user_id` = `users`.`id` WHERE `users`.`name` = ? LIMIT 10"
+	if gotSQL != expectedSQL {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expectedSQL, gotSQL)
+	}
+	if len(gotArgs) != 1 || gotArgs[0] != "Alice" {
+		t.Errorf("Expected args [Alice], got %v", gotArgs)
+	}
+	if len(pairs) != 1 {
+		t.Fatalf("Expected 1 pair, got %d", len(pairs))
+	}
+	if *pairs[0].First != (joinUser{Id: 1, Name: "Alice"}) {
+		t.Errorf("Unexpected first: %+v", pairs[0].First)
+	}
+	if *pairs[0].Second != (joinPost{Id: 10, UserId: 1, Title: "Hello"}) {
+		t.Errorf("Unexpected second: %+v", pairs[0].Second)
+	}
+}
+
+func TestJoin2_RequiresOn(t *testing.T) {
+	userTable := table.New("users")
+	userTable.Int64("id")
+	postTable := table.New("posts")
+	postTable.Int64("id")
+	users := &ORM[joinUser, joinUserOptional]{table: userTable}
+	posts := &ORM[joinPost, joinPostOptional]{table: postTable}
+
+	_, _, err := Join2(users, posts).SQL()
+	if err == nil {
+		t.Errorf("Expected error without On condition")
+	}
+}
@@ -0,0 +1,180 @@
+package orm
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xhd2015/arc-orm/field"
+)
+
+// csvTimeLayout is the layout of times written by WriteCSV
+const csvTimeLayout = "2006-01-02 15:04:05.999999"
+
+// WriteCSV writes the models as CSV rows in the column order of the
+// table, in the format read by the statement of LoadDataSQL.
+// Values follow the rules of Insert, columns Insert would skip, like
+// a zero auto-increment id or a nil pointer, are written as NULL.
+// Strings are always quoted, so the string "NULL" is not a NULL, and
+// times are written in UTC, like the default loc of the MySQL driver.
+// Keys generated like Insert, by WithIDGenerator or for auto-generated
+// UUIDs, are reset if writing fails.
+func (o *ORM[T, P]) WriteCSV(w io.Writer, models []*T) error {
+	generated := make([]generatedKeys, len(models))
+	err := o.writeCSV(w, models, generated)
+	if err != nil {
+		o.resetGenerated(models, generated)
+	}
+	return err
+}
+
+// writeCSV writes the models like WriteCSV, recording
+// the keys generated for the models into generated
+func (o *ORM[T, P]) writeCSV(w io.Writer, models []*T, generated []generatedKeys) error {
+	columns := o.table.Fields()
+	bw := bufio.NewWriter(w)
+	values := make(map[string]interface{}, len(columns))
+	for i, model := range models {
+		if model == nil {
+			return fmt.Errorf("model %d cannot be nil", i)
+		}
+		generated[i] = o.generateKeys(model)
+		row, err := o.insertRow(model)
+		if err != nil {
+			return fmt.Errorf("model %d: %w", i, err)
+		}
+		for k := range values {
+			delete(values, k)
+		}
+		for j, f := range row.fields {
+			exprSQL, params, err := row.values[j].ToSQL()
+			if err != nil {
+				return fmt.Errorf("model %d field %s: %w", i, f.Name(), err)
+			}
+			if exprSQL != "?" || len(params) != 1 {
+				return fmt.Errorf("model %d field %s: unsupported value %s", i, f.Name(), exprSQL)
+			}
+			values[f.Name()] = params[0]
+		}
+		for j, column := range columns {
+			if j > 0 {
+				bw.WriteByte(',')
+			}
+			value, ok := values[column.Name()]
+			if !ok {
+				bw.WriteString("NULL")
+				continue
+			}
+			if err := writeCSVValue(bw, value); err != nil {
+				return fmt.Errorf("model %d field %s: %w", i, column.Name(), err)
+			}
+		}
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// resetGenerated resets the keys generated for the models
+func (o *ORM[T, P]) resetGenerated(models []*T, generated []generatedKeys) {
+	for i, keys := range generated {
+		if models[i] != nil {
+			o.resetKeys(models[i], keys)
+		}
+	}
+}
+
+func writeCSVValue(w *bufio.Writer, value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		w.WriteByte('"')
+		w.WriteString(strings.ReplaceAll(v, `"`, `""`))
+		w.WriteByte('"')
+	case int64:
+		w.WriteString(strconv.FormatInt(v, 10))
+	case int32:
+		w.WriteString(strconv.FormatInt(int64(v), 10))
+	case float64:
+		w.WriteString(strconv.FormatFloat(v, 'g', -1, 64))
+	case bool:
+		if v {
+			w.WriteByte('1')
+		} else {
+			w.WriteByte('0')
+		}
+	case time.Time:
+		w.WriteByte('"')
+		w.WriteString(v.UTC().Format(csvTimeLayout))
+		w.WriteByte('"')
+	default:
+		return fmt.Errorf("unsupported value %T", value)
+	}
+	return nil
+}
+
+// LoadDataSQL returns the LOAD DATA LOCAL INFILE statement
+// loading the CSV file written by WriteCSV into the table
+func (o *ORM[T, P]) LoadDataSQL(path string) string {
+	var b strings.Builder
+	b.WriteString("LOAD DATA LOCAL INFILE '")
+	b.WriteString(strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(path))
+	b.WriteString("' INTO TABLE ")
+	b.WriteString(field.QuoteTable(o.physicalTable()))
+	b.WriteString(" CHARACTER SET utf8mb4 FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' ESCAPED BY '' LINES TERMINATED BY '\\n' (")
+	for i, column := range o.table.Fields() {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("`")
+		b.WriteString(column.Name())
+		b.WriteString("`")
+	}
+	b.WriteString(")")
+	return b.String()
+}
+
+// LoadData imports the models with LOAD DATA LOCAL INFILE from a
+// temporary CSV file written by WriteCSV, for imports where even
+// batched inserts by InsertMany are too slow.
+// The MySQL driver must allow the file, e.g. by allowAllFiles=true
+// in the DSN, and the server by local_infile=ON.
+// Auto-increment ids are not set on the models, like InsertMany, ids
+// generated by WithIDGenerator and UUIDs are, and are reset if the
+// import fails.
+func (o *ORM[T, P]) LoadData(ctx context.Context, models []*T) (err error) {
+	if len(models) == 0 {
+		return nil
+	}
+	generated := make([]generatedKeys, len(models))
+	defer func() {
+		if err != nil {
+			o.resetGenerated(models, generated)
+		}
+	}()
+	file, err := os.CreateTemp("", "arc-orm-*.csv")
+	if err != nil {
+		return fmt.Errorf("failed to create CSV file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	err = o.writeCSV(file, models, generated)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write CSV file: %w", err)
+	}
+
+	if err := o.getEngine(ctx).Exec(ctx, o.LoadDataSQL(file.Name()), nil); err != nil {
+		return fmt.Errorf("failed to execute LoadData: %w", err)
+	}
+	var keys []interface{}
+	for _, model := range models {
+		keys = append(keys, o.modelKeys(model, 0)...)
+	}
+	o.emitWrite(WriteInsert, keys)
+	return nil
+}
@@ -0,0 +1,124 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/engine"
+)
+
+// fileEngine reads the file loaded by LOAD DATA statements
+type fileEngine struct {
+	MockEngine
+	content string
+	err     error
+}
+
+func (m *fileEngine) GetEngine() engine.Engine {
+	return m
+}
+
+func (m *fileEngine) Exec(ctx context.Context, sql string, args []interface{}) error {
+	path := strings.TrimPrefix(sql, "LOAD DATA LOCAL INFILE '")
+	path = path[:strings.Index(path, "'")]
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	m.content = string(content)
+	if m.err != nil {
+		return m.err
+	}
+	return m.MockEngine.Exec(ctx, sql, args)
+}
+
+func TestWriteCSV(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.FixedZone("UTC+8", 8*3600))
+	orm := newInsertOrUpdateTestORM(&MockEngine{}, now)
+
+	var b strings.Builder
+	err := orm.WriteCSV(&b, []*TestModelWithTime{
+		{Name: "Alice", Age: 30},
+		{Id: 7, Name: "NULL", Age: 25},
+		{Name: "say \"hi\",\nbye"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "NULL,\"Alice\",30,\"2024-01-01 19:04:05\",\"2024-01-01 19:04:05\"\n" +
+		"7,\"NULL\",25,\"2024-01-01 19:04:05\",\"2024-01-01 19:04:05\"\n" +
+		"NULL,\"say \"\"hi\"\",\nbye\",0,\"2024-01-01 19:04:05\",\"2024-01-01 19:04:05\"\n"
+	if b.String() != expected {
+		t.Errorf("Expected CSV:\n%s\ngot:\n%s", expected, b.String())
+	}
+}
+
+func TestLoadData(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	eng := &fileEngine{}
+	orm := newInsertOrUpdateTestORM(&MockEngine{}, now)
+	orm.engine = eng
+
+	err := orm.LoadData(context.Background(), []*TestModelWithTime{{Name: "Alice", Age: 30}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(eng.ExecCalls) != 1 {
+		t.Fatalf("Expected 1 Exec call, got %d", len(eng.ExecCalls))
+	}
+	sql := eng.ExecCalls[0].SQL
+	path := strings.TrimPrefix(sql, "LOAD DATA LOCAL INFILE '")
+	path = path[:strings.Index(path, "'")]
+	if expected := orm.LoadDataSQL(path); sql != expected {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expected, sql)
+	}
+	if expected := "NULL,\"Alice\",30,\"2024-01-02 03:04:05\",\"2024-01-02 03:04:05\"\n"; eng.content != expected {
+		t.Errorf("Expected file content:\n%s\ngot:\n%s", expected, eng.content)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected the CSV file removed, got %v", err)
+	}
+
+	expected := "LOAD DATA LOCAL INFILE '/tmp/it\\'s.csv' INTO TABLE `tasks` CHARACTER SET utf8mb4" +
+		" FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' ESCAPED BY '' LINES TERMINATED BY '\\n'" +
+		" (`id`, `name`, `age`, `create_time`, `update_time`)"
+	if got := orm.LoadDataSQL("/tmp/it's.csv"); got != expected {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+// TestLoadData_Failed tests that generated ids are reset when the import fails
+func TestLoadData_Failed(t *testing.T) {
+	eng := &fileEngine{err: errors.New("local_infile disabled")}
+	nextID := int64(1000)
+	orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](eng, newTimeTestTable(), WithIDGenerator(func() int64 {
+		nextID++
+		return nextID
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	models := []*TestModelWithTime{{Name: "Alice"}, {Id: 7, Name: "Bob"}}
+	if err := orm.LoadData(context.Background(), models); err == nil {
+		t.Fatal("Expected LoadData to fail")
+	}
+	if !strings.HasPrefix(eng.content, "1001,") {
+		t.Errorf("Expected the generated id written, got %q", eng.content)
+	}
+	if models[0].Id != 0 || models[1].Id != 7 {
+		t.Errorf("Expected only the generated id reset, got %d and %d", models[0].Id, models[1].Id)
+	}
+
+	var b strings.Builder
+	if err := orm.WriteCSV(&b, []*TestModelWithTime{models[0], nil}); err == nil {
+		t.Fatal("Expected WriteCSV to fail on a nil model")
+	}
+	if models[0].Id != 0 {
+		t.Errorf("Expected the generated id reset after a failed WriteCSV, got %d", models[0].Id)
+	}
+}
@@ -0,0 +1,114 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sql"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// ManyToManyRelation relates the records of two ORMs through a pivot
+// table holding pairs of their primary keys, see ManyToMany
+type ManyToManyRelation[L any, LP any, R any, RP any] struct {
+	left     *ORM[L, LP]
+	right    *ORM[R, RP]
+	pivot    table.Table
+	leftKey  field.Int64Field
+	rightKey field.Int64Field
+}
+
+// ManyToMany relates the records of left and right through the pivot
+// table, whose leftKey and rightKey refer to their primary keys, e.g.
+// the roles of users through user_roles:
+//
+//	var Roles = orm.ManyToMany(user.ORM, role.ORM, userrole.Table, userrole.UserID, userrole.RoleID)
+//
+//	roles, err := Roles.ListRelated(ctx, userID)
+//	err = Roles.Attach(ctx, userID, adminRoleID)
+func ManyToMany[L any, LP any, R any, RP any](left *ORM[L, LP], right *ORM[R, RP], pivot table.Table, leftKey field.Int64Field, rightKey field.Int64Field) *ManyToManyRelation[L, LP, R, RP] {
+	return &ManyToManyRelation[L, LP, R, RP]{
+		left:     left,
+		right:    right,
+		pivot:    pivot,
+		leftKey:  leftKey,
+		rightKey: rightKey,
+	}
+}
+
+// ListRelated returns the right records related to the left record,
+// joining the pivot table, prefixed like the right ORM
+func (m *ManyToManyRelation[L, LP, R, RP]) ListRelated(ctx context.Context, leftID int64) ([]*R, error) {
+	rightID, err := m.right.idField()
+	if err != nil {
+		return nil, err
+	}
+	return m.right.SelectAll().
+		Join(m.pivot.Name(), m.rightKey.EqField(rightID)).
+		Where(m.leftKey.Eq(leftID)).
+		Query(ctx)
+}
+
+// Attach relates the right records to the left record, inserting the
+// missing pairs into the pivot table, prefixed like the left ORM.
+// Existing pairs are ignored, provided the pivot table has a unique
+// key on them.
+func (m *ManyToManyRelation[L, LP, R, RP]) Attach(ctx context.Context, leftID int64, rightIDs ...int64) error {
+	if leftID == 0 {
+		return errors.New("requires left id, got 0")
+	}
+	eng := m.left.getEngine(ctx)
+	for _, rightID := range rightIDs {
+		query, args, err := sql.InsertInto(m.left.prefixed(m.pivot.Name())).
+			Set(m.leftKey, sql.Int64(leftID)).
+			Set(m.rightKey, sql.Int64(rightID)).
+			Ignore().
+			SQL()
+		if err != nil {
+			return fmt.Errorf("failed to build insert SQL: %w", err)
+		}
+		if err := eng.Exec(ctx, query, args); err != nil {
+			return fmt.Errorf("failed to execute Attach: %w", err)
+		}
+	}
+	return nil
+}
+
+// Detach removes the relations of the right records to the left
+// record from the pivot table, prefixed and guarded by safe mode like
+// the left ORM, doing nothing without right ids.
+// Very large id sets are removed in chunks.
+func (m *ManyToManyRelation[L, LP, R, RP]) Detach(ctx context.Context, leftID int64, rightIDs ...int64) error {
+	if leftID == 0 {
+		return errors.New("requires left id, got 0")
+	}
+	for start := 0; start < len(rightIDs); start += idsChunkSize {
+		end := start + idsChunkSize
+		if end > len(rightIDs) {
+			end = len(rightIDs)
+		}
+		query, args, err := m.pivotDelete().
+			Where(m.leftKey.Eq(leftID), m.rightKey.In(rightIDs[start:end]...)).
+			SQL()
+		if err != nil {
+			return fmt.Errorf("failed to build delete SQL: %w", err)
+		}
+		if err := m.left.getEngine(ctx).Exec(ctx, query, args); err != nil {
+			return fmt.Errorf("failed to execute Detach: %w", err)
+		}
+	}
+	return nil
+}
+
+// pivotDelete creates a DELETE from the pivot table, aliased by its
+// logical name under a table prefix so conditions on its keys keep working
+func (m *ManyToManyRelation[L, LP, R, RP]) pivotDelete() *sql.DeleteBuilder {
+	physical, alias := m.left.joinTable(m.pivot.Name())
+	builder := sql.DeleteFrom(physical).As(alias)
+	if m.left.opts.safeMode {
+		builder.RequireWhere()
+	}
+	return builder
+}
@@ -0,0 +1,124 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/sqltest"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+func TestManyToMany(t *testing.T) {
+	userTable := table.New("users")
+	userTable.Int64("id")
+	userTable.String("name")
+	roleTable := table.New("roles")
+	roleTable.Int64("id")
+	roleTable.String("name")
+	userRoles := table.New("user_roles")
+	userID := userRoles.Int64("user_id")
+	roleID := userRoles.Int64("role_id")
+
+	rec := engine.Recorder(nil)
+	users := &ORM[joinUser, joinUserOptional]{table: userTable, engine: rec}
+	roles := &ORM[joinUser, joinUserOptional]{table: roleTable, engine: rec}
+	rel := ManyToMany(users, roles, userRoles, userID, roleID)
+
+	ctx := context.Background()
+	if _, err := rel.ListRelated(ctx, 1); err != nil {
+		t.Fatalf("ListRelated: %v", err)
+	}
+	if err := rel.Attach(ctx, 1, 10, 11); err != nil {
+		t.Fatalf("Attach: %v", err)
+	}
+	if err := rel.Detach(ctx, 1, 10, 11); err != nil {
+		t.Fatalf("Detach: %v", err)
+	}
+	if err := rel.Detach(ctx, 1); err != nil {
+		t.Fatalf("Detach: %v", err)
+	}
+	if err := rel.Attach(ctx, 0, 10); err == nil {
+		t.Errorf("Expected error attaching to a zero id")
+	}
+
+	sqltest.AssertCount(t, rec, 4)
+	sqltest.AssertExecuted(t, rec, "SELECT `roles`.`id`, `roles`.`name` FROM `roles` JOIN `user_roles` ON `user_roles`.`role_id` = `roles`.`id` WHERE `user_roles`.`user_id` = ?", 1)
+	sqltest.AssertExecuted(t, rec, "INSERT IGNORE INTO `user_roles` SET `user_id`=?, `role_id`=?", 1, 10)
+	sqltest.AssertExecuted(t, rec, "INSERT IGNORE INTO `user_roles` SET `user_id`=?, `role_id`=?", 1, 11)
+	sqltest.AssertExecuted(t, rec, "DELETE FROM `user_roles` WHERE `user_roles`.`user_id` = ? AND `user_roles`.`role_id` IN (?, ?)", 1, 10, 11)
+
+	rec.Reset()
+	if err := rel.Detach(ctx, 1, make([]int64, idsChunkSize+1)...); err != nil {
+		t.Fatalf("Detach: %v", err)
+	}
+	sqltest.AssertCount(t, rec, 2)
+}
+
+// TestManyToMany_Prefix tests that the pivot table is prefixed like the ORMs
+func TestManyToMany_Prefix(t *testing.T) {
+	userTable := table.New("users")
+	userTable.Int64("id")
+	userTable.String("name")
+	roleTable := table.New("roles")
+	roleTable.Int64("id")
+	roleTable.String("name")
+	userRoles := table.New("user_roles")
+	userID := userRoles.Int64("user_id")
+	roleID := userRoles.Int64("role_id")
+
+	rec := engine.Recorder(nil)
+	users, err := bind[joinUser, joinUserOptional](rec, userTable, WithTablePrefix("app_"), WithSafeMode())
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	roles, err := bind[joinUser, joinUserOptional](rec, roleTable, WithTablePrefix("app_"), WithSafeMode())
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	rel := ManyToMany(users, roles, userRoles, userID, roleID)
+
+	ctx := context.Background()
+	if _, err := rel.ListRelated(ctx, 1); err != nil {
+		t.Fatalf("ListRelated: %v", err)
+	}
+	if err := rel.Attach(ctx, 1, 10); err != nil {
+		t.Fatalf("Attach: %v", err)
+	}
+	if err := rel.Detach(ctx, 1, 10); err != nil {
+		t.Fatalf("Detach: %v", err)
+	}
+
+	sqltest.AssertCount(t, rec, 3)
+	sqltest.AssertExecuted(t, rec, "SELECT `roles`.`id`, `roles`.`name` FROM `app_roles` AS `roles` JOIN `app_user_roles` AS `user_roles` ON `user_roles`.`role_id` = `roles`.`id` WHERE `user_roles`.`user_id` = ?", 1)
+	sqltest.AssertExecuted(t, rec, "INSERT IGNORE INTO `app_user_roles` SET `user_id`=?, `role_id`=?", 1, 10)
+	sqltest.AssertExecuted(t, rec, "DELETE `user_roles` FROM `app_user_roles` AS `user_roles` WHERE `user_roles`.`user_id` = ? AND `user_roles`.`role_id` IN (?)", 1, 10)
+}
+
+// TestManyToMany_Schema tests pivot tables of another database
+func TestManyToMany_Schema(t *testing.T) {
+	userTable := table.New("users")
+	userTable.Int64("id")
+	userTable.String("name")
+	roleTable := table.New("roles")
+	roleTable.Int64("id")
+	roleTable.String("name")
+	userRoles := table.NewIn("auth", "user_roles")
+	userID := userRoles.Int64("user_id")
+	roleID := userRoles.Int64("role_id")
+
+	rec := engine.Recorder(nil)
+	users := &ORM[joinUser, joinUserOptional]{table: userTable, engine: rec}
+	roles := &ORM[joinUser, joinUserOptional]{table: roleTable, engine: rec}
+	rel := ManyToMany(users, roles, userRoles, userID, roleID)
+
+	ctx := context.Background()
+	if err := rel.Attach(ctx, 1, 10); err != nil {
+		t.Fatalf("Attach: %v", err)
+	}
+	if err := rel.Detach(ctx, 1, 10); err != nil {
+		t.Fatalf("Detach: %v", err)
+	}
+	sqltest.AssertExecuted(t, rec, "INSERT IGNORE INTO `auth`.`user_roles` SET `user_id`=?, `role_id`=?", 1, 10)
+	sqltest.AssertExecuted(t, rec, "DELETE FROM `auth`.`user_roles` WHERE `auth`.`user_roles`.`user_id` = ? AND `auth`.`user_roles`.`role_id` IN (?)", 1, 10)
+}
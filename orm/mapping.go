@@ -0,0 +1,104 @@
+package orm
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/xhd2015/arc-orm/orm/column"
+	"github.com/xhd2015/arc-orm/sql"
+	"github.com/xhd2015/arc-orm/sql/expr"
+)
+
+// ColumnMapper is implemented by models with generated column
+// mapping (arc-orm gen --mapping), Insert uses it instead of
+// reflecting over the model
+type ColumnMapper interface {
+	// Columns returns the columns of the model fields, in the order of Values
+	Columns() []string
+	// Values returns the values of the model fields, in the order of Columns
+	Values() []interface{}
+}
+
+// RowScanner is implemented by models with generated column
+// mapping, engines use it to scan rows without reflection
+type RowScanner = column.RowScanner
+
+// mappedInsertRow returns the columns to insert from the generated
+// column mapping, following the same rules as the reflection path
+func (o *ORM[T, P]) mappedInsertRow(mapper ColumnMapper) (*insertRow, error) {
+	columns := mapper.Columns()
+	values := mapper.Values()
+	if len(columns) != len(values) {
+		return nil, fmt.Errorf("column mapping of %s is inconsistent: %d columns, %d values", o.table.Name(), len(columns), len(values))
+	}
+
+	d := o.describe()
+	row := &insertRow{}
+	now := o.now()
+	var checker constraintChecker
+	for i, column := range columns {
+		tableField, ok := d.tableFields[column]
+		if !ok {
+			return nil, fmt.Errorf("field %s not found in table %s", column, o.table.Name())
+		}
+
+		value := values[i]
+		if def, ok := o.insertDefault(column, tableField, reflect.ValueOf(value)); ok {
+			value = def.Interface()
+		}
+		// skip nil pointers (let DB use NULL default)
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Ptr {
+			if rv.IsNil() {
+				checker.check(tableField, nil)
+				continue
+			}
+			value = rv.Elem().Interface()
+		}
+
+		if timeValue, ok := value.(time.Time); ok {
+			if (column == d.createTimeColumn || column == d.updateTimeColumn) && timeValue.IsZero() {
+				timeValue = now
+			}
+			if timeValue.IsZero() {
+				continue
+			}
+			value = timeValue
+		}
+
+		sqlValue, isZero := valueToSQL(value)
+		if column == d.pkColumn && isZero {
+			continue
+		}
+		if sqlValue == nil {
+			return nil, fmt.Errorf("failed to convert column %s to SQL value: %T", column, value)
+		}
+		checker.check(tableField, value)
+		row.set(tableField, sqlValue)
+	}
+	if err := checker.err(o.table.Name()); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// valueToSQL is toSQLValue for common types without reflection
+func valueToSQL(value interface{}) (expr.Expr, bool) {
+	switch v := value.(type) {
+	case string:
+		return sql.String(v), false
+	case int64:
+		return sql.Int64(v), v == 0
+	case int:
+		return sql.Int64(int64(v)), v == 0
+	case int32:
+		return sql.Int64(int64(v)), v == 0
+	case float64:
+		return sql.Float64(v), v == 0
+	case bool:
+		return sql.Bool(v), false
+	case time.Time:
+		return sql.Time(v), false
+	}
+	return toSQLValue(reflect.ValueOf(value))
+}
@@ -0,0 +1,76 @@
+package orm
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// mappedModel implements ColumnMapper like generated code, with
+// the Name column under a name reflection would not resolve
+type mappedModel struct {
+	Id         int64
+	Label      *string
+	CreateTime time.Time
+	mapped     int
+}
+
+func (m *mappedModel) Columns() []string {
+	m.mapped++
+	return []string{"id", "name", "create_time"}
+}
+
+func (m *mappedModel) Values() []interface{} {
+	return []interface{}{m.Id, m.Label, m.CreateTime}
+}
+
+type mappedModelOptional struct {
+	Id         *int64
+	Label      *string `orm:"column:name"`
+	CreateTime *time.Time
+}
+
+func TestInsert_ColumnMapper(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	testTable := table.New("users")
+	testTable.Int64("id")
+	testTable.String("name")
+	testTable.Time("create_time")
+
+	mockEngine := &MockEngine{}
+	orm := &ORM[mappedModel, mappedModelOptional]{
+		table:  testTable,
+		engine: mockEngine,
+		opts:   options{clock: func() time.Time { return now }},
+	}
+
+	label := "Alice"
+	model := &mappedModel{Label: &label}
+	_, err := orm.Insert(context.Background(), model)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if model.mapped != 1 {
+		t.Errorf("Expected Columns called once, got %d", model.mapped)
+	}
+	call := mockEngine.ExecInsertCalls[0]
+	expectedSQL := "INSERT INTO `users` SET `name`=?, `create_time`=?"
+	if call.SQL != expectedSQL {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expectedSQL, call.SQL)
+	}
+	if len(call.Args) != 2 || call.Args[0] != "Alice" || call.Args[1] != now {
+		t.Errorf("Expected args [Alice %v], got %v", now, call.Args)
+	}
+
+	// nil pointers are skipped like the reflection path
+	_, err = orm.Insert(context.Background(), &mappedModel{Id: 7, CreateTime: now})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedSQL = "INSERT INTO `users` SET `id`=?, `create_time`=?"
+	if got := mockEngine.ExecInsertCalls[1].SQL; got != expectedSQL {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expectedSQL, got)
+	}
+}
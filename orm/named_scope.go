@@ -0,0 +1,70 @@
+package orm
+
+import (
+	"fmt"
+	"sync"
+)
+
+// SelectScope is a reusable combination of conditions, ordering
+// and the like applied to a select builder, see ORM.Scope
+type SelectScope[T any, P any] func(b *ORMSelectBuilder[T, P])
+
+// namedScopes holds the scopes registered on an ORM, shared by its copies
+type namedScopes[T any, P any] struct {
+	mu     sync.RWMutex
+	scopes map[string]SelectScope[T, P]
+}
+
+// Scope registers a named scope applied by ORMSelectBuilder.Scoped,
+// so common filter and ordering combinations are defined once.
+// It panics if the name is already registered.
+// Example:
+//
+//	var ORM = orm.Bind[User, UserOptional](engine.Engine, Table).
+//	    Scope("active", func(b *orm.ORMSelectBuilder[User, UserOptional]) {
+//	        b.Where(Status.Eq("active")).OrderBy(CreateTime.Desc())
+//	    })
+//
+//	users, err := ORM.SelectAll().Scoped("active").Limit(10).Query(ctx)
+func (o *ORM[T, P]) Scope(name string, scope SelectScope[T, P]) *ORM[T, P] {
+	if o.scopes == nil {
+		o.scopes = &namedScopes[T, P]{}
+	}
+	o.scopes.mu.Lock()
+	defer o.scopes.mu.Unlock()
+	if _, ok := o.scopes.scopes[name]; ok {
+		panic(fmt.Errorf("scope %s already registered on %s", name, o.table.Name()))
+	}
+	if o.scopes.scopes == nil {
+		o.scopes.scopes = make(map[string]SelectScope[T, P])
+	}
+	o.scopes.scopes[name] = scope
+	return o
+}
+
+// lookupScope returns the scope registered with the name
+func (o *ORM[T, P]) lookupScope(name string) (SelectScope[T, P], bool) {
+	if o.scopes == nil {
+		return nil, false
+	}
+	o.scopes.mu.RLock()
+	defer o.scopes.mu.RUnlock()
+	scope, ok := o.scopes.scopes[name]
+	return scope, ok
+}
+
+// Scoped applies the named scopes registered by ORM.Scope, in order.
+// Unknown names make the query fail.
+func (c *ORMSelectBuilder[T, P]) Scoped(names ...string) *ORMSelectBuilder[T, P] {
+	for _, name := range names {
+		scope, ok := c.orm.lookupScope(name)
+		if !ok {
+			if c.err == nil {
+				c.err = fmt.Errorf("scope %s not registered on %s", name, c.orm.table.Name())
+			}
+			continue
+		}
+		scope(c)
+	}
+	return c
+}
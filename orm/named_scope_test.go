@@ -0,0 +1,48 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// TestScope tests that named scopes are registered once and applied by Scoped
+func TestScope(t *testing.T) {
+	posts := table.New("posts")
+	id := posts.Int64("id")
+	tenantID := posts.Int64("tenant_id")
+	title := posts.String("title")
+
+	var queries []string
+	mockEngine := &MockQueryEngine{QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+		queries = append(queries, sql)
+		return nil
+	}}
+	orm, err := bind[tenantPost, tenantPostOptional](mockEngine, posts)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	orm.Scope("tenant1", func(b *ORMSelectBuilder[tenantPost, tenantPostOptional]) {
+		b.Where(tenantID.Eq(1))
+	}).Scope("latest", func(b *ORMSelectBuilder[tenantPost, tenantPostOptional]) {
+		b.OrderBy(id.Desc())
+	})
+
+	if _, err := orm.SelectAll().Where(title.Like("%go%")).Scoped("tenant1", "latest").Limit(10).Query(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "SELECT `posts`.`id`, `posts`.`tenant_id`, `posts`.`title` FROM `posts` WHERE `posts`.`title` LIKE ? AND `posts`.`tenant_id` = ? ORDER BY `posts`.`id` DESC LIMIT 10"
+	assertStrings(t, "queries", queries, []string{expected})
+
+	if _, err := orm.SelectAll().Scoped("missing").Query(context.Background()); err == nil {
+		t.Errorf("Expected error for unknown scope")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Expected panic for duplicate scope")
+		}
+	}()
+	orm.Scope("latest", func(b *ORMSelectBuilder[tenantPost, tenantPostOptional]) {})
+}
@@ -0,0 +1,95 @@
+package orm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/orm/column"
+	"github.com/xhd2015/less-gen/strcase"
+)
+
+// NamingStrategy maps the fields of models to table columns, and
+// decides which field names Validate accepts. The zero value is the
+// default strategy: fields must be strict CamelCase like UserId,
+// mapped to columns by their snake_case, e.g. user_id.
+type NamingStrategy struct {
+	// Initialisms spells common initialisms like ID, URL and HTTP
+	// uppercase as Go does, e.g. UserID and UserIDs map to user_id
+	// and user_ids. Validate then requires UserID instead of UserId.
+	Initialisms bool
+	// NoStrict disables the field naming check of Validate,
+	// columns are still derived as configured
+	NoStrict bool
+}
+
+// WithNamingStrategy sets the naming strategy of model fields, used to
+// map fields to columns by Validate, queries, Insert and the updates,
+// defaults to strict CamelCase. Generate models with the same strategy
+// by the naming section of arc-orm.yaml, see the README.
+func WithNamingStrategy(s NamingStrategy) Option {
+	return func(opts *options) {
+		opts.naming = s
+	}
+}
+
+// Naming returns the naming strategy of the ORM, see WithNamingStrategy
+func (o *ORM[T, P]) Naming() NamingStrategy {
+	return o.opts.naming
+}
+
+// ColumnName returns the column a model field maps to, which is
+// the `orm:"column:name"` tag if present, otherwise derived from
+// the field name
+func (s NamingStrategy) ColumnName(f reflect.StructField) string {
+	return column.Name(f, s.Initialisms)
+}
+
+// FieldName returns the name of the model field of a column,
+// e.g. user_id -> UserId, or UserID with Initialisms
+func (s NamingStrategy) FieldName(col string) string {
+	if !s.Initialisms {
+		return strcase.SnakeToCamel(col)
+	}
+	words := strings.Split(col, "_")
+	for i, word := range words {
+		upper := strings.ToUpper(word)
+		switch {
+		case column.IsInitialism(upper):
+			words[i] = upper
+		case strings.HasSuffix(word, "s") && column.IsInitialism(upper[:len(upper)-1]):
+			words[i] = upper[:len(upper)-1] + "s"
+		default:
+			words[i] = strcase.Capitalize(word)
+		}
+	}
+	return strings.Join(words, "")
+}
+
+// StrictFieldName returns the spelling of a field name Validate expects,
+// e.g. SomeID -> SomeId, or SomeId -> SomeID with Initialisms.
+// The name is returned as is with NoStrict.
+func (s NamingStrategy) StrictFieldName(name string) string {
+	switch {
+	case s.NoStrict:
+		return name
+	case s.Initialisms:
+		return s.FieldName(s.ColumnName(reflect.StructField{Name: name}))
+	}
+	return toStrictCamelCase(name)
+}
+
+// validateFieldName checks a field name against the strategy
+func (s NamingStrategy) validateFieldName(name string) error {
+	if !s.Initialisms {
+		if s.NoStrict {
+			return nil
+		}
+		return validateFieldNaming(name)
+	}
+	if expected := s.StrictFieldName(name); expected != name {
+		return fmt.Errorf("%w: field '%s' must spell initialisms uppercase, use '%s' instead",
+			ErrInvalidFieldNaming, name, expected)
+	}
+	return nil
+}
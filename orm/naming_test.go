@@ -0,0 +1,124 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/sqltest"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+type initialismUser struct {
+	ID         int64
+	OwnerID    int64
+	AvatarURL  string
+	CreateTime time.Time
+	UpdateTime time.Time
+}
+
+type initialismUserOptional struct {
+	ID         *int64
+	OwnerID    *int64
+	AvatarURL  *string
+	CreateTime *time.Time
+	UpdateTime *time.Time
+}
+
+func newInitialismTable() table.Table {
+	users := table.New("users")
+	users.Int64("id")
+	users.Int64("owner_id")
+	users.String("avatar_url")
+	users.Time("create_time")
+	users.Time("update_time")
+	return users
+}
+
+func TestNamingStrategyColumnName(t *testing.T) {
+	tests := []struct {
+		name        string
+		column      string
+		initialisms string
+	}{
+		{"Id", "id", "id"},
+		{"UserId", "user_id", "user_id"},
+		{"UserID", "user_id", "user_id"},
+		{"UserIDs", "user_i_ds", "user_ids"},
+		{"HTTPSProxy", "https_proxy", "https_proxy"},
+		{"XMLHTTPRequest", "xmlhttp_request", "xml_http_request"},
+		{"APIKeyV2", "api_key_v2", "api_key_v2"},
+		{"Identity", "identity", "identity"},
+	}
+	for _, tt := range tests {
+		f := reflect.StructField{Name: tt.name}
+		if got := (NamingStrategy{}).ColumnName(f); got != tt.column {
+			t.Errorf("ColumnName(%q): expected %q, got %q", tt.name, tt.column, got)
+		}
+		if got := (NamingStrategy{Initialisms: true}).ColumnName(f); got != tt.initialisms {
+			t.Errorf("ColumnName(%q) with Initialisms: expected %q, got %q", tt.name, tt.initialisms, got)
+		}
+	}
+}
+
+func TestNamingStrategyFieldName(t *testing.T) {
+	tests := []struct {
+		column      string
+		name        string
+		initialisms string
+	}{
+		{"id", "Id", "ID"},
+		{"user_id", "UserId", "UserID"},
+		{"user_ids", "UserIds", "UserIDs"},
+		{"avatar_url", "AvatarUrl", "AvatarURL"},
+		{"status", "Status", "Status"},
+	}
+	for _, tt := range tests {
+		if got := (NamingStrategy{}).FieldName(tt.column); got != tt.name {
+			t.Errorf("FieldName(%q): expected %q, got %q", tt.column, tt.name, got)
+		}
+		if got := (NamingStrategy{Initialisms: true}).FieldName(tt.column); got != tt.initialisms {
+			t.Errorf("FieldName(%q) with Initialisms: expected %q, got %q", tt.column, tt.initialisms, got)
+		}
+	}
+}
+
+func TestWithNamingStrategy(t *testing.T) {
+	users := newInitialismTable()
+	if _, err := bind[initialismUser, initialismUserOptional](nil, users); !errors.Is(err, ErrInvalidFieldNaming) {
+		t.Fatalf("Expected ErrInvalidFieldNaming by default, got %v", err)
+	}
+	if _, err := bind[initialismUser, initialismUserOptional](nil, users, WithNamingStrategy(NamingStrategy{NoStrict: true})); err != nil {
+		t.Fatalf("Expected NoStrict to accept initialisms, got %v", err)
+	}
+	names := table.New("users")
+	names.Int64("id")
+	names.String("name")
+	_, err := bind[joinUser, joinUserOptional](nil, names, WithNamingStrategy(NamingStrategy{Initialisms: true}))
+	if !errors.Is(err, ErrInvalidFieldNaming) || !contains(err.Error(), "use 'ID' instead") {
+		t.Fatalf("Expected Initialisms to require ID, got %v", err)
+	}
+
+	rec := engine.Recorder(nil)
+	o, err := bind[initialismUser, initialismUserOptional](rec, users, WithNamingStrategy(NamingStrategy{Initialisms: true}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	ctx := context.Background()
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	if _, err := o.Insert(ctx, &initialismUser{OwnerID: 7, AvatarURL: "a.png", CreateTime: now, UpdateTime: now}); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	sqltest.AssertExecuted(t, rec, "INSERT INTO `users` SET `owner_id`=?, `avatar_url`=?, `create_time`=?, `update_time`=?", 7, "a.png", now, now)
+
+	url := "b.png"
+	if err := o.UpdateByID(ctx, 1, &initialismUserOptional{AvatarURL: &url}); err != nil {
+		t.Fatalf("UpdateByID: %v", err)
+	}
+	if stmts := rec.Find("UPDATE `users` SET `avatar_url`=?"); len(stmts) != 1 {
+		t.Errorf("Expected avatar_url updated, got %q", rec.SQLs())
+	}
+}
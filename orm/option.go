@@ -0,0 +1,157 @@
+package orm
+
+import (
+	"context"
+	"time"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/field"
+)
+
+// Option configures optional behaviors of an ORM instance created by Bind
+type Option func(opts *options)
+
+// options holds the optional settings of an ORM instance
+// the zero value is valid and means default behavior
+type options struct {
+	clock func() time.Time
+	// fillDefaults fills zero-valued fields with declared defaults on Insert
+	fillDefaults bool
+	// primaryKey overrides the 'id' primary key
+	primaryKey field.Field
+	// idGenerator generates the ids of inserted models
+	idGenerator func() int64
+	// comment returns the comment appended to executed statements
+	comment func(ctx context.Context) string
+	// defaultScope returns the conditions appended to every statement
+	defaultScope func(ctx context.Context) []field.Expr
+	// engineResolver returns the engine of the context, nil for the bound one
+	engineResolver func(ctx context.Context) engine.Engine
+	// tablePrefix is prepended to the names of the target tables
+	tablePrefix string
+	// sqlRewriter rewrites statements just before execution
+	sqlRewriter func(sql string, args []interface{}) (string, []interface{})
+	// safeMode refuses UPDATE and DELETE statements without WHERE condition
+	safeMode bool
+	// maxRows limits the rows of SELECTs without LIMIT
+	maxRows int
+	// naming maps model fields to columns
+	naming NamingStrategy
+}
+
+// WithClock sets the clock used to fill CreateTime and UpdateTime
+// on Insert and UpdateByID, defaults to time.Now.
+// This is useful for controlling timestamps in tests, or aligning
+// all timestamps to a single transaction-begin time.
+func WithClock(clock func() time.Time) Option {
+	return func(opts *options) {
+		opts.clock = clock
+	}
+}
+
+// WithInsertDefaults makes Insert fill zero-valued fields with the defaults
+// declared on the table fields via Default(), e.g. Table.Int64("status").Default(1).
+// The primary key, CreateTime and UpdateTime fields are never filled.
+func WithInsertDefaults() Option {
+	return func(opts *options) {
+		opts.fillDefaults = true
+	}
+}
+
+// WithPrimaryKey sets the primary key used by the *ByID and *ByKey
+// methods, defaults to the single-column primary key declared on the
+// table, or 'id'. Int64 keys work with GetByID and the other *ByID
+// methods, keys of other types like UUID strings with GetByKey,
+// UpdateByKey and DeleteByKey.
+func WithPrimaryKey(f field.Field) Option {
+	return func(opts *options) {
+		opts.primaryKey = f
+	}
+}
+
+// WithIDGenerator sets the generator of the ids of inserted models,
+// e.g. a snowflake generator. Insert sets a zero integer primary key of
+// the model from it and returns the generated id, instead of the id
+// reported by the database. Given ids are kept, and generated ids are
+// reset to zero on models not inserted, e.g. when the INSERT fails.
+func WithIDGenerator(gen func() int64) Option {
+	return func(opts *options) {
+		opts.idGenerator = gen
+	}
+}
+
+// WithEngineResolver sets the resolver of the engine executing the
+// statements of a context, e.g. a transaction or a replica stashed in
+// the context by a middleware. A nil engine falls back to the bound one.
+// It is not consulted by ORMs returned by WithEngine.
+func WithEngineResolver(resolve func(ctx context.Context) engine.Engine) Option {
+	return func(opts *options) {
+		opts.engineResolver = resolve
+	}
+}
+
+// WithTablePrefix sets the prefix of the table names statements target,
+// e.g. "app_" makes the users table render as `app_users`, so the same
+// code runs in environments using prefixed table names. Like WithTable,
+// the prefixed table is aliased by the logical name in SELECT, UPDATE
+// and DELETE statements. Names given to WithTable are prefixed too.
+func WithTablePrefix(prefix string) Option {
+	return func(opts *options) {
+		opts.tablePrefix = prefix
+	}
+}
+
+// WithSafeMode makes every UPDATE and DELETE built by the ORM fail
+// with sql.ErrFullTable if it has no WHERE condition, e.g. all its
+// conditions are sql.Optional with false, a guard against updating or
+// deleting the whole table by a typo. The conditions of WithDefaultScope
+// do not count. Use ORMUpdateBuilder.AllowFullTable for the statements
+// intended to update every row.
+func WithSafeMode() Option {
+	return func(opts *options) {
+		opts.safeMode = true
+	}
+}
+
+// WithMaxRows makes the SELECTs without LIMIT executed through the ORM
+// fetch at most n+1 rows, failing with ErrTooManyRows if more than n
+// rows match, so a query accidentally matching a whole table can not
+// load it into memory. Queries with a Limit, and QuerySQL, are not affected.
+func WithMaxRows(n int) Option {
+	return func(opts *options) {
+		opts.maxRows = n
+	}
+}
+
+// getEngine returns the engine executing the statements of ctx,
+// see WithEngineResolver
+func (o *ORM[T, P]) getEngine(ctx context.Context) engine.Engine {
+	if o.opts.engineResolver != nil {
+		if eng := o.opts.engineResolver(ctx); eng != nil {
+			return o.opts.wrapEngine(eng)
+		}
+	}
+	return o.engine.GetEngine()
+}
+
+// baseEngine returns the engine of ctx like getEngine, without the
+// statement hooks of WithComment and WithSQLRewriter
+func (o *ORM[T, P]) baseEngine(ctx context.Context) engine.Engine {
+	if o.opts.engineResolver != nil {
+		if eng := o.opts.engineResolver(ctx); eng != nil {
+			return eng
+		}
+	}
+	if f, ok := o.engine.(hookFactory); ok {
+		return f.factory.GetEngine()
+	}
+	return o.engine.GetEngine()
+}
+
+// now returns the current time according to the configured clock
+func (o *ORM[T, P]) now() time.Time {
+	if o.opts.clock != nil {
+		return o.opts.clock()
+	}
+	return time.Now()
+}
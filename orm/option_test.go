@@ -0,0 +1,604 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sql"
+	"github.com/xhd2015/arc-orm/sqltest"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+func newTimeTestTable() table.Table {
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name")
+	testTable.Int64("age")
+	testTable.Time("create_time")
+	testTable.Time("update_time")
+	return testTable
+}
+
+// TestWithClock_Insert tests that Insert fills time fields from the configured clock
+func TestWithClock_Insert(t *testing.T) {
+	mockEngine := &MockEngine{}
+	fixed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](mockEngine, newTimeTestTable(), WithClock(func() time.Time {
+		return fixed
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	_, err = orm.Insert(context.Background(), &TestModelWithTime{Name: "Charlie"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(mockEngine.ExecInsertCalls) != 1 {
+		t.Fatalf("Expected 1 ExecInsert call, got %d", len(mockEngine.ExecInsertCalls))
+	}
+	call := mockEngine.ExecInsertCalls[0]
+	expectedSQL := "INSERT INTO `test_table` SET `name`=?, `age`=?, `create_time`=?, `update_time`=?"
+	if call.SQL != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, call.SQL)
+	}
+	if len(call.Args) != 4 {
+		t.Fatalf("Expected 4 args, got %d", len(call.Args))
+	}
+	for i, arg := range call.Args[2:] {
+		if arg != fixed {
+			t.Errorf("Expected time arg %d to be %v, got %v", i, fixed, arg)
+		}
+	}
+}
+
+// TestWithClock_UpdateByID tests that UpdateByID fills update_time from the configured clock
+func TestWithClock_UpdateByID(t *testing.T) {
+	mockEngine := &MockEngine{}
+	fixed := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](mockEngine, newTimeTestTable(), WithClock(func() time.Time {
+		return fixed
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	name := "Updated Name"
+	err = orm.UpdateByID(context.Background(), 42, &TestModelWithTimeOptional{Name: &name})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(mockEngine.ExecCalls) != 1 {
+		t.Fatalf("Expected 1 Exec call, got %d", len(mockEngine.ExecCalls))
+	}
+	call := mockEngine.ExecCalls[0]
+	expectedSQL := "UPDATE `test_table` SET `name`=?, `update_time`=? WHERE `test_table`.`id` = ?"
+	if call.SQL != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, call.SQL)
+	}
+	if len(call.Args) != 3 || call.Args[1] != fixed {
+		t.Errorf("Expected update_time arg to be %v, got %v", fixed, call.Args)
+	}
+}
+
+type uuidUser struct {
+	UserUuid string
+	Name     string
+}
+
+type uuidUserOptional struct {
+	UserUuid *string
+	Name     *string
+}
+
+// TestWithPrimaryKey tests the *ByKey methods on a UUID-keyed table
+func TestWithPrimaryKey(t *testing.T) {
+	users := table.New("users")
+	userUUID := users.String("user_uuid")
+	users.String("name")
+
+	var querySQL string
+	var queryArgs []interface{}
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			querySQL, queryArgs = sql, args
+			*result.(*[]*uuidUser) = []*uuidUser{{UserUuid: "u-1", Name: "Alice"}}
+			return nil
+		},
+	}
+	orm, err := bind[uuidUser, uuidUserOptional](mockEngine, users, WithPrimaryKey(userUUID))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.Background()
+	user, err := orm.GetByKey(ctx, "u-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if user.Name != "Alice" {
+		t.Errorf("Expected Alice, got %s", user.Name)
+	}
+	expectedSQL := "SELECT `users`.`user_uuid`, `users`.`name` FROM `users` WHERE `users`.`user_uuid` = ? LIMIT 1"
+	if querySQL != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, querySQL)
+	}
+	if len(queryArgs) != 1 || queryArgs[0] != "u-1" {
+		t.Errorf("Expected args [u-1], got %v", queryArgs)
+	}
+
+	name := "Bob"
+	if err := orm.UpdateByKey(ctx, "u-1", &uuidUserOptional{Name: &name}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := orm.DeleteByKey(ctx, "u-1"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedExecs := []string{
+		"UPDATE `users` SET `name`=? WHERE `users`.`user_uuid` = ?",
+		"DELETE FROM `users` WHERE `users`.`user_uuid` = ?",
+	}
+	if len(mockEngine.ExecCalls) != len(expectedExecs) {
+		t.Fatalf("Expected %d Exec calls, got %d", len(expectedExecs), len(mockEngine.ExecCalls))
+	}
+	for i, expected := range expectedExecs {
+		if mockEngine.ExecCalls[i].SQL != expected {
+			t.Errorf("Expected SQL: %s, got: %s", expected, mockEngine.ExecCalls[i].SQL)
+		}
+	}
+
+	if _, err := orm.GetByID(ctx, 1); err == nil {
+		t.Error("Expected GetByID to fail on a string primary key")
+	}
+	if err := orm.DeleteByKey(ctx, ""); err == nil {
+		t.Error("Expected an error for an empty key")
+	}
+}
+
+// TestWithPrimaryKey_NotInTable tests that Bind rejects unknown primary keys
+func TestWithPrimaryKey_NotInTable(t *testing.T) {
+	other := table.New("others")
+	_, err := bind[TestModelWithTime, TestModelWithTimeOptional](&MockEngine{}, newTimeTestTable(), WithPrimaryKey(other.Int64("other_id")))
+	if err == nil {
+		t.Fatal("Expected an error for a primary key not in the table")
+	}
+}
+
+// TestWithIDGenerator tests that Insert uses and returns generated ids
+func TestWithIDGenerator(t *testing.T) {
+	mockEngine := &MockEngine{}
+	nextID := int64(1000)
+	orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](mockEngine, newTimeTestTable(), WithIDGenerator(func() int64 {
+		nextID++
+		return nextID
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	model := &TestModelWithTime{Name: "Charlie"}
+	id, err := orm.Insert(context.Background(), model)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if id != 1001 || model.Id != 1001 {
+		t.Errorf("Expected generated id 1001, got %d (model %d)", id, model.Id)
+	}
+	if len(mockEngine.ExecInsertCalls) != 0 || len(mockEngine.ExecCalls) != 1 {
+		t.Fatalf("Expected 1 Exec call, got %d Exec and %d ExecInsert calls", len(mockEngine.ExecCalls), len(mockEngine.ExecInsertCalls))
+	}
+	call := mockEngine.ExecCalls[0]
+	expectedSQL := "INSERT INTO `test_table` SET `id`=?, `name`=?, `age`=?, `create_time`=?, `update_time`=?"
+	if call.SQL != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, call.SQL)
+	}
+	if call.Args[0] != int64(1001) {
+		t.Errorf("Expected id arg 1001, got %v", call.Args[0])
+	}
+
+	// given ids are kept and the database id is returned
+	id, err = orm.Insert(context.Background(), &TestModelWithTime{Id: 7, Name: "Dave"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if id != 42 || nextID != 1001 {
+		t.Errorf("Expected database id 42 without generating, got %d (next %d)", id, nextID)
+	}
+}
+
+// TestWithIDGenerator_Failed tests that generated ids are reset on models not inserted
+func TestWithIDGenerator_Failed(t *testing.T) {
+	eng := &failingEngine{name: "Eve"}
+	nextID := int64(1000)
+	orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](eng, newTimeTestTable(), WithIDGenerator(func() int64 {
+		nextID++
+		return nextID
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	model := &TestModelWithTime{Name: "Eve"}
+	if _, err := orm.Insert(context.Background(), model); err == nil {
+		t.Fatal("Expected Insert to fail")
+	}
+	if model.Id != 0 {
+		t.Errorf("Expected the generated id reset after a failed Insert, got %d", model.Id)
+	}
+
+	models := []*TestModelWithTime{{Name: "Frank"}, {Name: "Eve"}}
+	if err := orm.InsertMany(context.Background(), models); err == nil {
+		t.Fatal("Expected InsertMany to fail")
+	}
+	if models[0].Id != 0 || models[1].Id != 0 {
+		t.Errorf("Expected the generated ids reset after a failed InsertMany, got %d and %d", models[0].Id, models[1].Id)
+	}
+
+	// the next attempt generates a new id
+	model.Name = "Grace"
+	id, err := orm.Insert(context.Background(), model)
+	if err != nil || id != 1004 || model.Id != 1004 {
+		t.Errorf("Expected generated id 1004, got %d (model %d): %v", id, model.Id, err)
+	}
+}
+
+type routeKey struct{}
+
+// TestWithComment tests that executed statements carry the comment of their context
+func TestWithComment(t *testing.T) {
+	mockEngine := &MockIgnoreEngine{affected: 1}
+	orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](mockEngine, newTimeTestTable(), WithComment(func(ctx context.Context) string {
+		route, _ := ctx.Value(routeKey{}).(string)
+		if route == "" {
+			return ""
+		}
+		return "route=" + route
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), routeKey{}, "/pay */")
+	if _, err := orm.Insert(ctx, &TestModelWithTime{Name: "Charlie"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := orm.InsertIgnore(ctx, &TestModelWithTime{Name: "Charlie"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := orm.DeleteByID(context.Background(), 1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expectedSQL := "INSERT INTO `test_table` SET `name`=?, `age`=?, `create_time`=?, `update_time`=? /* route=/pay * / */"
+	if got := mockEngine.ExecInsertCalls[0].SQL; got != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, got)
+	}
+	expectedSQL = "INSERT IGNORE INTO `test_table` SET `name`=?, `age`=?, `create_time`=?, `update_time`=? /* route=/pay * / */"
+	if got := mockEngine.ExecCalls[0].SQL; got != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, got)
+	}
+	expectedSQL = "DELETE FROM `test_table` WHERE `test_table`.`id` = ?"
+	if got := mockEngine.ExecCalls[1].SQL; got != expectedSQL {
+		t.Errorf("Expected SQL without comment: %s, got: %s", expectedSQL, got)
+	}
+}
+
+func TestWithSQLRewriter(t *testing.T) {
+	rec := engine.Recorder(nil)
+	var rewritten []string
+	orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](rec, newTimeTestTable(),
+		WithComment(func(ctx context.Context) string { return "svc=test" }),
+		WithSQLRewriter(func(sql string, args []interface{}) (string, []interface{}) {
+			rewritten = append(rewritten, sql)
+			return strings.ReplaceAll(sql, "`test_table`", "`test_table_green`"), args
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	if _, err := orm.FindByID(context.Background(), 1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := orm.WithEngine(rec).DeleteByID(context.Background(), 1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(rewritten) != 2 || !strings.HasSuffix(rewritten[0], " /* svc=test */") {
+		t.Errorf("Expected the rewriter to see the commented statements, got %q", rewritten)
+	}
+	sqltest.AssertExecuted(t, rec, "SELECT `test_table_green`.`id`, `test_table_green`.`name`, `test_table_green`.`age`, `test_table_green`.`create_time`, `test_table_green`.`update_time` FROM `test_table_green` WHERE `test_table_green`.`id` = ? LIMIT 1 /* svc=test */", 1)
+	sqltest.AssertExecuted(t, rec, "DELETE FROM `test_table_green` WHERE `test_table_green`.`id` = ? /* svc=test */", 1)
+}
+
+type txKey struct{}
+
+// TestWithEngineResolver tests that statements run on the engine of their context
+func TestWithEngineResolver(t *testing.T) {
+	bound := &MockEngine{}
+	orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](bound, newTimeTestTable(), WithEngineResolver(func(ctx context.Context) engine.Engine {
+		tx, _ := ctx.Value(txKey{}).(engine.Engine)
+		return tx
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	tx := &MockEngine{}
+	txCtx := context.WithValue(context.Background(), txKey{}, engine.Engine(tx))
+	if _, err := orm.Insert(txCtx, &TestModelWithTime{Name: "Alice"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := orm.DeleteByID(txCtx, 1); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(tx.ExecInsertCalls) != 1 || len(tx.ExecCalls) != 1 || len(bound.ExecInsertCalls) != 0 {
+		t.Errorf("Expected statements on the engine of the context, got %d inserts and %d execs", len(tx.ExecInsertCalls), len(tx.ExecCalls))
+	}
+
+	// contexts without an engine use the bound one
+	if _, err := orm.Insert(context.Background(), &TestModelWithTime{Name: "Bob"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(bound.ExecInsertCalls) != 1 {
+		t.Errorf("Expected the insert on the bound engine, got %d calls", len(bound.ExecInsertCalls))
+	}
+
+	// WithEngine takes precedence over the resolver
+	replica := &MockEngine{}
+	if _, err := orm.WithEngine(replica).Insert(txCtx, &TestModelWithTime{Name: "Carol"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(replica.ExecInsertCalls) != 1 || len(tx.ExecInsertCalls) != 1 {
+		t.Errorf("Expected the insert on the engine given to WithEngine")
+	}
+}
+
+func TestWithTablePrefix(t *testing.T) {
+	userTable := table.New("users")
+	userID := userTable.Int64("id")
+	userTable.String("name")
+	postTable := table.New("posts")
+	postTable.Int64("id")
+	postUserID := postTable.Int64("user_id")
+	postTable.String("title")
+
+	rec := engine.Recorder(nil)
+	users, err := bind[joinUser, joinUserOptional](rec, userTable, WithTablePrefix("app_"))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	posts, err := bind[joinPost, joinPostOptional](rec, postTable, WithTablePrefix("app_"))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := users.Insert(ctx, &joinUser{Name: "Alice"}); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	name := "Bob"
+	if err := users.UpdateByID(ctx, 1, &joinUserOptional{Name: &name}); err != nil {
+		t.Fatalf("UpdateByID: %v", err)
+	}
+	if err := users.DeleteByID(ctx, 1); err != nil {
+		t.Fatalf("DeleteByID: %v", err)
+	}
+	if _, err := Join2(users, posts).On(postUserID.EqField(userID)).Query(ctx); err != nil {
+		t.Fatalf("Join2: %v", err)
+	}
+	if _, err := users.WithTable("users_2025").GetByID(ctx, 1); err == nil {
+		t.Fatalf("Expected GetByID to report the missing user")
+	}
+
+	sqltest.AssertExecuted(t, rec, "INSERT INTO `app_users` SET `name`=?", "Alice")
+	sqltest.AssertExecuted(t, rec, "UPDATE `app_users` AS `users` SET `name`=? WHERE `users`.`id` = ?", "Bob", 1)
+	sqltest.AssertExecuted(t, rec, "DELETE `users` FROM `app_users` AS `users` WHERE `users`.`id` = ?", 1)
+	sqltest.AssertExecuted(t, rec, "SELECT `users`.`id` AS `first__id`, `users`.`name` AS `first__name`, `posts`.`id` AS `second__id`, `posts`.`user_id` AS `second__user_id`, `posts`.`title` AS `second__title` FROM `app_users` AS `users` JOIN `app_posts` AS `posts` ON `posts`.`user_id` = `users`.`id`")
+	sqltest.AssertExecuted(t, rec, "SELECT `users`.`id`, `users`.`name` FROM `app_users_2025` AS `users` WHERE `users`.`id` = ? LIMIT 1", 1)
+}
+
+// TestWithTablePrefix_Join tests that tables joined by name are prefixed too
+func TestWithTablePrefix_Join(t *testing.T) {
+	userTable := table.New("users")
+	userID := userTable.Int64("id")
+	userTable.String("name")
+	userTable.Int64("age")
+	postTable := table.New("posts")
+	postUserID := postTable.Int64("user_id")
+
+	rec := engine.Recorder(nil)
+	users, err := bind[TestModel, TestModelOptional](rec, userTable, WithTablePrefix("app_"))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := users.Select(userID).Join("posts", postUserID.EqField(userID)).Query(ctx); err != nil {
+		t.Fatalf("Join: %v", err)
+	}
+	if _, err := users.Select(userID).LeftJoin("posts", postUserID.EqField(userID)).Query(ctx); err != nil {
+		t.Fatalf("LeftJoin: %v", err)
+	}
+	sqltest.AssertExecuted(t, rec, "SELECT `users`.`id` FROM `app_users` AS `users` JOIN `app_posts` AS `posts` ON `posts`.`user_id` = `users`.`id`")
+	sqltest.AssertExecuted(t, rec, "SELECT `users`.`id` FROM `app_users` AS `users` LEFT JOIN `app_posts` AS `posts` ON `posts`.`user_id` = `users`.`id`")
+
+	countSQL, _, err := users.Count().Join("posts", postUserID.EqField(userID)).SQL()
+	if err != nil {
+		t.Fatalf("Count Join: %v", err)
+	}
+	expected := "SELECT COUNT(*) AS `count` FROM `app_users` AS `users` JOIN `app_posts` AS `posts` ON `posts`.`user_id` = `users`.`id`"
+	if countSQL != expected {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expected, countSQL)
+	}
+	countSQL, _, err = users.Count().LeftJoin("posts", postUserID.EqField(userID)).SQL()
+	if err != nil {
+		t.Fatalf("Count LeftJoin: %v", err)
+	}
+	expected = "SELECT COUNT(*) AS `count` FROM `app_users` AS `users` LEFT JOIN `app_posts` AS `posts` ON `posts`.`user_id` = `users`.`id`"
+	if countSQL != expected {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expected, countSQL)
+	}
+}
+
+// TestWithTablePrefix_Schema tests that schema-qualified tables are
+// aliased by their unqualified name under a prefix or WithTable
+func TestWithTablePrefix_Schema(t *testing.T) {
+	eventTable := table.New("analytics.events")
+	eventTable.Int64("id")
+	eventName := eventTable.String("name")
+
+	rec := engine.Recorder(nil)
+	events, err := bind[joinUser, joinUserOptional](rec, eventTable, WithTablePrefix("app_"))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := events.Insert(ctx, &joinUser{Name: "signup"}); err != nil {
+		t.Fatalf("Insert: %v", err)
+	}
+	if _, err := events.SelectAll().Where(eventName.Eq("signup")).Query(ctx); err != nil {
+		t.Fatalf("SelectAll: %v", err)
+	}
+	name := "login"
+	if err := events.UpdateByID(ctx, 1, &joinUserOptional{Name: &name}); err != nil {
+		t.Fatalf("UpdateByID: %v", err)
+	}
+	if err := events.DeleteByID(ctx, 1); err != nil {
+		t.Fatalf("DeleteByID: %v", err)
+	}
+	if _, err := events.WithTable("analytics.events_2025").FindByID(ctx, 1); err != nil {
+		t.Fatalf("WithTable FindByID: %v", err)
+	}
+
+	sqltest.AssertExecuted(t, rec, "INSERT INTO `analytics`.`app_events` SET `name`=?", "signup")
+	sqltest.AssertExecuted(t, rec, "SELECT `events`.`id`, `events`.`name` FROM `analytics`.`app_events` AS `events` WHERE `events`.`name` = ?", "signup")
+	sqltest.AssertExecuted(t, rec, "UPDATE `analytics`.`app_events` AS `events` SET `name`=? WHERE `events`.`id` = ?", "login", 1)
+	sqltest.AssertExecuted(t, rec, "DELETE `events` FROM `analytics`.`app_events` AS `events` WHERE `events`.`id` = ?", 1)
+	sqltest.AssertExecuted(t, rec, "SELECT `events`.`id`, `events`.`name` FROM `analytics`.`app_events_2025` AS `events` WHERE `events`.`id` = ? LIMIT 1", 1)
+
+}
+
+func TestWithSafeMode(t *testing.T) {
+	userTable := table.New("users")
+	userTable.Int64("id")
+	userName := userTable.String("name")
+
+	rec := engine.Recorder(nil)
+	users, err := bind[joinUser, joinUserOptional](rec, userTable, WithSafeMode())
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := users.Update().Set(userName, sql.String("Bob")).Exec(ctx); !errors.Is(err, sql.ErrFullTable) {
+		t.Errorf("Expected ErrFullTable, got %v", err)
+	}
+	if err := users.DeleteWhere(ctx, sql.Optional(false, userName.Eq("Bob"))); !errors.Is(err, sql.ErrFullTable) {
+		t.Errorf("Expected ErrFullTable, got %v", err)
+	}
+	sqltest.AssertCount(t, rec, 0)
+
+	if err := users.Update().Set(userName, sql.String("Bob")).AllowFullTable().Exec(ctx); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := users.DeleteByID(ctx, 1); err != nil {
+		t.Fatalf("DeleteByID: %v", err)
+	}
+	sqltest.AssertExecuted(t, rec, "UPDATE `users` SET `name`=?", "Bob")
+	sqltest.AssertExecuted(t, rec, "DELETE FROM `users` WHERE `users`.`id` = ?", 1)
+}
+
+// TestWithSafeMode_Scope tests that the default scope
+// does not count as a condition under safe mode
+func TestWithSafeMode_Scope(t *testing.T) {
+	userTable := table.New("users")
+	userID := userTable.Int64("id")
+	userName := userTable.String("name")
+
+	rec := engine.Recorder(nil)
+	users, err := bind[joinUser, joinUserOptional](rec, userTable, WithSafeMode(), WithDefaultScope(func(ctx context.Context) []field.Expr {
+		return []field.Expr{userID.Gt(10)}
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := users.Update().Set(userName, sql.String("Bob")).Exec(ctx); !errors.Is(err, sql.ErrFullTable) {
+		t.Errorf("Expected ErrFullTable, got %v", err)
+	}
+	if err := users.DeleteWhere(ctx, sql.Optional(false, userName.Eq("Bob"))); !errors.Is(err, sql.ErrFullTable) {
+		t.Errorf("Expected ErrFullTable, got %v", err)
+	}
+	sqltest.AssertCount(t, rec, 0)
+
+	if err := users.Update().Set(userName, sql.String("Bob")).AllowFullTable().Exec(ctx); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := users.Update().Set(userName, sql.String("Bob")).Where(userName.Eq("Alice")).Exec(ctx); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if err := users.DeleteWhere(ctx, userName.Eq("Bob")); err != nil {
+		t.Fatalf("DeleteWhere: %v", err)
+	}
+	sqltest.AssertExecuted(t, rec, "UPDATE `users` SET `name`=? WHERE `users`.`id` > ?", "Bob", 10)
+	sqltest.AssertExecuted(t, rec, "UPDATE `users` SET `name`=? WHERE `users`.`name` = ? AND `users`.`id` > ?", "Bob", "Alice", 10)
+	sqltest.AssertExecuted(t, rec, "DELETE FROM `users` WHERE `users`.`name` = ? AND `users`.`id` > ?", "Bob", 10)
+}
+
+func TestWithMaxRows(t *testing.T) {
+	userTable := table.New("users")
+	userTable.Int64("id")
+	userTable.String("name")
+
+	var sqls []string
+	rows := 3
+	eng := &MockQueryEngine{QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+		sqls = append(sqls, sql)
+		users := result.(*[]*joinUser)
+		for i := 0; i < rows; i++ {
+			*users = append(*users, &joinUser{Id: int64(i + 1)})
+		}
+		return nil
+	}}
+	users, err := bind[joinUser, joinUserOptional](eng, userTable, WithMaxRows(2))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := users.SelectAll().Query(ctx); !errors.Is(err, ErrTooManyRows) {
+		t.Errorf("Expected ErrTooManyRows, got %v", err)
+	}
+	rows = 2
+	list, err := users.SelectAll().Query(ctx)
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	if len(list) != 2 {
+		t.Errorf("Expected 2 users, got %d", len(list))
+	}
+	rows = 3
+	if _, err := users.SelectAll().Limit(10).Query(ctx); err != nil {
+		t.Errorf("Expected an explicit Limit to be kept, got %v", err)
+	}
+
+	expected := []string{
+		"SELECT `users`.`id`, `users`.`name` FROM `users` LIMIT 3",
+		"SELECT `users`.`id`, `users`.`name` FROM `users` LIMIT 3",
+		"SELECT `users`.`id`, `users`.`name` FROM `users` LIMIT 10",
+	}
+	if strings.Join(sqls, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(sqls, "\n"))
+	}
+}
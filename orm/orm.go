@@ -16,19 +16,40 @@ import (
 type ORM[T any, P any] struct {
 	table  table.Table
 	engine engine.Factory
+	opts   options
+
+	descriptor *modelDescriptor
+	// physicalName overrides the name of the table statements target, see WithTable
+	physicalName string
+	scopes       *namedScopes[T, P]
+	relations    *namedRelations
+	listeners    *writeListeners
 }
 
 // Common errors
 var (
 	ErrNothingToUpdate     = errors.New("nothing to update")
+	ErrTooManyRows         = errors.New("query matched more rows than allowed by WithMaxRows")
 	ErrMissingIDField      = errors.New("table is missing 'id' field")
 	ErrMissingCountField   = errors.New("model type must have a Count field of type int64")
 	ErrWrongCountFieldType = errors.New("Count field must be of type int64")
+
+	// ErrNotFound is returned, wrapped with the table and the key
+	// if known, when the requested record does not exist
+	ErrNotFound = errors.New("data not found")
+	// ErrNoRowsAffected is returned, wrapped with the table and the key,
+	// when a delete by primary key matches no record. It is only
+	// reported by engines implementing engine.AffectedExecer.
+	ErrNoRowsAffected = errors.New("no rows affected")
+	// ErrScopedUpsert is returned by InsertOrUpdate under an active default
+	// scope, since the conflicting row it updates may be out of the scope
+	ErrScopedUpsert = errors.New("InsertOrUpdate cannot update rows out of the default scope, use Unscoped")
 )
 
-// Bind creates a new ORM instance and panics if validation fails
-func Bind[T any, P any](engine engine.Factory, table table.Table) *ORM[T, P] {
-	orm, err := bind[T, P](engine, table)
+// Bind creates a new ORM instance and panics if validation fails.
+// The ORM implements Interface, for service layers to depend on.
+func Bind[T any, P any](engine engine.Factory, table table.Table, opts ...Option) *ORM[T, P] {
+	orm, err := bind[T, P](engine, table, opts...)
 	if err != nil {
 		panic(err)
 	}
@@ -36,16 +57,62 @@ func Bind[T any, P any](engine engine.Factory, table table.Table) *ORM[T, P] {
 }
 
 // bind creates a new ORM instance and validates the model and optional fields types
-func bind[T any, P any](engine engine.Factory, table table.Table) (*ORM[T, P], error) {
+func bind[T any, P any](engine engine.Factory, table table.Table, opts ...Option) (*ORM[T, P], error) {
 	orm := &ORM[T, P]{
-		table:  table,
-		engine: engine,
+		table:     table,
+		engine:    engine,
+		scopes:    &namedScopes[T, P]{},
+		relations: &namedRelations{},
+		listeners: &writeListeners{},
+	}
+	for _, opt := range opts {
+		opt(&orm.opts)
+	}
+	if orm.opts.hasHooks() {
+		orm.engine = hookFactory{factory: engine, opts: orm.opts}
 	}
 
 	// Validate the model and optional fields types
 	if err := orm.Validate(); err != nil {
 		return nil, fmt.Errorf("ORM validation failed: %w", err)
 	}
+	if pk := orm.opts.primaryKey; pk != nil && !hasField(table, pk.Name()) {
+		return nil, fmt.Errorf("ORM validation failed: primary key %s not found in table %s", pk.Name(), table.Name())
+	}
+	orm.descriptor = orm.newDescriptor()
 
 	return orm, nil
 }
+
+// Table returns the table the ORM is bound to
+func (o *ORM[T, P]) Table() table.Table {
+	return o.table
+}
+
+// Engine returns the engine factory the ORM executes statements with
+func (o *ORM[T, P]) Engine() engine.Factory {
+	return o.engine
+}
+
+// WithEngine returns a shallow copy of the ORM bound to eng, e.g. a
+// transaction, a replica or a tenant-specific database, without binding
+// and validating again. Options, scopes, relations and listeners are
+// shared, except the engine resolver, which eng takes precedence over.
+func (o *ORM[T, P]) WithEngine(eng engine.Factory) *ORM[T, P] {
+	c := *o
+	c.engine = eng
+	c.opts.engineResolver = nil
+	if c.opts.hasHooks() {
+		c.engine = hookFactory{factory: eng, opts: c.opts}
+	}
+	return &c
+}
+
+func hasField(t table.Table, name string) bool {
+	for _, f := range t.Fields() {
+		if f.Name() == name {
+			return true
+		}
+	}
+	return false
+}
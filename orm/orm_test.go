@@ -10,6 +10,7 @@ import (
 
 	"github.com/xhd2015/arc-orm/engine"
 	"github.com/xhd2015/arc-orm/sql"
+	"github.com/xhd2015/arc-orm/sqltest"
 	"github.com/xhd2015/arc-orm/table"
 	"github.com/xhd2015/xgo/support/assert"
 )
@@ -341,9 +342,18 @@ func TestQueryByID_NotFound(t *testing.T) {
 		t.Fatalf("Expected an error, got nil")
 	}
 
-	if diff := assert.Diff(err.Error(), "data not found"); diff != "" {
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	if diff := assert.Diff(err.Error(), "data not found: test_table id=99"); diff != "" {
 		t.Error(diff)
 	}
+
+	// FindByID reports a missing record as nil without error
+	model, err := orm.FindByID(context.Background(), 99)
+	if err != nil || model != nil {
+		t.Errorf("Expected nil without error, got %+v, %v", model, err)
+	}
 }
 
 func TestQueryByID_Error(t *testing.T) {
@@ -1107,6 +1117,59 @@ func TestDeleteByID_MissingIDField(t *testing.T) {
 }
 
 // TestSelectExpr tests the SelectExpr method for custom expressions
+func TestSelectAll_Lazy(t *testing.T) {
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name").Lazy()
+	age := testTable.Int64("age")
+	rec := engine.Recorder(nil)
+	orm := &ORM[TestModel, TestModelOptional]{table: testTable, engine: rec}
+
+	ctx := context.Background()
+	if _, err := orm.SelectAll().Where(age.Gt(18)).Query(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := orm.SelectAll().WithLazy().Where(age.Gt(18)).Query(ctx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	sqltest.AssertExecuted(t, rec, "SELECT `test_table`.`id`, `test_table`.`age` FROM `test_table` WHERE `test_table`.`age` > ?", 18)
+	sqltest.AssertExecuted(t, rec, "SELECT `test_table`.`id`, `test_table`.`name`, `test_table`.`age` FROM `test_table` WHERE `test_table`.`age` > ?", 18)
+}
+
+func TestQueryOptional(t *testing.T) {
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	name := testTable.String("name")
+	age := testTable.Int64("age")
+
+	var gotSQL string
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			gotSQL = sql
+			rows, ok := result.(*[]*TestModelOptional)
+			if !ok {
+				return fmt.Errorf("unexpected result type %T", result)
+			}
+			alice := "Alice"
+			*rows = []*TestModelOptional{{Name: &alice}}
+			return nil
+		},
+	}
+	orm := &ORM[TestModel, TestModelOptional]{table: testTable, engine: mockEngine}
+
+	rows, err := orm.Select(name).Where(age.Gt(18)).QueryOptional(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expectedSQL := "SELECT `test_table`.`name` FROM `test_table` WHERE `test_table`.`age` > ?"
+	if gotSQL != expectedSQL {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expectedSQL, gotSQL)
+	}
+	if len(rows) != 1 || rows[0].Name == nil || *rows[0].Name != "Alice" || rows[0].Age != nil {
+		t.Errorf("Expected only the selected name to be set, got %+v", rows)
+	}
+}
+
 func TestSelectExpr(t *testing.T) {
 	// Setup a mock engine that captures the SQL
 	var capturedSQL string
@@ -1224,3 +1287,174 @@ func TestQueryInto(t *testing.T) {
 		t.Errorf("Second result mismatch: got %+v", results[1])
 	}
 }
+
+func TestCount_Distinct(t *testing.T) {
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			expectedSQL := "SELECT COUNT(DISTINCT `test_table`.`age`) AS `count` FROM `test_table` WHERE `test_table`.`name` = ? LIMIT 1"
+			if sql != expectedSQL {
+				t.Errorf("Expected query '%s', got %s", expectedSQL, sql)
+			}
+			*result.(*[]*TestModel) = []*TestModel{{Count: 3}}
+			return nil
+		},
+	}
+
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	name := testTable.String("name")
+	age := testTable.Int64("age")
+
+	orm, err := bind[TestModel, TestModelOptional](mockEngine, testTable)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	n, err := orm.Count().Distinct(age).Where(name.Eq("Alice")).Query(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != 3 {
+		t.Fatalf("Expected 3, got %d", n)
+	}
+}
+
+func TestCount_GroupByHavingJoin(t *testing.T) {
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			expectedSQL := "SELECT COUNT(*) AS `count`, `test_table`.`name` FROM `test_table` JOIN `other` ON `other`.`test_id` = `test_table`.`id` GROUP BY `test_table`.`name` HAVING COUNT(*) > ? ORDER BY `test_table`.`name` ASC"
+			if sql != expectedSQL {
+				t.Errorf("Expected query:\n%s\ngot:\n%s", expectedSQL, sql)
+			}
+			*result.(*[]*TestModel) = []*TestModel{{Name: "Alice", Count: 3}}
+			return nil
+		},
+	}
+
+	testTable := table.New("test_table")
+	id := testTable.Int64("id")
+	name := testTable.String("name")
+	testTable.Int64("age")
+	otherTable := table.New("other")
+	otherTestID := otherTable.Int64("test_id")
+
+	orm, err := bind[TestModel, TestModelOptional](mockEngine, testTable)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	list, err := orm.Count(name).
+		Join(otherTable.Name(), otherTestID.EqField(id)).
+		GroupBy(name).
+		Having(sql.Count(sql.All).Gt(1)).
+		OrderBy(name.Asc()).
+		QueryMany(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(list) != 1 || list[0].Count != 3 {
+		t.Fatalf("Unexpected result: %v", list)
+	}
+}
+
+func TestSelect_JoinGroupByHaving(t *testing.T) {
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			expectedSQL := "SELECT `test_table`.`name` FROM `test_table` JOIN `other` ON `other`.`test_id` = `test_table`.`id` GROUP BY `test_table`.`name` HAVING COUNT(*) > ?"
+			if sql != expectedSQL {
+				t.Errorf("Expected query:\n%s\ngot:\n%s", expectedSQL, sql)
+			}
+			return nil
+		},
+	}
+
+	testTable := table.New("test_table")
+	id := testTable.Int64("id")
+	name := testTable.String("name")
+	testTable.Int64("age")
+	otherTable := table.New("other")
+	otherTestID := otherTable.Int64("test_id")
+
+	orm, err := bind[TestModel, TestModelOptional](mockEngine, testTable)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	_, err = orm.Select(name).
+		Join(otherTable.Name(), otherTestID.EqField(id)).
+		GroupBy(name).
+		Having(sql.Count(sql.All).Gt(1)).
+		Query(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestCount_Clone(t *testing.T) {
+	var queries []string
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			queries = append(queries, sql)
+			*result.(*[]*TestModel) = []*TestModel{{Count: 1}}
+			return nil
+		},
+	}
+
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	name := testTable.String("name")
+	age := testTable.Int64("age")
+
+	orm, err := bind[TestModel, TestModelOptional](mockEngine, testTable)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	base := orm.Count().Where(name.Eq("Alice"))
+	distinct := base.Clone().Distinct(age)
+	for _, b := range []*ORMCountBuilder[TestModel, TestModelOptional]{base, distinct, base} {
+		if _, err := b.Query(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+
+	expected := []string{
+		"SELECT COUNT(*) AS `count` FROM `test_table` WHERE `test_table`.`name` = ? LIMIT 1",
+		"SELECT COUNT(DISTINCT `test_table`.`age`) AS `count` FROM `test_table` WHERE `test_table`.`name` = ? LIMIT 1",
+		"SELECT COUNT(*) AS `count` FROM `test_table` WHERE `test_table`.`name` = ? LIMIT 1",
+	}
+	for i, q := range expected {
+		if queries[i] != q {
+			t.Errorf("Expected query %d:\n%s\ngot:\n%s", i, q, queries[i])
+		}
+	}
+}
+
+func TestWithEngine(t *testing.T) {
+	primary := &MockEngine{}
+	orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](primary, newTimeTestTable(), WithComment(func(ctx context.Context) string {
+		return "svc=test"
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	tx := &MockEngine{}
+	if _, err := orm.WithEngine(tx).Insert(context.Background(), &TestModelWithTime{Name: "Alice"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(primary.ExecInsertCalls) != 0 || len(tx.ExecInsertCalls) != 1 {
+		t.Fatalf("Expected the insert on the overriding engine only, got %d and %d", len(primary.ExecInsertCalls), len(tx.ExecInsertCalls))
+	}
+	if got := tx.ExecInsertCalls[0].SQL; !strings.HasSuffix(got, " /* svc=test */") {
+		t.Errorf("Expected the comment option to apply to the overriding engine, got %s", got)
+	}
+
+	// the original ORM keeps its engine
+	if _, err := orm.Insert(context.Background(), &TestModelWithTime{Name: "Bob"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(primary.ExecInsertCalls) != 1 {
+		t.Errorf("Expected the insert on the bound engine, got %d calls", len(primary.ExecInsertCalls))
+	}
+}
@@ -2,11 +2,10 @@ package orm
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/xhd2015/arc-orm/field"
-	"github.com/xhd2015/arc-orm/sql"
 )
 
 // QuerySQL executes the provided SQL query and returns matching records
@@ -15,7 +14,7 @@ func (o *ORM[T, P]) QuerySQL(ctx context.Context, sql string, args []interface{}
 	var results []*T
 
 	// Execute the query using the engine
-	err := o.engine.GetEngine().Query(ctx, sql, args, &results)
+	err := o.getEngine(ctx).Query(ctx, sql, args, &results)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
@@ -25,12 +24,34 @@ func (o *ORM[T, P]) QuerySQL(ctx context.Context, sql string, args []interface{}
 
 // GetByID retrieves a record by its primary key
 // the record must exist, otherwise it will return an error
+// wrapping ErrNotFound
 func (o *ORM[T, P]) GetByID(ctx context.Context, id int64) (*T, error) {
 	idCondition, err := o.toIDCondition(id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert id to condition: %w", err)
 	}
-	return o.get(ctx, []field.Expr{idCondition})
+	return o.get(ctx, []field.Expr{idCondition}, []interface{}{id})
+}
+
+// FindByID retrieves a record by its primary key,
+// returning nil without error if it does not exist
+func (o *ORM[T, P]) FindByID(ctx context.Context, id int64) (*T, error) {
+	idCondition, err := o.toIDCondition(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert id to condition: %w", err)
+	}
+	return o.first(ctx, []field.Expr{idCondition})
+}
+
+// GetByKey retrieves a record by its primary key of any type,
+// like a UUID string set by WithPrimaryKey.
+// The record must exist, otherwise it will return an error
+func (o *ORM[T, P]) GetByKey(ctx context.Context, key interface{}) (*T, error) {
+	keyCondition, err := o.toKeyCondition(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert key to condition: %w", err)
+	}
+	return o.get(ctx, []field.Expr{keyCondition}, []interface{}{key})
 }
 
 func (o *ORM[T, P]) GetBy(ctx context.Context, condition *P) (*T, error) {
@@ -43,13 +64,26 @@ func (o *ORM[T, P]) GetBy(ctx context.Context, condition *P) (*T, error) {
 		return nil, fmt.Errorf("failed to convert condition to SQL conditions: %w", err)
 	}
 
-	return o.get(ctx, sqlConditions)
+	return o.get(ctx, sqlConditions, nil)
 }
 
-func (o *ORM[T, P]) get(ctx context.Context, conditions []field.Expr) (*T, error) {
-	querySQL, args, err := sql.Select(fieldsToExprs(o.table.Fields())...).
-		From(o.table.Name()).
-		Where(conditions...).
+// get returns the first record matching the conditions, or ErrNotFound,
+// keys are the primary keys of the record, if known
+func (o *ORM[T, P]) get(ctx context.Context, conditions []field.Expr, keys []interface{}) (*T, error) {
+	result, err := o.first(ctx, conditions)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, o.keyError(ErrNotFound, keys)
+	}
+	return result, nil
+}
+
+// first returns the first record matching the conditions, or nil if none
+func (o *ORM[T, P]) first(ctx context.Context, conditions []field.Expr) (*T, error) {
+	querySQL, args, err := o.newSelect(fieldsToExprs(o.table.Fields())...).
+		Where(o.scoped(ctx, conditions)...).
 		Limit(1).
 		SQL()
 	if err != nil {
@@ -60,15 +94,151 @@ func (o *ORM[T, P]) get(ctx context.Context, conditions []field.Expr) (*T, error
 	var results []*T
 
 	// Execute the query
-	err = o.engine.GetEngine().Query(ctx, querySQL, args, &results)
+	err = o.getEngine(ctx).Query(ctx, querySQL, args, &results)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute Get: %w", err)
 	}
 
 	// Check if we found a result
 	if len(results) == 0 {
-		return nil, errors.New("data not found")
+		return nil, nil
 	}
 
 	return results[0], nil
 }
+
+// GetByIDs retrieves the records by their IDs keyed by ID,
+// splitting very large id sets into chunks. Missing records are absent from the map.
+func (o *ORM[T, P]) GetByIDs(ctx context.Context, ids []int64) (map[int64]*T, error) {
+	idField, err := o.idField()
+	if err != nil {
+		return nil, err
+	}
+	idIndex, err := modelIDIndex[T](o.opts.naming, idField.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	// deduplicate ids
+	seen := make(map[int64]bool, len(ids))
+	uniqueIDs := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			uniqueIDs = append(uniqueIDs, id)
+		}
+	}
+
+	result := make(map[int64]*T, len(uniqueIDs))
+	for start := 0; start < len(uniqueIDs); start += idsChunkSize {
+		end := start + idsChunkSize
+		if end > len(uniqueIDs) {
+			end = len(uniqueIDs)
+		}
+		querySQL, args, err := o.newSelect(fieldsToExprs(o.table.Fields())...).
+			Where(o.scoped(ctx, []field.Expr{idField.In(uniqueIDs[start:end]...)})...).
+			SQL()
+		if err != nil {
+			return nil, fmt.Errorf("sql: %w", err)
+		}
+		var records []*T
+		err = o.getEngine(ctx).Query(ctx, querySQL, args, &records)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute GetByIDs: %w", err)
+		}
+		for _, record := range records {
+			result[reflect.ValueOf(record).Elem().Field(idIndex).Int()] = record
+		}
+	}
+	return result, nil
+}
+
+// ListByIDs retrieves the records by their IDs in the order of ids,
+// missing records are skipped
+func (o *ORM[T, P]) ListByIDs(ctx context.Context, ids []int64) ([]*T, error) {
+	records, err := o.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	list := make([]*T, 0, len(records))
+	for _, id := range ids {
+		if record, ok := records[id]; ok {
+			list = append(list, record)
+		}
+	}
+	return list, nil
+}
+
+// modelIDIndex returns the index of the integer model field of the id column
+func modelIDIndex[T any](naming NamingStrategy, column string) (int, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || Ignored(f) || naming.ColumnName(f) != column {
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return i, nil
+		}
+		return 0, fmt.Errorf("model field %s of '%s' must be an integer, got %s", f.Name, column, f.Type)
+	}
+	return 0, fmt.Errorf("model is missing the field of '%s'", column)
+}
+
+// FindByExample returns the records equal to the example on all its
+// non-zero fields, complementing GetBy for full models.
+// The include fields are matched even if their values are zero.
+func (o *ORM[T, P]) FindByExample(ctx context.Context, example *T, include ...field.Field) ([]*T, error) {
+	if example == nil {
+		return nil, fmt.Errorf("requires example")
+	}
+	included := make(map[string]bool, len(include))
+	for _, f := range include {
+		included[f.Name()] = true
+	}
+
+	var conditions []field.Expr
+	v := reflect.ValueOf(example).Elem()
+	for _, mf := range o.describe().model {
+		fieldV := v.Field(mf.index)
+		if !mf.field.IsExported() || mf.isCount {
+			continue
+		}
+		column := mf.column
+		if fieldV.IsZero() && !included[column] {
+			continue
+		}
+		tableField := mf.tableField
+		if tableField == nil {
+			return nil, fmt.Errorf("column %s not found in table %s", column, o.table.Name())
+		}
+		if fieldV.Kind() == reflect.Ptr {
+			if fieldV.IsNil() {
+				conditions = append(conditions, &nullCondition{field: tableField})
+				continue
+			}
+			fieldV = fieldV.Elem()
+		}
+		conditions = append(conditions, &opCondition{
+			field:  tableField,
+			op:     "=",
+			values: []interface{}{fieldV.Interface()},
+		})
+	}
+
+	return o.SelectAll().Where(conditions...).Query(ctx)
+}
+
+// nullCondition matches NULL values of a field
+type nullCondition struct {
+	field field.Field
+}
+
+func (c *nullCondition) ToSQL() (string, []interface{}, error) {
+	sql, params, err := c.field.ToSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	return sql + " IS NULL", params, nil
+}
@@ -0,0 +1,150 @@
+package orm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+func newByIDsTestORM(queries *[]string) *ORM[TestModel, TestModelOptional] {
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			*queries = append(*queries, sql)
+			// return the rows in reverse order, skipping id 404
+			var rows []*TestModel
+			for i := len(args) - 1; i >= 0; i-- {
+				id := args[i].(int64)
+				if id != 404 {
+					rows = append(rows, &TestModel{Id: id})
+				}
+			}
+			*result.(*[]*TestModel) = rows
+			return nil
+		},
+	}
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name")
+	testTable.Int64("age")
+	return &ORM[TestModel, TestModelOptional]{
+		table:  testTable,
+		engine: mockEngine,
+	}
+}
+
+func TestGetByIDs(t *testing.T) {
+	var queries []string
+	orm := newByIDsTestORM(&queries)
+
+	records, err := orm.GetByIDs(context.Background(), []int64{3, 1, 404, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 2 || records[1].Id != 1 || records[3].Id != 3 {
+		t.Errorf("Expected records 1 and 3, got %v", records)
+	}
+	expectedSQL := "SELECT `test_table`.`id`, `test_table`.`name`, `test_table`.`age` FROM `test_table` WHERE `test_table`.`id` IN (?, ?, ?)"
+	if len(queries) != 1 || queries[0] != expectedSQL {
+		t.Errorf("Expected query %q, got %v", expectedSQL, queries)
+	}
+}
+
+func TestGetByIDs_Chunked(t *testing.T) {
+	var queries []string
+	orm := newByIDsTestORM(&queries)
+
+	ids := make([]int64, idsChunkSize+1)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	records, err := orm.GetByIDs(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// all but 404
+	if len(records) != len(ids)-1 {
+		t.Errorf("Expected %d records, got %d", len(ids)-1, len(records))
+	}
+	if len(queries) != 2 {
+		t.Errorf("Expected 2 queries, got %d", len(queries))
+	}
+}
+
+func TestListByIDs(t *testing.T) {
+	var queries []string
+	orm := newByIDsTestORM(&queries)
+
+	list, err := orm.ListByIDs(context.Background(), []int64{3, 404, 1, 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []int64
+	for _, record := range list {
+		got = append(got, record.Id)
+	}
+	if len(got) != 3 || got[0] != 3 || got[1] != 1 || got[2] != 2 {
+		t.Errorf("Expected ids [3 1 2], got %v", got)
+	}
+}
+
+func TestFindByExample(t *testing.T) {
+	var gotSQL string
+	var gotArgs []interface{}
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			gotSQL = sql
+			gotArgs = args
+			*result.(*[]*TestModel) = []*TestModel{{Id: 1, Name: "Alice"}}
+			return nil
+		},
+	}
+	testTable := table.New("test_table")
+	testTable.Int64("id")
+	testTable.String("name")
+	age := testTable.Int64("age")
+	orm := &ORM[TestModel, TestModelOptional]{
+		table:  testTable,
+		engine: mockEngine,
+	}
+
+	list, err := orm.FindByExample(context.Background(), &TestModel{Name: "Alice"}, age)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list) != 1 || list[0].Id != 1 {
+		t.Errorf("Unexpected result: %v", list)
+	}
+	expectedSQL := "SELECT `test_table`.`id`, `test_table`.`name`, `test_table`.`age` FROM `test_table` WHERE `test_table`.`name` = ? AND `test_table`.`age` = ?"
+	if gotSQL != expectedSQL {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expectedSQL, gotSQL)
+	}
+	if len(gotArgs) != 2 || gotArgs[0] != "Alice" || gotArgs[1] != 0 {
+		t.Errorf("Unexpected args: %v", gotArgs)
+	}
+}
+
+// TestQueryOne_Reusable tests that QueryOne and RequireOne leave the builder unlimited
+func TestQueryOne_Reusable(t *testing.T) {
+	var queries []string
+	orm := newByIDsTestORM(&queries)
+	query := orm.SelectAll()
+	if _, err := query.QueryOne(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := query.RequireOne(context.Background()); err == nil {
+		t.Fatalf("Expected not found")
+	}
+	if _, err := query.Query(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queries) != 3 {
+		t.Fatalf("Expected 3 queries, got %q", queries)
+	}
+	for i, suffix := range []string{" LIMIT 1", " LIMIT 1", "`test_table`"} {
+		if !strings.HasSuffix(queries[i], suffix) {
+			t.Errorf("Expected query %d to end with %q, got %s", i, suffix, queries[i])
+		}
+	}
+}
@@ -0,0 +1,268 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/xhd2015/arc-orm/field"
+)
+
+// Relation relates the records of an ORM to the records of another,
+// declared by HasMany or BelongsTo and registered by ORM.Relation
+type Relation interface {
+	// localKey returns the column of the declaring model holding the
+	// keys of the related records, given its primary key column
+	localKey(pkColumn string) string
+	// load queries the related records of the keys with a single
+	// IN query, grouped by key
+	load(ctx context.Context, keys []int64) (map[int64][]reflect.Value, error)
+	// target returns the type of the related records, a model pointer
+	target() reflect.Type
+	// many reports whether a record relates to many records
+	many() bool
+}
+
+type hasMany[C any, CP any] struct {
+	child      *ORM[C, CP]
+	foreignKey field.Int64Field
+}
+
+// HasMany relates a record to the child records whose foreignKey
+// refers to its primary key, e.g. the posts of a user:
+//
+//	var ORM = orm.Bind[User, UserOptional](engine.Engine, Table).
+//	    Relation("Posts", orm.HasMany(post.ORM, post.UserID))
+func HasMany[C any, CP any](child *ORM[C, CP], foreignKey field.Int64Field) Relation {
+	return hasMany[C, CP]{child: child, foreignKey: foreignKey}
+}
+
+func (r hasMany[C, CP]) localKey(pkColumn string) string {
+	return pkColumn
+}
+
+func (r hasMany[C, CP]) load(ctx context.Context, keys []int64) (map[int64][]reflect.Value, error) {
+	return loadRelated(ctx, r.child, r.foreignKey, keys)
+}
+
+func (r hasMany[C, CP]) target() reflect.Type {
+	return reflect.TypeOf((*C)(nil))
+}
+
+func (r hasMany[C, CP]) many() bool {
+	return true
+}
+
+type belongsTo[C any, CP any] struct {
+	parent     *ORM[C, CP]
+	foreignKey field.Int64Field
+}
+
+// BelongsTo relates a record to the parent record its foreignKey
+// refers to, e.g. the author of a post:
+//
+//	var ORM = orm.Bind[Post, PostOptional](engine.Engine, Table).
+//	    Relation("Author", orm.BelongsTo(user.ORM, UserID))
+func BelongsTo[C any, CP any](parent *ORM[C, CP], foreignKey field.Int64Field) Relation {
+	return belongsTo[C, CP]{parent: parent, foreignKey: foreignKey}
+}
+
+func (r belongsTo[C, CP]) localKey(pkColumn string) string {
+	return r.foreignKey.Name()
+}
+
+func (r belongsTo[C, CP]) load(ctx context.Context, keys []int64) (map[int64][]reflect.Value, error) {
+	idField, err := r.parent.idField()
+	if err != nil {
+		return nil, err
+	}
+	return loadRelated(ctx, r.parent, idField, keys)
+}
+
+func (r belongsTo[C, CP]) target() reflect.Type {
+	return reflect.TypeOf((*C)(nil))
+}
+
+func (r belongsTo[C, CP]) many() bool {
+	return false
+}
+
+// loadRelated queries the records whose key is in keys, grouped by key
+func loadRelated[C any, CP any](ctx context.Context, o *ORM[C, CP], key field.Int64Field, keys []int64) (map[int64][]reflect.Value, error) {
+	index, err := modelIDIndex[C](o.opts.naming, key.Name())
+	if err != nil {
+		return nil, err
+	}
+	grouped := make(map[int64][]reflect.Value)
+	for start := 0; start < len(keys); start += idsChunkSize {
+		end := start + idsChunkSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		records, err := o.SelectAll().Where(key.In(keys[start:end]...)).Query(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			v := reflect.ValueOf(record)
+			k := v.Elem().Field(index).Int()
+			grouped[k] = append(grouped[k], v)
+		}
+	}
+	return grouped, nil
+}
+
+// namedRelations holds the relations registered on an ORM, shared by its copies
+type namedRelations struct {
+	mu        sync.RWMutex
+	relations map[string]Relation
+}
+
+// Relation registers a named relation loaded by ORMSelectBuilder.Preload,
+// see HasMany and BelongsTo. It panics if the name is already registered.
+func (o *ORM[T, P]) Relation(name string, rel Relation) *ORM[T, P] {
+	if o.relations == nil {
+		o.relations = &namedRelations{}
+	}
+	o.relations.mu.Lock()
+	defer o.relations.mu.Unlock()
+	if _, ok := o.relations.relations[name]; ok {
+		panic(fmt.Errorf("relation %s already registered on %s", name, o.table.Name()))
+	}
+	if o.relations.relations == nil {
+		o.relations.relations = make(map[string]Relation)
+	}
+	o.relations.relations[name] = rel
+	return o
+}
+
+// lookupRelation returns the relation registered with the name
+func (o *ORM[T, P]) lookupRelation(name string) (Relation, bool) {
+	if o.relations == nil {
+		return nil, false
+	}
+	o.relations.mu.RLock()
+	defer o.relations.mu.RUnlock()
+	rel, ok := o.relations.relations[name]
+	return rel, ok
+}
+
+// Preload loads the named relations registered by ORM.Relation along
+// with the records of QueryAggregate, with a single IN query each
+func (c *ORMSelectBuilder[T, P]) Preload(names ...string) *ORMSelectBuilder[T, P] {
+	c.preloads = append(c.preloads, names...)
+	return c
+}
+
+// QueryAggregate executes the query and fills result, a pointer to a
+// slice of pointers to aggregate structs embedding the model, by value
+// or pointer, with a field named after each preloaded relation: a slice
+// of related model pointers for HasMany, a model pointer for BelongsTo.
+// Example:
+//
+//	type UserWithPosts struct {
+//	    *User
+//	    Posts []*post.Post
+//	}
+//	var users []*UserWithPosts
+//	err := user.ORM.SelectAll().Preload("Posts").QueryAggregate(ctx, &users)
+func (c *ORMSelectBuilder[T, P]) QueryAggregate(ctx context.Context, result interface{}) error {
+	slice := reflect.ValueOf(result)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice ||
+		slice.Elem().Type().Elem().Kind() != reflect.Ptr || slice.Elem().Type().Elem().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("QueryAggregate requires a pointer to a slice of struct pointers, got %T", result)
+	}
+	aggType := slice.Elem().Type().Elem().Elem()
+
+	modelType := reflect.TypeOf((*T)(nil)).Elem()
+	modelIndex := -1
+	for i := 0; i < aggType.NumField(); i++ {
+		f := aggType.Field(i)
+		if f.Anonymous && f.IsExported() && (f.Type == modelType || f.Type == reflect.PtrTo(modelType)) {
+			modelIndex = i
+			break
+		}
+	}
+	if modelIndex < 0 {
+		return fmt.Errorf("aggregate %s must embed the exported %s", aggType, modelType)
+	}
+
+	type preload struct {
+		rel   Relation
+		index int
+	}
+	preloads := make([]preload, 0, len(c.preloads))
+	for _, name := range c.preloads {
+		rel, ok := c.orm.lookupRelation(name)
+		if !ok {
+			return fmt.Errorf("relation %s not registered on %s", name, c.orm.table.Name())
+		}
+		f, ok := aggType.FieldByName(name)
+		if !ok || len(f.Index) != 1 || !f.IsExported() {
+			return fmt.Errorf("aggregate %s is missing the field of relation %s", aggType, name)
+		}
+		expected := rel.target()
+		if rel.many() {
+			expected = reflect.SliceOf(expected)
+		}
+		if f.Type != expected {
+			return fmt.Errorf("field %s of aggregate %s must be %s, got %s", name, aggType, expected, f.Type)
+		}
+		preloads = append(preloads, preload{rel: rel, index: f.Index[0]})
+	}
+
+	records, err := c.Query(ctx)
+	if err != nil {
+		return err
+	}
+	aggs := reflect.MakeSlice(slice.Elem().Type(), len(records), len(records))
+	for i, record := range records {
+		agg := reflect.New(aggType)
+		if aggType.Field(modelIndex).Type.Kind() == reflect.Ptr {
+			agg.Elem().Field(modelIndex).Set(reflect.ValueOf(record))
+		} else {
+			agg.Elem().Field(modelIndex).Set(reflect.ValueOf(record).Elem())
+		}
+		aggs.Index(i).Set(agg)
+	}
+
+	pkColumn := c.orm.describe().pkColumn
+	for _, p := range preloads {
+		keyIndex, err := modelIDIndex[T](c.orm.opts.naming, p.rel.localKey(pkColumn))
+		if err != nil {
+			return err
+		}
+		keys := make([]int64, len(records))
+		var uniqueKeys []int64
+		seen := make(map[int64]bool, len(records))
+		for i, record := range records {
+			keys[i] = reflect.ValueOf(record).Elem().Field(keyIndex).Int()
+			if keys[i] != 0 && !seen[keys[i]] {
+				seen[keys[i]] = true
+				uniqueKeys = append(uniqueKeys, keys[i])
+			}
+		}
+		if len(uniqueKeys) == 0 {
+			continue
+		}
+		grouped, err := p.rel.load(ctx, uniqueKeys)
+		if err != nil {
+			return err
+		}
+		for i, key := range keys {
+			related := grouped[key]
+			if len(related) == 0 {
+				continue
+			}
+			fv := aggs.Index(i).Elem().Field(p.index)
+			if !p.rel.many() {
+				fv.Set(related[0])
+				continue
+			}
+			fv.Set(reflect.Append(reflect.MakeSlice(fv.Type(), 0, len(related)), related...))
+		}
+	}
+	slice.Elem().Set(aggs)
+	return nil
+}
@@ -0,0 +1,117 @@
+package orm
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/table"
+)
+
+// aggregates embed exported models
+type (
+	JoinUser = joinUser
+	JoinPost = joinPost
+)
+
+type joinUserWithPosts struct {
+	*JoinUser
+	Posts []*joinPost
+}
+
+type joinPostWithAuthor struct {
+	JoinPost
+	Author *joinUser
+}
+
+func newRelationTestORMs(queries *[]string) (*ORM[joinUser, joinUserOptional], *ORM[joinPost, joinPostOptional]) {
+	userTable := table.New("users")
+	userTable.Int64("id")
+	userTable.String("name")
+	postTable := table.New("posts")
+	postTable.Int64("id")
+	postUserID := postTable.Int64("user_id")
+	postTable.String("title")
+
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			*queries = append(*queries, sql)
+			switch rows := result.(type) {
+			case *[]*joinUser:
+				*rows = []*joinUser{{Id: 1, Name: "Alice"}, {Id: 2, Name: "Bob"}}
+			case *[]*joinPost:
+				*rows = []*joinPost{{Id: 10, UserId: 1, Title: "a"}, {Id: 11, UserId: 1, Title: "b"}, {Id: 12, UserId: 3, Title: "c"}}
+			}
+			return nil
+		},
+	}
+	users := &ORM[joinUser, joinUserOptional]{table: userTable, engine: mockEngine}
+	posts := &ORM[joinPost, joinPostOptional]{table: postTable, engine: mockEngine}
+	users.Relation("Posts", HasMany(posts, postUserID))
+	posts.Relation("Author", BelongsTo(users, postUserID))
+	return users, posts
+}
+
+func TestPreload_HasMany(t *testing.T) {
+	var queries []string
+	users, _ := newRelationTestORMs(&queries)
+
+	var rows []*joinUserWithPosts
+	if err := users.SelectAll().Preload("Posts").QueryAggregate(context.Background(), &rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedQueries := []string{
+		"SELECT `users`.`id`, `users`.`name` FROM `users`",
+		"SELECT `posts`.`id`, `posts`.`user_id`, `posts`.`title` FROM `posts` WHERE `posts`.`user_id` IN (?, ?)",
+	}
+	if strings.Join(queries, "\n") != strings.Join(expectedQueries, "\n") {
+		t.Errorf("Expected queries:\n%s\ngot:\n%s", strings.Join(expectedQueries, "\n"), strings.Join(queries, "\n"))
+	}
+	if len(rows) != 2 || rows[0].Name != "Alice" || len(rows[0].Posts) != 2 || rows[0].Posts[1].Title != "b" {
+		t.Fatalf("Expected Alice with 2 posts, got %+v", rows)
+	}
+	if rows[1].Name != "Bob" || rows[1].Posts != nil {
+		t.Errorf("Expected Bob without posts, got %+v", rows[1])
+	}
+}
+
+func TestPreload_BelongsTo(t *testing.T) {
+	var queries []string
+	_, posts := newRelationTestORMs(&queries)
+
+	var rows []*joinPostWithAuthor
+	if err := posts.SelectAll().Preload("Author").QueryAggregate(context.Background(), &rows); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(queries) != 2 || queries[1] != "SELECT `users`.`id`, `users`.`name` FROM `users` WHERE `users`.`id` IN (?, ?)" {
+		t.Errorf("Expected a single IN query of the authors, got %q", queries)
+	}
+	if len(rows) != 3 || rows[0].Author == nil || rows[0].Author.Name != "Alice" || rows[2].Author != nil {
+		t.Errorf("Expected the authors attached, got %+v", rows)
+	}
+}
+
+func TestPreload_Errors(t *testing.T) {
+	var queries []string
+	users, _ := newRelationTestORMs(&queries)
+	ctx := context.Background()
+
+	var rows []*joinUserWithPosts
+	if err := users.SelectAll().Preload("Comments").QueryAggregate(ctx, &rows); err == nil || !strings.Contains(err.Error(), "relation Comments not registered") {
+		t.Errorf("Expected unregistered relation error, got %v", err)
+	}
+	var wrong []*struct {
+		*JoinUser
+		Posts []joinPost
+	}
+	if err := users.SelectAll().Preload("Posts").QueryAggregate(ctx, &wrong); err == nil || !strings.Contains(err.Error(), "must be []*orm.joinPost") {
+		t.Errorf("Expected field type error, got %v", err)
+	}
+	var unrelated []*joinPostWithAuthor
+	if err := users.SelectAll().QueryAggregate(ctx, &unrelated); err == nil || !strings.Contains(err.Error(), "must embed") {
+		t.Errorf("Expected embedding error, got %v", err)
+	}
+	if len(queries) != 0 {
+		t.Errorf("Expected no queries on invalid aggregates, got %q", queries)
+	}
+}
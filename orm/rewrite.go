@@ -0,0 +1,91 @@
+package orm
+
+import (
+	"context"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/sql"
+)
+
+// WithSQLRewriter sets a hook rewriting each statement executed by the
+// ORM just before execution, after the comment of WithComment is
+// appended, e.g. to inject shard comments, rewrite schemas or switch
+// blue/green tables without forking the builders.
+func WithSQLRewriter(rewrite func(sql string, args []interface{}) (string, []interface{})) Option {
+	return func(opts *options) {
+		opts.sqlRewriter = rewrite
+	}
+}
+
+// hasHooks reports whether statements are rewritten before execution,
+// see WithComment and WithSQLRewriter
+func (opts *options) hasHooks() bool {
+	return opts.comment != nil || opts.sqlRewriter != nil
+}
+
+// wrapEngine wraps eng to rewrite statements before execution,
+// see WithComment and WithSQLRewriter
+func (opts *options) wrapEngine(eng engine.Engine) engine.Engine {
+	if rewrite := opts.sqlRewriter; rewrite != nil {
+		eng = withRewrite(eng, func(ctx context.Context, query string, args []interface{}) (string, []interface{}) {
+			return rewrite(query, args)
+		})
+	}
+	if comment := opts.comment; comment != nil {
+		eng = withRewrite(eng, func(ctx context.Context, query string, args []interface{}) (string, []interface{}) {
+			return sql.AppendComment(query, comment(ctx)), args
+		})
+	}
+	return eng
+}
+
+// hookFactory wraps the engines of factory to rewrite statements
+type hookFactory struct {
+	factory engine.Factory
+	opts    options
+}
+
+func (f hookFactory) GetEngine() engine.Engine {
+	return f.opts.wrapEngine(f.factory.GetEngine())
+}
+
+// withRewrite wraps eng to rewrite each statement
+func withRewrite(eng engine.Engine, rewrite func(ctx context.Context, query string, args []interface{}) (string, []interface{})) engine.Engine {
+	r := rewriteEngine{engine: eng, rewrite: rewrite}
+	if execer, ok := eng.(engine.AffectedExecer); ok {
+		return rewriteAffectedEngine{rewriteEngine: r, execer: execer}
+	}
+	return r
+}
+
+// rewriteEngine rewrites each statement before executing it
+type rewriteEngine struct {
+	engine  engine.Engine
+	rewrite func(ctx context.Context, query string, args []interface{}) (string, []interface{})
+}
+
+func (e rewriteEngine) Query(ctx context.Context, query string, args []interface{}, result interface{}) error {
+	query, args = e.rewrite(ctx, query, args)
+	return e.engine.Query(ctx, query, args, result)
+}
+
+func (e rewriteEngine) Exec(ctx context.Context, query string, args []interface{}) error {
+	query, args = e.rewrite(ctx, query, args)
+	return e.engine.Exec(ctx, query, args)
+}
+
+func (e rewriteEngine) ExecInsert(ctx context.Context, query string, args []interface{}) (int64, error) {
+	query, args = e.rewrite(ctx, query, args)
+	return e.engine.ExecInsert(ctx, query, args)
+}
+
+// rewriteAffectedEngine keeps engine.AffectedExecer of the wrapped engine
+type rewriteAffectedEngine struct {
+	rewriteEngine
+	execer engine.AffectedExecer
+}
+
+func (e rewriteAffectedEngine) ExecAffected(ctx context.Context, query string, args []interface{}) (int64, error) {
+	query, args = e.rewrite(ctx, query, args)
+	return e.execer.ExecAffected(ctx, query, args)
+}
@@ -0,0 +1,46 @@
+package orm
+
+import (
+	"context"
+
+	"github.com/xhd2015/arc-orm/field"
+)
+
+// WithDefaultScope appends the conditions returned by scope for the context
+// to every SELECT, UPDATE and DELETE the ORM builds, e.g. the tenant of the
+// request, preventing cross-tenant access by construction.
+// Inserts and raw SQL of QuerySQL are not scoped, and InsertOrUpdate is
+// rejected since it may update a row of another scope. Use Unscoped to
+// bypass the scope explicitly.
+// Example:
+//
+//	orm.WithDefaultScope(func(ctx context.Context) []field.Expr {
+//	    return []field.Expr{TenantID.Eq(tenantFromContext(ctx))}
+//	})
+func WithDefaultScope(scope func(ctx context.Context) []field.Expr) Option {
+	return func(opts *options) {
+		opts.defaultScope = scope
+	}
+}
+
+// Unscoped returns a copy of the ORM without the default scope,
+// for the rare queries that must span all scopes, e.g. admin tools
+func (o *ORM[T, P]) Unscoped() *ORM[T, P] {
+	c := *o
+	c.opts.defaultScope = nil
+	return &c
+}
+
+// scoped returns the conditions with the default scope of ctx appended
+func (o *ORM[T, P]) scoped(ctx context.Context, conditions []field.Expr) []field.Expr {
+	if o.opts.defaultScope == nil {
+		return conditions
+	}
+	scope := o.opts.defaultScope(ctx)
+	if len(scope) == 0 {
+		return conditions
+	}
+	all := make([]field.Expr, 0, len(conditions)+len(scope))
+	all = append(all, conditions...)
+	return append(all, scope...)
+}
@@ -0,0 +1,146 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sql"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+type tenantKey struct{}
+
+type tenantPost struct {
+	Id       int64
+	TenantId int64
+	Title    string
+}
+
+type tenantPostOptional struct {
+	Id       *int64
+	TenantId *int64
+	Title    *string
+}
+
+// TestWithDefaultScope tests that selects, updates and deletes are restricted to the tenant of the context
+func TestWithDefaultScope(t *testing.T) {
+	posts := table.New("posts")
+	id := posts.Int64("id")
+	tenantID := posts.Int64("tenant_id")
+	title := posts.String("title")
+
+	var queries []string
+	mockEngine := &MockQueryEngine{QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+		queries = append(queries, sql)
+		return nil
+	}}
+	orm, err := bind[tenantPost, tenantPostOptional](mockEngine, posts, WithDefaultScope(func(ctx context.Context) []field.Expr {
+		tenant, ok := ctx.Value(tenantKey{}).(int64)
+		if !ok {
+			return nil
+		}
+		return []field.Expr{tenantID.Eq(tenant)}
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, int64(7))
+	if _, err := orm.SelectAll().Where(title.Like("%go%")).Query(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := orm.Sum(ctx, id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	newTitle := "Go"
+	if err := orm.UpdateByID(ctx, 1, &tenantPostOptional{Title: &newTitle}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := orm.Update().Set(title, sql.String("Rust")).Where(id.Eq(2)).Exec(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := orm.DeleteByID(ctx, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// no tenant in the context, no scope
+	if _, err := orm.SelectAll().Query(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// explicitly unscoped
+	if err := orm.Unscoped().DeleteByID(ctx, 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedQueries := []string{
+		"SELECT `posts`.`id`, `posts`.`tenant_id`, `posts`.`title` FROM `posts` WHERE `posts`.`title` LIKE ? AND `posts`.`tenant_id` = ?",
+		"SELECT SUM(`posts`.`id`) AS `value` FROM `posts` WHERE `posts`.`tenant_id` = ?",
+		"SELECT `posts`.`id`, `posts`.`tenant_id`, `posts`.`title` FROM `posts`",
+	}
+	assertStrings(t, "queries", queries, expectedQueries)
+
+	var execs []string
+	for _, call := range mockEngine.ExecCalls {
+		execs = append(execs, call.SQL)
+	}
+	expectedExecs := []string{
+		"UPDATE `posts` SET `title`=? WHERE `posts`.`id` = ? AND `posts`.`tenant_id` = ?",
+		"UPDATE `posts` SET `title`=? WHERE `posts`.`id` = ? AND `posts`.`tenant_id` = ?",
+		"DELETE FROM `posts` WHERE `posts`.`id` = ? AND `posts`.`tenant_id` = ?",
+		"DELETE FROM `posts` WHERE `posts`.`id` = ?",
+	}
+	assertStrings(t, "execs", execs, expectedExecs)
+}
+
+// TestWithDefaultScope_InsertOrUpdate tests that upserts are rejected under an active scope
+func TestWithDefaultScope_InsertOrUpdate(t *testing.T) {
+	posts := table.New("posts")
+	posts.Int64("id")
+	tenantID := posts.Int64("tenant_id")
+	posts.String("title")
+
+	mockEngine := &MockEngine{}
+	orm, err := bind[tenantPost, tenantPostOptional](mockEngine, posts, WithDefaultScope(func(ctx context.Context) []field.Expr {
+		tenant, ok := ctx.Value(tenantKey{}).(int64)
+		if !ok {
+			return nil
+		}
+		return []field.Expr{tenantID.Eq(tenant)}
+	}))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, int64(7))
+	newTitle := "Go"
+	post := &tenantPost{TenantId: 7, Title: "Rust"}
+	if _, err := orm.InsertOrUpdate(ctx, post, &tenantPostOptional{Title: &newTitle}); !errors.Is(err, ErrScopedUpsert) {
+		t.Fatalf("Expected ErrScopedUpsert, got %v", err)
+	}
+	if len(mockEngine.ExecInsertCalls) != 0 {
+		t.Fatalf("Expected nothing executed, got %v", mockEngine.ExecInsertCalls)
+	}
+	if _, err := orm.Unscoped().InsertOrUpdate(ctx, post, &tenantPostOptional{Title: &newTitle}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// no tenant in the context, no scope
+	if _, err := orm.InsertOrUpdate(context.Background(), post, &tenantPostOptional{Title: &newTitle}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mockEngine.ExecInsertCalls) != 2 {
+		t.Errorf("Expected 2 upserts, got %d", len(mockEngine.ExecInsertCalls))
+	}
+}
+
+func assertStrings(t *testing.T, name string, got []string, expected []string) {
+	t.Helper()
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d %s, got %d: %v", len(expected), name, len(got), got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Expected %s[%d]:\n%s\ngot:\n%s", name, i, expected[i], got[i])
+		}
+	}
+}
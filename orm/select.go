@@ -3,6 +3,7 @@ package orm
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/xhd2015/arc-orm/field"
 	"github.com/xhd2015/arc-orm/sql"
@@ -12,18 +13,53 @@ import (
 type ORMSelectBuilder[T any, P any] struct {
 	builder *sql.SelectBuilder
 	orm     *ORM[T, P]
+	err     error
+	// all is set by SelectAll, see WithLazy
+	all bool
+	// preloads are the relations loaded by QueryAggregate, see Preload
+	preloads []string
 }
 
+// SelectAll selects all the fields of the table, except the ones
+// declared lazy like Table.String("body").Lazy(), see WithLazy.
+// Lookups like GetByID and FindByID select the lazy fields too.
 func (c *ORM[T, P]) SelectAll() *ORMSelectBuilder[T, P] {
 	return &ORMSelectBuilder[T, P]{
-		builder: sql.Select(fieldsToExprs(c.table.Fields())...).From(c.table.Name()),
+		builder: c.newSelect(fieldsToExprs(eagerFields(c.table.Fields()))...),
 		orm:     c,
+		all:     true,
 	}
 }
 
+// WithLazy makes SelectAll select the lazy fields too
+func (c *ORMSelectBuilder[T, P]) WithLazy() *ORMSelectBuilder[T, P] {
+	if c.all {
+		c.builder.Fields(fieldsToExprs(c.orm.table.Fields())...)
+	}
+	return c
+}
+
+// eagerFields returns the fields not declared lazy
+func eagerFields(fields []field.Field) []field.Field {
+	eager := make([]field.Field, 0, len(fields))
+	for _, f := range fields {
+		if isLazy(f) {
+			continue
+		}
+		eager = append(eager, f)
+	}
+	return eager
+}
+
+// isLazy tells if the field is declared lazy
+func isLazy(f field.Field) bool {
+	lazy, ok := f.(field.LazyLoaded)
+	return ok && lazy.IsLazy()
+}
+
 func (c *ORM[T, P]) Select(fields ...field.Field) *ORMSelectBuilder[T, P] {
 	return &ORMSelectBuilder[T, P]{
-		builder: sql.Select(fieldsToExprs(fields)...).From(c.table.Name()),
+		builder: c.newSelect(fieldsToExprs(fields)...),
 		orm:     c,
 	}
 }
@@ -36,7 +72,7 @@ func (c *ORM[T, P]) Select(fields ...field.Field) *ORMSelectBuilder[T, P] {
 //	orm.SelectExpr(sql.Date(field).As("date"), sql.Count(sql.All).As("count"))
 func (c *ORM[T, P]) SelectExpr(exprs ...sql.Expr) *ORMSelectBuilder[T, P] {
 	return &ORMSelectBuilder[T, P]{
-		builder: sql.Select(exprs...).From(c.table.Name()),
+		builder: c.newSelect(exprs...),
 		orm:     c,
 	}
 }
@@ -59,8 +95,18 @@ func (c *ORMSelectBuilder[T, P]) Where(conditions ...field.Expr) *ORMSelectBuild
 	return c
 }
 
+// Join joins the table given by its logical name, prefixed like
+// the ORM table, see WithTablePrefix
+func (c *ORMSelectBuilder[T, P]) Join(tableName string, condition field.Expr) *ORMSelectBuilder[T, P] {
+	physical, alias := c.orm.joinTable(tableName)
+	c.builder.JoinAs(physical, alias, condition)
+	return c
+}
+
+// LeftJoin left joins the table given by its logical name, see Join
 func (c *ORMSelectBuilder[T, P]) LeftJoin(tableName string, condition field.Expr) *ORMSelectBuilder[T, P] {
-	c.builder.LeftJoin(tableName, condition)
+	physical, alias := c.orm.joinTable(tableName)
+	c.builder.LeftJoinAs(physical, alias, condition)
 	return c
 }
 
@@ -74,6 +120,24 @@ func (c *ORMSelectBuilder[T, P]) Having(conditions ...field.Expr) *ORMSelectBuil
 	return c
 }
 
+// Comment sets a comment appended to the SQL, see sql.SelectBuilder.Comment
+func (c *ORMSelectBuilder[T, P]) Comment(comment string) *ORMSelectBuilder[T, P] {
+	c.builder.Comment(comment)
+	return c
+}
+
+// Partition restricts the query to the given partitions, see sql.SelectBuilder.Partition
+func (c *ORMSelectBuilder[T, P]) Partition(partitions ...string) *ORMSelectBuilder[T, P] {
+	c.builder.Partition(partitions...)
+	return c
+}
+
+// Hint adds MySQL optimizer hints, see sql.SelectBuilder.Hint
+func (c *ORMSelectBuilder[T, P]) Hint(hints ...string) *ORMSelectBuilder[T, P] {
+	c.builder.Hint(hints...)
+	return c
+}
+
 func (c *ORMSelectBuilder[T, P]) OrderBy(orderFields ...expr.Expr) *ORMSelectBuilder[T, P] {
 	c.builder.OrderBy(orderFields...)
 	return c
@@ -89,17 +153,56 @@ func (c *ORMSelectBuilder[T, P]) Offset(offset int) *ORMSelectBuilder[T, P] {
 	return c
 }
 
+// Clone returns a copy of the builder, so that a base query
+// can be forked per variant without mutating shared state
+func (c *ORMSelectBuilder[T, P]) Clone() *ORMSelectBuilder[T, P] {
+	return &ORMSelectBuilder[T, P]{
+		builder:  c.builder.Clone(),
+		orm:      c.orm,
+		err:      c.err,
+		all:      c.all,
+		preloads: append([]string(nil), c.preloads...),
+	}
+}
+
 func (c *ORMSelectBuilder[T, P]) Query(ctx context.Context) ([]*T, error) {
-	sql, args, err := c.builder.SQL()
+	sql, args, limited, err := c.limitedSQL(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results, err := c.orm.QuerySQL(ctx, sql, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.orm.checkRows(limited, len(results)); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// QueryOptional executes the query scanning the records into the
+// optional model P, so the fields of columns not selected, e.g. by
+// Select(a, b), stay nil instead of looking like zero values
+func (c *ORMSelectBuilder[T, P]) QueryOptional(ctx context.Context) ([]*P, error) {
+	sql, args, limited, err := c.limitedSQL(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return c.orm.QuerySQL(ctx, sql, args)
+	var results []*P
+	if err := c.orm.getEngine(ctx).Query(ctx, sql, args, &results); err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	if err := c.orm.checkRows(limited, len(results)); err != nil {
+		return nil, err
+	}
+	return results, nil
 }
 
+// QueryOne returns the first record of the query, nil if there is none.
+// The limit is applied to a clone, leaving the builder reusable.
 func (c *ORMSelectBuilder[T, P]) QueryOne(ctx context.Context) (*T, error) {
-	c.builder.Limit(1)
-	sql, args, err := c.builder.SQL()
+	c = c.Clone().Limit(1)
+	sql, args, err := c.scopedSQL(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -113,15 +216,35 @@ func (c *ORMSelectBuilder[T, P]) QueryOne(ctx context.Context) (*T, error) {
 	return list[0], nil
 }
 
+// RequireOne returns the first record of the query, or a *NotFoundError
+// wrapping ErrNotFound if there is none, leaving the builder reusable like QueryOne
 func (c *ORMSelectBuilder[T, P]) RequireOne(ctx context.Context) (*T, error) {
-	result, err := c.QueryOne(ctx)
+	c = c.Clone().Limit(1)
+	builder, err := c.scopedBuilder(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if result == nil {
-		return nil, fmt.Errorf("record not found")
+	sql, args, err := builder.SQL()
+	if err != nil {
+		return nil, err
 	}
-	return result, nil
+	list, err := c.orm.QuerySQL(ctx, sql, args)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		conditions, _, err := builder.WhereSQL()
+		if err != nil {
+			return nil, err
+		}
+		return nil, &NotFoundError{
+			Table:      c.orm.physicalTable(),
+			Conditions: conditions,
+			sql:        sql,
+			args:       args,
+		}
+	}
+	return list[0], nil
 }
 
 // QueryInto executes the query and scans results into the provided slice pointer.
@@ -137,9 +260,66 @@ func (c *ORMSelectBuilder[T, P]) RequireOne(ctx context.Context) (*T, error) {
 //	err := orm.SelectExpr(sql.Date(field), sql.Count(sql.All).As("count")).
 //	    Where(...).GroupBy(sql.Date(field)).QueryInto(ctx, &results)
 func (c *ORMSelectBuilder[T, P]) QueryInto(ctx context.Context, result interface{}) error {
-	sqlStr, args, err := c.builder.SQL()
+	sqlStr, args, limited, err := c.limitedSQL(ctx)
 	if err != nil {
 		return err
 	}
-	return c.orm.engine.GetEngine().Query(ctx, sqlStr, args, result)
+	if err := c.orm.getEngine(ctx).Query(ctx, sqlStr, args, result); err != nil {
+		return err
+	}
+	if v := reflect.ValueOf(result); limited && v.Kind() == reflect.Ptr && v.Elem().Kind() == reflect.Slice {
+		return c.orm.checkRows(limited, v.Elem().Len())
+	}
+	return nil
+}
+
+// scopedSQL generates the SQL with the default scope of ctx, see WithDefaultScope
+func (c *ORMSelectBuilder[T, P]) scopedSQL(ctx context.Context) (string, []interface{}, error) {
+	builder, err := c.scopedBuilder(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	return builder.SQL()
+}
+
+// limitedSQL is scopedSQL with the limit of WithMaxRows
+// applied, reporting whether it was
+func (c *ORMSelectBuilder[T, P]) limitedSQL(ctx context.Context) (string, []interface{}, bool, error) {
+	builder, err := c.scopedBuilder(ctx)
+	if err != nil {
+		return "", nil, false, err
+	}
+	builder, limited := c.orm.limitRows(builder)
+	sql, args, err := builder.SQL()
+	return sql, args, limited, err
+}
+
+// limitRows limits a SELECT without LIMIT to one row
+// more than allowed by WithMaxRows, reporting whether it did
+func (o *ORM[T, P]) limitRows(builder *sql.SelectBuilder) (*sql.SelectBuilder, bool) {
+	if o.opts.maxRows <= 0 || builder.HasLimit() {
+		return builder, false
+	}
+	return builder.Clone().Limit(o.opts.maxRows + 1), true
+}
+
+// checkRows fails with ErrTooManyRows if a query
+// limited by limitRows returned more rows than allowed
+func (o *ORM[T, P]) checkRows(limited bool, n int) error {
+	if limited && n > o.opts.maxRows {
+		return fmt.Errorf("%w: more than %d rows of %s, add a Limit", ErrTooManyRows, o.opts.maxRows, o.physicalTable())
+	}
+	return nil
+}
+
+// scopedBuilder returns the builder with the default scope of ctx applied
+func (c *ORMSelectBuilder[T, P]) scopedBuilder(ctx context.Context) (*sql.SelectBuilder, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	builder := c.builder
+	if scope := c.orm.scoped(ctx, nil); len(scope) > 0 {
+		builder = builder.Clone().Where(scope...)
+	}
+	return builder, nil
 }
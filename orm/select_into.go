@@ -0,0 +1,64 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/sql"
+)
+
+// SelectIntoQuery runs a select query scanning rows into R, see SelectInto
+type SelectIntoQuery[R any] struct {
+	engine  engine.Factory
+	builder *sql.SelectBuilder
+}
+
+// SelectInto runs any select query and scans the rows into the
+// caller-defined struct R by matching columns and aliases to the
+// fields of R, useful for aggregations and projections that do not
+// map to a table model.
+// Example:
+//
+//	type PostCount struct {
+//	    UserId int64
+//	    Count  int64
+//	}
+//	counts, err := orm.SelectInto[PostCount](engine, sql.
+//	    Select(post.UserId, sql.Count(sql.All).As("count")).
+//	    From(post.Table.Name()).
+//	    GroupBy(post.UserId)).Query(ctx)
+func SelectInto[R any](eng engine.Factory, builder *sql.SelectBuilder) *SelectIntoQuery[R] {
+	return &SelectIntoQuery[R]{
+		engine:  eng,
+		builder: builder,
+	}
+}
+
+// Query executes the query and returns all rows
+func (q *SelectIntoQuery[R]) Query(ctx context.Context) ([]*R, error) {
+	sqlStr, args, err := q.builder.SQL()
+	if err != nil {
+		return nil, err
+	}
+	var results []*R
+	err = q.engine.GetEngine().Query(ctx, sqlStr, args, &results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	return results, nil
+}
+
+// QueryOne executes the query with limit 1, returns nil if there is no row.
+// The limit is applied to a clone, leaving the builder reusable.
+func (q *SelectIntoQuery[R]) QueryOne(ctx context.Context) (*R, error) {
+	limited := &SelectIntoQuery[R]{engine: q.engine, builder: q.builder.Clone().Limit(1)}
+	results, err := limited.Query(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+	return results[0], nil
+}
@@ -0,0 +1,79 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/sql"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+type postCount struct {
+	UserId int64
+	Count  int64
+}
+
+func TestSelectInto(t *testing.T) {
+	postTable := table.New("posts")
+	postUserID := postTable.Int64("user_id")
+
+	var gotSQL string
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			gotSQL = sql
+			results, ok := result.(*[]*postCount)
+			if !ok {
+				t.Fatalf("Expected result to be *[]*postCount, got %T", result)
+			}
+			*results = []*postCount{{UserId: 1, Count: 3}}
+			return nil
+		},
+	}
+
+	counts, err := SelectInto[postCount](mockEngine, sql.
+		Select(postUserID, sql.Count(sql.All).As("count")).
+		From(postTable.Name()).
+		GroupBy(postUserID)).Query(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedSQL := "SELECT `posts`.`user_id`, COUNT(*) AS `count` FROM `posts` GROUP BY `posts`.`user_id`"
+	if gotSQL != expectedSQL {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expectedSQL, gotSQL)
+	}
+	if len(counts) != 1 || *counts[0] != (postCount{UserId: 1, Count: 3}) {
+		t.Errorf("Unexpected results: %v", counts)
+	}
+}
+
+func TestSelectInto_QueryOne(t *testing.T) {
+	postTable := table.New("posts")
+	postUserID := postTable.Int64("user_id")
+
+	var gotSQL []string
+	mockEngine := &MockQueryEngine{
+		QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			gotSQL = append(gotSQL, sql)
+			return nil
+		},
+	}
+	query := SelectInto[postCount](mockEngine, sql.Select(postUserID).From(postTable.Name()))
+	one, err := query.QueryOne(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if one != nil {
+		t.Errorf("Expected nil, got %v", one)
+	}
+	// the builder is not limited by QueryOne
+	if _, err := query.Query(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{
+		"SELECT `posts`.`user_id` FROM `posts` LIMIT 1",
+		"SELECT `posts`.`user_id` FROM `posts`",
+	}
+	if len(gotSQL) != len(expected) || gotSQL[0] != expected[0] || gotSQL[1] != expected[1] {
+		t.Errorf("Expected SQL %q, got %q", expected, gotSQL)
+	}
+}
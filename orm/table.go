@@ -0,0 +1,91 @@
+package orm
+
+import (
+	"strings"
+
+	"github.com/xhd2015/arc-orm/sql"
+)
+
+// WithTable returns a shallow copy of the ORM targeting the physical table
+// name, e.g. a monthly table event_202501 of the logical events table.
+// Models are still validated against the table definition, and the
+// physical table is aliased by the logical name in SELECT, UPDATE and
+// DELETE statements, so conditions on the table fields keep working.
+// Schema-qualified tables like analytics.events are aliased by their
+// unqualified name, e.g. FROM `analytics`.`events_2025` AS `events`.
+func (o *ORM[T, P]) WithTable(name string) *ORM[T, P] {
+	c := *o
+	c.physicalName = name
+	return &c
+}
+
+// physicalTable returns the name of the table statements target
+func (o *ORM[T, P]) physicalTable() string {
+	name := o.table.Name()
+	if o.physicalName != "" {
+		name = o.physicalName
+	}
+	return o.prefixed(name)
+}
+
+// prefixed returns the table name with the table prefix,
+// which goes to the table of qualified names like analytics.events
+func (o *ORM[T, P]) prefixed(name string) string {
+	i := strings.LastIndex(name, ".") + 1
+	return name[:i] + o.opts.tablePrefix + name[i:]
+}
+
+// joinTable returns the physical name and alias of a joined table
+// given by its logical name, so join conditions on its fields keep
+// working under a table prefix
+func (o *ORM[T, P]) joinTable(name string) (string, string) {
+	if physical := o.prefixed(name); physical != name {
+		return physical, name
+	}
+	return name, ""
+}
+
+// tableAlias returns the alias of the physical table, which is the
+// logical name if it differs from the physical one, or "". The
+// builders alias qualified names like analytics.events as `events`.
+func (o *ORM[T, P]) tableAlias() string {
+	if name := o.physicalTable(); name != o.table.Name() {
+		return o.table.Name()
+	}
+	return ""
+}
+
+// newSelect creates a SELECT of the exprs from the table
+func (o *ORM[T, P]) newSelect(exprs ...sql.Expr) *sql.SelectBuilder {
+	return sql.Select(exprs...).From(o.physicalTable()).As(o.tableAlias())
+}
+
+// newUpdate creates an UPDATE of the table
+func (o *ORM[T, P]) newUpdate() *sql.UpdateBuilder {
+	builder := sql.Update(o.physicalTable()).As(o.tableAlias())
+	if o.opts.safeMode {
+		builder.RequireWhere()
+	}
+	return builder
+}
+
+// newDelete creates a DELETE from the table
+func (o *ORM[T, P]) newDelete() *sql.DeleteBuilder {
+	builder := sql.DeleteFrom(o.physicalTable()).As(o.tableAlias())
+	if o.opts.safeMode {
+		builder.RequireWhere()
+	}
+	return builder
+}
+
+// newInsert creates an INSERT into the table
+func (o *ORM[T, P]) newInsert() *sql.InsertIntoBuilder {
+	return sql.InsertInto(o.physicalTable())
+}
+
+// columnRef is an unqualified column reference
+type columnRef string
+
+func (c columnRef) ToSQL() (string, []interface{}, error) {
+	return "`" + string(c) + "`", nil, nil
+}
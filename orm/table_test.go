@@ -0,0 +1,49 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/sqltest"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+func TestWithTable(t *testing.T) {
+	events := table.New("events")
+	id := events.Int64("id")
+	events.String("name")
+	events.Int64("age")
+	rec := engine.Recorder(nil)
+	orm, err := bind[TestModel, TestModelOptional](rec, events)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.Background()
+	monthly := orm.WithTable("event_202501")
+	if _, err := monthly.SelectAll().Where(id.Gt(10)).Query(ctx); err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	age := 31
+	if err := monthly.UpdateByID(ctx, 1, &TestModelOptional{Age: &age}); err != nil {
+		t.Fatalf("UpdateByID: %v", err)
+	}
+	if err := monthly.DeleteByID(ctx, 1); err != nil {
+		t.Fatalf("DeleteByID: %v", err)
+	}
+	if _, err := monthly.InsertOrUpdate(ctx, &TestModel{Name: "Alice"}, nil); err != nil {
+		t.Fatalf("InsertOrUpdate: %v", err)
+	}
+	sqltest.AssertExecuted(t, rec, "SELECT `events`.`id`, `events`.`name`, `events`.`age` FROM `event_202501` AS `events` WHERE `events`.`id` > ?", 10)
+	sqltest.AssertExecuted(t, rec, "UPDATE `event_202501` AS `events` SET `age`=? WHERE `events`.`id` = ?", 31, 1)
+	sqltest.AssertExecuted(t, rec, "DELETE `events` FROM `event_202501` AS `events` WHERE `events`.`id` = ?", 1)
+	sqltest.AssertExecuted(t, rec, "INSERT INTO `event_202501` SET `name`=?, `age`=? ON DUPLICATE KEY UPDATE `id`=LAST_INSERT_ID(`id`)", "Alice", 0)
+
+	// the original ORM keeps the logical table
+	rec.Reset()
+	if _, err := orm.SelectAll().Where(id.Gt(10)).Query(ctx); err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	sqltest.AssertExecuted(t, rec, "SELECT `events`.`id`, `events`.`name`, `events`.`age` FROM `events` WHERE `events`.`id` > ?", 10)
+}
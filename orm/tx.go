@@ -0,0 +1,158 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/xhd2015/arc-orm/engine"
+)
+
+// TxOptions are the options of a transaction started by ORM.BeginTx
+type TxOptions = engine.TxOptions
+
+// Isolation levels of TxOptions
+const (
+	DefaultIsolation = engine.DefaultIsolation
+	ReadUncommitted  = engine.ReadUncommitted
+	ReadCommitted    = engine.ReadCommitted
+	RepeatableRead   = engine.RepeatableRead
+	Serializable     = engine.Serializable
+)
+
+// BeginTx starts a transaction with the options on the engine of the
+// ORM, which must implement engine.TxBeginner, e.g. a cheaper read-only
+// transaction for reporting queries:
+//
+//	tx, err := user.ORM.BeginTx(ctx, orm.TxOptions{Isolation: orm.RepeatableRead, ReadOnly: true})
+//	if err != nil {
+//	    return err
+//	}
+//	defer tx.Rollback()
+//	users, err := user.ORM.WithEngine(tx).SelectAll().Query(ctx)
+func (o *ORM[T, P]) BeginTx(ctx context.Context, opts TxOptions) (engine.Tx, error) {
+	eng := o.baseEngine(ctx)
+	beginner, ok := eng.(engine.TxBeginner)
+	if !ok {
+		return nil, fmt.Errorf("engine %T does not support transactions", eng)
+	}
+	tx, err := beginner.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// RetryOptions configures RunInTxWithRetry, the zero value is valid
+type RetryOptions struct {
+	// MaxAttempts is the maximum number of runs of the function, 3 by default
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry, doubled on each
+	// further retry and jittered, 10ms by default
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff, 1s by default
+	MaxDelay time.Duration
+}
+
+// RunInTx runs fn in a transaction of eng, which must implement
+// engine.TxBeginner. The transaction is committed if fn returns nil,
+// and rolled back otherwise. Statements run in the transaction through
+// ORMs bound to tx, e.g. user.ORM.WithEngine(tx).
+func RunInTx(ctx context.Context, eng engine.Factory, fn func(ctx context.Context, tx engine.Tx) error) (err error) {
+	beginner, ok := eng.GetEngine().(engine.TxBeginner)
+	if !ok {
+		return fmt.Errorf("engine %T does not support transactions", eng.GetEngine())
+	}
+	tx, err := beginner.BeginTx(ctx, engine.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+	if err := fn(ctx, tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback: %v)", err, rbErr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// RunInTxWithRetry runs fn in a transaction like RunInTx, running it
+// again in a new transaction, after a jittered exponential backoff, if
+// it fails with a MySQL deadlock (1213) or lock wait timeout (1205),
+// see IsRetryable. fn must be safe to run several times.
+func RunInTxWithRetry(ctx context.Context, eng engine.Factory, opts RetryOptions, fn func(ctx context.Context, tx engine.Tx) error) error {
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	delay := opts.BaseDelay
+	if delay <= 0 {
+		delay = 10 * time.Millisecond
+	}
+	maxDelay := opts.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = time.Second
+	}
+	for attempt := 1; ; attempt++ {
+		err := RunInTx(ctx, eng, fn)
+		if err == nil || attempt >= maxAttempts || !IsRetryable(err) {
+			return err
+		}
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+		// sleep for a random duration in [delay/2, delay)
+		backoff := delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		delay *= 2
+	}
+}
+
+// retryableErrors are the MySQL error numbers of deadlocks
+// and lock wait timeouts
+var retryableErrors = []uint16{1213, 1205}
+
+// IsRetryable reports whether err is a MySQL deadlock (1213) or lock
+// wait timeout (1205), whose transaction can be retried. It recognizes
+// errors with a Number field, like *mysql.MySQLError, and messages
+// like "Error 1213 (40001): Deadlock found ..." in the error chain.
+func IsRetryable(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.ValueOf(err)
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+		if v.Kind() == reflect.Struct {
+			if number := v.FieldByName("Number"); number.IsValid() && number.Kind() == reflect.Uint16 {
+				for _, n := range retryableErrors {
+					if uint16(number.Uint()) == n {
+						return true
+					}
+				}
+			}
+		}
+		for _, n := range retryableErrors {
+			if strings.HasPrefix(err.Error(), fmt.Sprintf("Error %d", n)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,156 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/engine"
+)
+
+// MockTxEngine begins transactions recording their outcome
+type MockTxEngine struct {
+	MockEngine
+	begins    int
+	commits   int
+	rollbacks int
+	// opts are the options of the last transaction
+	opts engine.TxOptions
+}
+
+func (m *MockTxEngine) GetEngine() engine.Engine {
+	return m
+}
+
+func (m *MockTxEngine) BeginTx(ctx context.Context, opts engine.TxOptions) (engine.Tx, error) {
+	m.begins++
+	m.opts = opts
+	return &mockTx{MockEngine: &m.MockEngine, engine: m}, nil
+}
+
+type mockTx struct {
+	*MockEngine
+	engine *MockTxEngine
+}
+
+func (t *mockTx) GetEngine() engine.Engine {
+	return t
+}
+
+func (t *mockTx) Commit() error {
+	t.engine.commits++
+	return nil
+}
+
+func (t *mockTx) Rollback() error {
+	t.engine.rollbacks++
+	return nil
+}
+
+// mysqlError mimics *mysql.MySQLError
+type mysqlError struct {
+	Number  uint16
+	Message string
+}
+
+func (e *mysqlError) Error() string {
+	return fmt.Sprintf("Error %d: %s", e.Number, e.Message)
+}
+
+func TestRunInTx(t *testing.T) {
+	eng := &MockTxEngine{}
+	orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](eng, newTimeTestTable())
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	err = RunInTx(context.Background(), eng, func(ctx context.Context, tx engine.Tx) error {
+		_, err := orm.WithEngine(tx).Insert(ctx, &TestModelWithTime{Name: "Alice"})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if eng.commits != 1 || eng.rollbacks != 0 || len(eng.ExecInsertCalls) != 1 {
+		t.Errorf("Expected the insert committed, got %d commits, %d rollbacks", eng.commits, eng.rollbacks)
+	}
+
+	failure := errors.New("failure")
+	err = RunInTx(context.Background(), eng, func(ctx context.Context, tx engine.Tx) error {
+		return failure
+	})
+	if !errors.Is(err, failure) || eng.rollbacks != 1 {
+		t.Errorf("Expected the failure rolled back, got %v, %d rollbacks", err, eng.rollbacks)
+	}
+
+	if err := RunInTx(context.Background(), &MockEngine{}, func(ctx context.Context, tx engine.Tx) error { return nil }); err == nil {
+		t.Errorf("Expected error for an engine without transactions")
+	}
+}
+
+func TestRunInTxWithRetry(t *testing.T) {
+	eng := &MockTxEngine{}
+	opts := RetryOptions{MaxAttempts: 3, BaseDelay: time.Millisecond}
+
+	runs := 0
+	err := RunInTxWithRetry(context.Background(), eng, opts, func(ctx context.Context, tx engine.Tx) error {
+		runs++
+		if runs == 1 {
+			return fmt.Errorf("update: %w", &mysqlError{Number: 1213, Message: "Deadlock found when trying to get lock"})
+		}
+		if runs == 2 {
+			return errors.New("Error 1205 (HY000): Lock wait timeout exceeded")
+		}
+		return nil
+	})
+	if err != nil || runs != 3 || eng.begins != 3 || eng.commits != 1 || eng.rollbacks != 2 {
+		t.Errorf("Expected success on the third run, got %v after %d runs, %d commits", err, runs, eng.commits)
+	}
+
+	runs = 0
+	err = RunInTxWithRetry(context.Background(), eng, opts, func(ctx context.Context, tx engine.Tx) error {
+		runs++
+		return &mysqlError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+	})
+	if err == nil || runs != 3 {
+		t.Errorf("Expected the deadlock after 3 runs, got %v after %d runs", err, runs)
+	}
+
+	runs = 0
+	err = RunInTxWithRetry(context.Background(), eng, opts, func(ctx context.Context, tx engine.Tx) error {
+		runs++
+		return &mysqlError{Number: 1062, Message: "Duplicate entry"}
+	})
+	if err == nil || runs != 1 {
+		t.Errorf("Expected no retry of a duplicate entry, got %v after %d runs", err, runs)
+	}
+}
+
+func TestBeginTx(t *testing.T) {
+	eng := &MockTxEngine{}
+	orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](eng, newTimeTestTable(), WithComment(func(ctx context.Context) string { return "svc=report" }))
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	tx, err := orm.BeginTx(context.Background(), TxOptions{Isolation: RepeatableRead, ReadOnly: true})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if eng.begins != 1 || eng.opts != (TxOptions{Isolation: RepeatableRead, ReadOnly: true}) {
+		t.Errorf("Expected a read-only repeatable read transaction, got %d begins with %+v", eng.begins, eng.opts)
+	}
+	if err := tx.Commit(); err != nil || eng.commits != 1 {
+		t.Errorf("Expected the transaction committed, got %v", err)
+	}
+
+	plain, err := bind[TestModelWithTime, TestModelWithTimeOptional](&MockEngine{}, newTimeTestTable())
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	if _, err := plain.BeginTx(context.Background(), TxOptions{}); err == nil {
+		t.Errorf("Expected an error for an engine without transactions")
+	}
+}
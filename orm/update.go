@@ -9,7 +9,6 @@ import (
 	"github.com/xhd2015/arc-orm/field"
 	"github.com/xhd2015/arc-orm/sql"
 	"github.com/xhd2015/arc-orm/sql/expr"
-	"github.com/xhd2015/less-gen/strcase"
 )
 
 type ORMUpdateBuilder[T any, P any] struct {
@@ -19,7 +18,7 @@ type ORMUpdateBuilder[T any, P any] struct {
 
 func (c *ORM[T, P]) Update() *ORMUpdateBuilder[T, P] {
 	return &ORMUpdateBuilder[T, P]{
-		builder: sql.Update(c.table.Name()),
+		builder: c.newUpdate(),
 		orm:     c,
 	}
 }
@@ -31,7 +30,153 @@ func (o *ORM[T, P]) UpdateByID(ctx context.Context, id int64, data *P) error {
 		return fmt.Errorf("failed to convert id to condition: %w", err)
 	}
 
-	return o.update(ctx, []field.Expr{idCondition}, data)
+	return o.update(ctx, []field.Expr{idCondition}, []interface{}{id}, data)
+}
+
+// UpdateOption configures UpdateModelByID
+type UpdateOption func(opts *updateOptions)
+
+// updateOptions holds the settings of UpdateModelByID
+type updateOptions struct {
+	skipZero bool
+	include  map[string]bool
+}
+
+// SkipZero skips model fields with zero values, except the
+// columns forced by IncludeColumns
+func SkipZero() UpdateOption {
+	return func(opts *updateOptions) {
+		opts.skipZero = true
+	}
+}
+
+// IncludeColumns forces writing the columns even if their values are zero,
+// the columns are lazy or create_time
+func IncludeColumns(fields ...field.Field) UpdateOption {
+	return func(opts *updateOptions) {
+		if opts.include == nil {
+			opts.include = make(map[string]bool, len(fields))
+		}
+		for _, f := range fields {
+			opts.include[f.Name()] = true
+		}
+	}
+}
+
+// null is the SQL NULL literal written for nil pointer fields
+type null struct{}
+
+// ToSQL implements expr.Expr for NULL
+func (null) ToSQL() (string, []interface{}, error) {
+	return "NULL", nil, nil
+}
+
+// UpdateByKey updates an existing record by its primary key
+// of any type with partial fields, see GetByKey
+func (o *ORM[T, P]) UpdateByKey(ctx context.Context, key interface{}, data *P) error {
+	keyCondition, err := o.toKeyCondition(key)
+	if err != nil {
+		return fmt.Errorf("failed to convert key to condition: %w", err)
+	}
+	return o.update(ctx, []field.Expr{keyCondition}, []interface{}{key}, data)
+}
+
+// UpdateModelByID updates all columns of an existing record by ID from
+// the full model, unlike UpdateByID which only writes the non-nil
+// fields of P. The primary key column is never written, and a zero UpdateTime
+// is set to current time. CreateTime is kept as set by Insert, and so are
+// lazy columns, which SelectAll skips unless WithLazy is called while
+// GetByID and FindByID load them: both are only written if forced by
+// IncludeColumns.
+func (o *ORM[T, P]) UpdateModelByID(ctx context.Context, id int64, model *T, opts ...UpdateOption) error {
+	if model == nil {
+		return fmt.Errorf("requires model, got nil")
+	}
+	idCondition, err := o.toIDCondition(id)
+	if err != nil {
+		return fmt.Errorf("failed to convert id to condition: %w", err)
+	}
+	if err := validateForUpdate(model); err != nil {
+		return err
+	}
+	var options updateOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	builder := o.newUpdate()
+	hasFieldsToUpdate := false
+	var checker constraintChecker
+
+	v := reflect.ValueOf(model).Elem()
+	for _, mf := range o.describe().model {
+		field := v.Field(mf.index)
+		fieldType := mf.field
+
+		// Skip unexported fields and the Count field
+		if !fieldType.IsExported() || mf.isCount {
+			continue
+		}
+
+		fieldName := mf.column
+		tableField := mf.tableField
+		if tableField == nil || fieldName == o.describe().pkColumn {
+			continue
+		}
+
+		if (isLazy(tableField) || fieldName == o.describe().createTimeColumn) && !options.include[fieldName] {
+			continue
+		}
+
+		if fieldType.Name == "UpdateTime" && field.IsZero() {
+			if _, ok := field.Interface().(time.Time); ok {
+				field = reflect.ValueOf(o.now())
+			}
+		}
+		if options.skipZero && field.IsZero() && !options.include[fieldName] {
+			continue
+		}
+
+		var sqlValue expr.Expr
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				sqlValue = null{}
+				checker.check(tableField, nil)
+			} else {
+				sqlValue, _ = toSQLValue(field.Elem())
+				checker.check(tableField, field.Elem().Interface())
+			}
+		} else {
+			sqlValue, _ = toSQLValue(field)
+			checker.check(tableField, field.Interface())
+		}
+		if sqlValue == nil {
+			return fmt.Errorf("failed to convert field %s to SQL value: %s", fieldType.Name, field.Type())
+		}
+
+		builder.Set(tableField, sqlValue)
+		hasFieldsToUpdate = true
+	}
+	if err := checker.err(o.table.Name()); err != nil {
+		return err
+	}
+
+	if !hasFieldsToUpdate {
+		return ErrNothingToUpdate
+	}
+
+	query, args, err := builder.Where(o.scoped(ctx, []field.Expr{idCondition})...).SQL()
+	if err != nil {
+		return fmt.Errorf("failed to build update SQL: %w", err)
+	}
+
+	err = o.getEngine(ctx).Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("failed to execute UpdateModelByID: %w", err)
+	}
+	o.emitWrite(WriteUpdate, []interface{}{id})
+
+	return nil
 }
 
 func (o *ORM[T, P]) UpdateBy(ctx context.Context, condition *P, data *P) error {
@@ -44,10 +189,12 @@ func (o *ORM[T, P]) UpdateBy(ctx context.Context, condition *P, data *P) error {
 		return fmt.Errorf("failed to convert condition to SQL conditions: %w", err)
 	}
 
-	return o.update(ctx, sqlConditions, data)
+	return o.update(ctx, sqlConditions, nil, data)
 }
 
-func (o *ORM[T, P]) update(ctx context.Context, conditions []field.Expr, data *P) error {
+// update updates the records matching the conditions, keys are
+// the primary keys of the records reported to OnWrite, if known
+func (o *ORM[T, P]) update(ctx context.Context, conditions []field.Expr, keys []interface{}, data *P) error {
 	if data == nil {
 		return fmt.Errorf("requires data, got nil")
 	}
@@ -55,17 +202,62 @@ func (o *ORM[T, P]) update(ctx context.Context, conditions []field.Expr, data *P
 		return fmt.Errorf("requires conditions")
 	}
 
+	sets, updateTimeField, err := o.updateSets(data)
+	if err != nil {
+		return err
+	}
+
+	// Check if there are any fields to update
+	if len(sets) == 0 {
+		return ErrNothingToUpdate
+	}
+
 	// Create the SQL Update builder
-	builder := sql.Update(o.table.Name())
+	builder := o.newUpdate()
+	for _, set := range sets {
+		builder.Set(set.field, set.value)
+	}
 
-	// Map struct fields to table fields
-	tableFields := make(map[string]field.Field)
-	for _, f := range o.table.Fields() {
-		tableFields[f.Name()] = f
+	// If we have an UpdateTime field that was nil, add it to the query with current time
+	if updateTimeField != nil {
+		builder.Set(updateTimeField, sql.Time(o.now()))
 	}
 
-	// Flag to track if we have any fields to update
-	hasFieldsToUpdate := false
+	// Add WHERE clause for ID
+	builder.Where(o.scoped(ctx, conditions)...)
+
+	// Generate the SQL and args
+	query, args, err := builder.SQL()
+	if err != nil {
+		return fmt.Errorf("failed to build update SQL: %w", err)
+	}
+
+	// Execute the update
+	err = o.getEngine(ctx).Exec(ctx, query, args)
+	if err != nil {
+		return fmt.Errorf("failed to execute UpdateByID: %w", err)
+	}
+	o.emitWrite(WriteUpdate, keys)
+
+	return nil
+}
+
+// updateSet is a column assignment of an update
+type updateSet struct {
+	field field.Field
+	value expr.Expr
+}
+
+// updateSets converts the non-nil fields of data to column assignments,
+// the returned update time field is non-nil if it should be set to now.
+// The update validation hooks of data run first, and values breaking
+// field constraints are reported as *ValidationError.
+func (o *ORM[T, P]) updateSets(data *P) ([]updateSet, field.Field, error) {
+	if err := validateForUpdate(data); err != nil {
+		return nil, nil, err
+	}
+	var sets []updateSet
+	var checker constraintChecker
 
 	// Check if the model has an UpdateTime field and if it's nil
 	shouldAddUpdateTime := false
@@ -74,12 +266,11 @@ func (o *ORM[T, P]) update(ctx context.Context, conditions []field.Expr, data *P
 
 	// Use reflection to extract non-nil fields from the partialModel
 	v := reflect.ValueOf(data).Elem()
-	t := v.Type()
 
 	// Iterate through the struct fields and add them to the builder
-	for i := 0; i < v.NumField(); i++ {
-		field := v.Field(i)
-		fieldType := t.Field(i)
+	for _, mf := range o.describe().optional {
+		field := v.Field(mf.index)
+		fieldType := mf.field
 
 		// Skip unexported fields
 		if !fieldType.IsExported() {
@@ -89,8 +280,7 @@ func (o *ORM[T, P]) update(ctx context.Context, conditions []field.Expr, data *P
 		// Special handling for UpdateTime
 		if fieldType.Name == "UpdateTime" {
 			hasUpdateTimeField = true
-			fieldName := strcase.CamelToSnake(fieldType.Name)
-			updateTimeField = tableFields[fieldName]
+			updateTimeField = mf.tableField
 
 			// If the field is nil, we should add update_time to the query
 			if field.Kind() == reflect.Ptr && field.IsNil() {
@@ -111,12 +301,9 @@ func (o *ORM[T, P]) update(ctx context.Context, conditions []field.Expr, data *P
 		}
 		fieldValue := fieldRValue.Interface()
 
-		// Convert field name to snake_case
-		fieldName := strcase.CamelToSnake(fieldType.Name)
-
 		// Get the corresponding table field
-		tableField, exists := tableFields[fieldName]
-		if !exists {
+		tableField := mf.tableField
+		if tableField == nil {
 			continue // Skip fields not in the table
 		}
 
@@ -139,6 +326,10 @@ func (o *ORM[T, P]) update(ctx context.Context, conditions []field.Expr, data *P
 			if t, ok := fieldValue.(time.Time); ok {
 				sqlValue = sql.Time(t)
 			}
+		case reflect.Array:
+			if u, ok := uuidSQL(fieldValue); ok {
+				sqlValue = u
+			}
 		}
 
 		// Skip if we couldn't convert the value
@@ -146,41 +337,39 @@ func (o *ORM[T, P]) update(ctx context.Context, conditions []field.Expr, data *P
 			continue
 		}
 
-		// Add to the builder
-		builder.Set(tableField, sqlValue)
-		hasFieldsToUpdate = true
+		checker.check(tableField, fieldValue)
+		sets = append(sets, updateSet{field: tableField, value: sqlValue})
 	}
-
-	// Check if there are any fields to update
-	if !hasFieldsToUpdate {
-		return ErrNothingToUpdate
+	if err := checker.err(o.table.Name()); err != nil {
+		return nil, nil, err
 	}
 
-	// If we have an UpdateTime field that was nil, add it to the query with current time
-	if hasUpdateTimeField && shouldAddUpdateTime {
-		builder.Set(updateTimeField, sql.Time(time.Now()))
+	if !hasUpdateTimeField || !shouldAddUpdateTime || updateTimeField == nil {
+		return sets, nil, nil
 	}
+	return sets, updateTimeField, nil
+}
 
-	// Add WHERE clause for ID
-	builder.Where(conditions...)
-
-	// Generate the SQL and args
-	query, args, err := builder.SQL()
-	if err != nil {
-		return fmt.Errorf("failed to build update SQL: %w", err)
-	}
+func (c *ORMUpdateBuilder[T, P]) Set(f field.Field, value expr.Expr) *ORMUpdateBuilder[T, P] {
+	c.builder.Set(f, value)
+	return c
+}
 
-	// Execute the update
-	err = o.engine.GetEngine().Exec(ctx, query, args)
-	if err != nil {
-		return fmt.Errorf("failed to execute UpdateByID: %w", err)
-	}
+// Comment sets a comment appended to the SQL, see sql.UpdateBuilder.Comment
+func (c *ORMUpdateBuilder[T, P]) Comment(comment string) *ORMUpdateBuilder[T, P] {
+	c.builder.Comment(comment)
+	return c
+}
 
-	return nil
+// Partition restricts the update to the given partitions, see sql.UpdateBuilder.Partition
+func (c *ORMUpdateBuilder[T, P]) Partition(partitions ...string) *ORMUpdateBuilder[T, P] {
+	c.builder.Partition(partitions...)
+	return c
 }
 
-func (c *ORMUpdateBuilder[T, P]) Set(f field.Field, value expr.Expr) *ORMUpdateBuilder[T, P] {
-	c.builder.Set(f, value)
+// SetIf sets the field only when cond is true, see sql.UpdateBuilder.SetIf
+func (c *ORMUpdateBuilder[T, P]) SetIf(cond bool, f field.Field, value expr.Expr) *ORMUpdateBuilder[T, P] {
+	c.builder.SetIf(cond, f, value)
 	return c
 }
 
@@ -189,10 +378,38 @@ func (c *ORMUpdateBuilder[T, P]) Where(conditions ...expr.Expr) *ORMUpdateBuilde
 	return c
 }
 
+// AllowFullTable allows the update without WHERE condition
+// under WithSafeMode, see sql.UpdateBuilder.AllowFullTable
+func (c *ORMUpdateBuilder[T, P]) AllowFullTable() *ORMUpdateBuilder[T, P] {
+	c.builder.AllowFullTable()
+	return c
+}
+
+// Clone returns a copy of the builder, see ORMSelectBuilder.Clone
+func (c *ORMUpdateBuilder[T, P]) Clone() *ORMUpdateBuilder[T, P] {
+	return &ORMUpdateBuilder[T, P]{
+		builder: c.builder.Clone(),
+		orm:     c.orm,
+	}
+}
+
 func (c *ORMUpdateBuilder[T, P]) Exec(ctx context.Context) error {
-	sql, args, err := c.builder.SQL()
+	builder := c.builder
+	if scope := c.orm.scoped(ctx, nil); len(scope) > 0 {
+		// the default scope does not count as a condition under WithSafeMode
+		if _, _, err := builder.SQL(); err != nil {
+			return err
+		}
+		builder = builder.Clone().Where(scope...)
+	}
+	sql, args, err := builder.SQL()
 	if err != nil {
 		return err
 	}
-	return c.orm.engine.GetEngine().Exec(ctx, sql, args)
+	err = c.orm.getEngine(ctx).Exec(ctx, sql, args)
+	if err != nil {
+		return err
+	}
+	c.orm.emitWrite(WriteUpdate, nil)
+	return nil
 }
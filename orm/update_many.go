@@ -0,0 +1,137 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sql"
+	"github.com/xhd2015/arc-orm/sql/expr"
+)
+
+// UpdateManyByID updates many records with per-row partial fields in a single
+// statement of the form:
+//
+//	UPDATE t SET col = CASE id WHEN ? THEN ? ... ELSE col END WHERE id IN (...)
+//
+// Like UpdateByID, only non-nil fields of each P are written and a nil
+// UpdateTime is set to current time. Rows not setting a column keep its value.
+// Very large maps are split into chunks of statements.
+func (o *ORM[T, P]) UpdateManyByID(ctx context.Context, updates map[int64]*P) error {
+	if len(updates) == 0 {
+		return nil
+	}
+	idField, err := o.idField()
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int64, 0, len(updates))
+	for id, data := range updates {
+		if data == nil {
+			return fmt.Errorf("requires data of id %d, got nil", id)
+		}
+		ids = append(ids, id)
+	}
+	// sort for deterministic SQL
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for start := 0; start < len(ids); start += idsChunkSize {
+		end := start + idsChunkSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		query, args, err := o.updateManySQL(ctx, idField, ids[start:end], updates)
+		if err != nil {
+			return err
+		}
+		err = o.getEngine(ctx).Exec(ctx, query, args)
+		if err != nil {
+			return fmt.Errorf("failed to execute UpdateManyByID: %w", err)
+		}
+	}
+	o.emitWrite(WriteUpdate, int64Keys(ids))
+	return nil
+}
+
+func (o *ORM[T, P]) updateManySQL(ctx context.Context, idField field.Int64Field, ids []int64, updates map[int64]*P) (string, []interface{}, error) {
+	cases := make(map[string]*caseByID)
+	for _, id := range ids {
+		sets, updateTimeField, err := o.updateSets(updates[id])
+		if err != nil {
+			return "", nil, err
+		}
+		if updateTimeField != nil {
+			sets = append(sets, updateSet{field: updateTimeField, value: sql.Time(o.now())})
+		}
+		for _, set := range sets {
+			c := cases[set.field.Name()]
+			if c == nil {
+				c = &caseByID{id: idField, field: set.field}
+				cases[set.field.Name()] = c
+			}
+			c.whens = append(c.whens, caseWhen{id: id, value: set.value})
+		}
+	}
+	if len(cases) == 0 {
+		return "", nil, ErrNothingToUpdate
+	}
+
+	builder := o.newUpdate()
+	// set columns in the order of the table
+	for _, f := range o.table.Fields() {
+		if c, ok := cases[f.Name()]; ok {
+			builder.Set(f, c)
+		}
+	}
+	query, args, err := builder.Where(o.scoped(ctx, []field.Expr{idField.In(ids...)})...).SQL()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build update SQL: %w", err)
+	}
+	return query, args, nil
+}
+
+// caseByID assigns per-id values to a column, keeping the
+// column unchanged for ids without a value
+type caseByID struct {
+	id    field.Field
+	field field.Field
+	whens []caseWhen
+}
+
+type caseWhen struct {
+	id    int64
+	value expr.Expr
+}
+
+// ToSQL implements expr.Expr
+func (c *caseByID) ToSQL() (string, []interface{}, error) {
+	idSQL, params, err := c.id.ToSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	var b strings.Builder
+	b.WriteString("CASE ")
+	b.WriteString(idSQL)
+	for _, when := range c.whens {
+		valueSQL, valueParams, err := when.value.ToSQL()
+		if err != nil {
+			return "", nil, err
+		}
+		b.WriteString(" WHEN ? THEN ")
+		b.WriteString(valueSQL)
+		params = append(params, when.id)
+		params = append(params, valueParams...)
+	}
+	fieldSQL, fieldParams, err := c.field.ToSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	b.WriteString(" ELSE ")
+	b.WriteString(fieldSQL)
+	b.WriteString(" END")
+	params = append(params, fieldParams...)
+	return b.String(), params, nil
+}
@@ -3,6 +3,7 @@ package orm
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/xhd2015/arc-orm/engine"
 	"github.com/xhd2015/arc-orm/field"
@@ -243,3 +244,151 @@ func (f testFieldExpression) ToSQL() (string, []interface{}, error) {
 }
 
 var _ field.Expr = testFieldExpression{}
+
+func TestUpdateModelByID(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	testTable := table.New("tasks")
+	testTable.Int64("id")
+	name := testTable.String("name")
+	age := testTable.Int64("age")
+	createTime := testTable.Time("create_time")
+	testTable.Time("update_time")
+
+	created := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		model        *TestModelWithTime
+		opts         []UpdateOption
+		expectedSQL  string
+		expectedArgs []interface{}
+	}{
+		{
+			name:         "all columns",
+			model:        &TestModelWithTime{Id: 1, Name: "Alice", CreateTime: created},
+			expectedSQL:  "UPDATE `tasks` SET `name`=?, `age`=?, `update_time`=? WHERE `tasks`.`id` = ?",
+			expectedArgs: []interface{}{"Alice", int64(0), now, int64(7)},
+		},
+		{
+			name:         "included create time",
+			model:        &TestModelWithTime{Id: 1, Name: "Alice", CreateTime: created},
+			opts:         []UpdateOption{IncludeColumns(createTime)},
+			expectedSQL:  "UPDATE `tasks` SET `name`=?, `age`=?, `create_time`=?, `update_time`=? WHERE `tasks`.`id` = ?",
+			expectedArgs: []interface{}{"Alice", int64(0), created, now, int64(7)},
+		},
+		{
+			name:         "skip zero",
+			model:        &TestModelWithTime{Name: "Alice"},
+			opts:         []UpdateOption{SkipZero()},
+			expectedSQL:  "UPDATE `tasks` SET `name`=?, `update_time`=? WHERE `tasks`.`id` = ?",
+			expectedArgs: []interface{}{"Alice", now, int64(7)},
+		},
+		{
+			name:         "skip zero with included columns",
+			model:        &TestModelWithTime{},
+			opts:         []UpdateOption{SkipZero(), IncludeColumns(name, age)},
+			expectedSQL:  "UPDATE `tasks` SET `name`=?, `age`=?, `update_time`=? WHERE `tasks`.`id` = ?",
+			expectedArgs: []interface{}{"", int64(0), now, int64(7)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEngine := &MockEngine{}
+			orm := &ORM[TestModelWithTime, TestModelWithTimeOptional]{
+				table:  testTable,
+				engine: mockEngine,
+				opts:   options{clock: func() time.Time { return now }},
+			}
+			err := orm.UpdateModelByID(context.Background(), 7, tt.model, tt.opts...)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(mockEngine.ExecCalls) != 1 {
+				t.Fatalf("Expected 1 Exec call, got %d", len(mockEngine.ExecCalls))
+			}
+			call := mockEngine.ExecCalls[0]
+			if call.SQL != tt.expectedSQL {
+				t.Errorf("Expected SQL:\n%s\ngot:\n%s", tt.expectedSQL, call.SQL)
+			}
+			if len(call.Args) != len(tt.expectedArgs) {
+				t.Fatalf("Expected args %v, got %v", tt.expectedArgs, call.Args)
+			}
+			for i, arg := range tt.expectedArgs {
+				if call.Args[i] != arg {
+					t.Errorf("Expected arg[%d] %v, got %v", i, arg, call.Args[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateManyByID(t *testing.T) {
+	testTable := table.New("users")
+	testTable.Int64("id")
+	testTable.String("name")
+	testTable.Int64("age")
+
+	mockEngine := &MockEngine{}
+	orm := &ORM[TestModel, TestModelOptional]{
+		table:  testTable,
+		engine: mockEngine,
+	}
+	name1, name2 := "Alice", "Bob"
+	age2 := 30
+	err := orm.UpdateManyByID(context.Background(), map[int64]*TestModelOptional{
+		2: {Name: &name2, Age: &age2},
+		1: {Name: &name1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(mockEngine.ExecCalls) != 1 {
+		t.Fatalf("Expected 1 Exec call, got %d", len(mockEngine.ExecCalls))
+	}
+	call := mockEngine.ExecCalls[0]
+	expectedSQL := "UPDATE `users` SET " +
+		"`name`=CASE `users`.`id` WHEN ? THEN ? WHEN ? THEN ? ELSE `users`.`name` END, " +
+		"`age`=CASE `users`.`id` WHEN ? THEN ? ELSE `users`.`age` END " +
+		"WHERE `users`.`id` IN (?, ?)"
+	if call.SQL != expectedSQL {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expectedSQL, call.SQL)
+	}
+	expectedArgs := []interface{}{int64(1), "Alice", int64(2), "Bob", int64(2), int64(30), int64(1), int64(2)}
+	if len(call.Args) != len(expectedArgs) {
+		t.Fatalf("Expected args %v, got %v", expectedArgs, call.Args)
+	}
+	for i, arg := range expectedArgs {
+		if call.Args[i] != arg {
+			t.Errorf("Expected arg[%d] %v, got %v", i, arg, call.Args[i])
+		}
+	}
+}
+
+func TestORMUpdateBuilder_SetIf(t *testing.T) {
+	var capturedSQL string
+	mockEngine := &MockExecEngine{
+		ExecFunc: func(ctx context.Context, sql string, args []interface{}) error {
+			capturedSQL = sql
+			return nil
+		},
+	}
+	testTable := table.New("users")
+	id := testTable.Int64("id")
+	name := testTable.String("name")
+	age := testTable.Int64("age")
+	orm := &ORM[TestModel, TestModelOptional]{table: testTable, engine: mockEngine}
+
+	newName := ""
+	err := orm.Update().
+		SetIf(newName != "", name, sql.String(newName)).
+		SetIf(true, age, age.Increment(1)).
+		Where(id.Eq(1)).
+		Exec(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expectedSQL := "UPDATE `users` SET `age`=`users`.`age`+? WHERE `users`.`id` = ?"
+	if capturedSQL != expectedSQL {
+		t.Errorf("SQL mismatch:\n  got:  %s\n  want: %s", capturedSQL, expectedSQL)
+	}
+}
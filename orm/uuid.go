@@ -0,0 +1,75 @@
+package orm
+
+import (
+	"reflect"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sql"
+	"github.com/xhd2015/arc-orm/sql/expr"
+)
+
+var uuidType = reflect.TypeOf(field.UUID{})
+
+// uuidSQL converts a field.UUID value to its stored text form
+func uuidSQL(v interface{}) (expr.Expr, bool) {
+	u, ok := v.(field.UUID)
+	if !ok {
+		return nil, false
+	}
+	return sql.String(u.String()), true
+}
+
+// generatedUUID is a model field filled by generateUUIDs
+type generatedUUID struct {
+	index int
+	// allocated reports whether the field was a nil pointer
+	allocated bool
+}
+
+// generateUUIDs fills the empty model fields of auto-generated
+// UUID columns with new UUIDs, returning the fields filled
+func (o *ORM[T, P]) generateUUIDs(model *T) []generatedUUID {
+	uuids := o.describe().uuids
+	if len(uuids) == 0 {
+		return nil
+	}
+	var generated []generatedUUID
+	v := reflect.ValueOf(model).Elem()
+	for _, mf := range uuids {
+		fv := v.Field(mf.index)
+		var allocated bool
+		if fv.Kind() == reflect.Ptr {
+			if !fv.IsNil() && !fv.Elem().IsZero() {
+				continue
+			}
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+				allocated = true
+			}
+			fv = fv.Elem()
+		}
+		if !fv.IsZero() {
+			continue
+		}
+		generated = append(generated, generatedUUID{index: mf.index, allocated: allocated})
+		u := field.GenerateUUID()
+		if fv.Kind() == reflect.String {
+			fv.SetString(field.FormatUUID(u))
+		} else {
+			fv.Set(reflect.ValueOf(field.UUID(u)))
+		}
+	}
+	return generated
+}
+
+// resetUUIDs empties the fields filled by generateUUIDs
+func (o *ORM[T, P]) resetUUIDs(model *T, generated []generatedUUID) {
+	v := reflect.ValueOf(model).Elem()
+	for _, g := range generated {
+		fv := v.Field(g.index)
+		if fv.Kind() == reflect.Ptr && !g.allocated {
+			fv = fv.Elem()
+		}
+		fv.Set(reflect.Zero(fv.Type()))
+	}
+}
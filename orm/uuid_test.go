@@ -0,0 +1,224 @@
+package orm
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+type uuidDoc struct {
+	Id      int64
+	DocUuid string
+	Title   string
+}
+
+type uuidDocOptional struct {
+	Id      *int64
+	DocUuid *string
+	Title   *string
+}
+
+type binaryUUIDDoc struct {
+	Id      int64
+	DocUuid field.UUID
+	Title   string
+}
+
+type binaryUUIDDocOptional struct {
+	Id      *int64
+	DocUuid *field.UUID
+	Title   *string
+}
+
+func newUUIDDocTable() (table.Table, field.UuidField) {
+	docs := table.New("docs")
+	docs.Int64("id")
+	docUUID := docs.UUID("doc_uuid").AutoGenerate()
+	docs.String("title")
+	return docs, docUUID
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+// TestUUID_AutoGenerate tests that Insert fills and returns empty UUIDs
+func TestUUID_AutoGenerate(t *testing.T) {
+	docs, docUUID := newUUIDDocTable()
+	mockEngine := &MockEngine{}
+	orm, err := bind[uuidDoc, uuidDocOptional](mockEngine, docs)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	doc := &uuidDoc{Title: "hello"}
+	if _, err := orm.Insert(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !uuidPattern.MatchString(doc.DocUuid) {
+		t.Fatalf("Expected a generated UUID, got %q", doc.DocUuid)
+	}
+	call := mockEngine.ExecInsertCalls[0]
+	if call.Args[0] != doc.DocUuid {
+		t.Errorf("Expected inserted UUID %s, got %v", doc.DocUuid, call.Args[0])
+	}
+
+	// given UUIDs are kept
+	doc = &uuidDoc{DocUuid: "given", Title: "hello"}
+	if _, err := orm.Insert(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if doc.DocUuid != "given" {
+		t.Errorf("Expected given UUID to be kept, got %s", doc.DocUuid)
+	}
+
+	querySQL, args, err := docUUID.In("a", "b").ToSQL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if querySQL != "`docs`.`doc_uuid` IN (?, ?)" || len(args) != 2 {
+		t.Errorf("Unexpected IN condition: %s %v", querySQL, args)
+	}
+}
+
+type ptrUUIDDoc struct {
+	Id      int64
+	DocUuid *string
+	Title   string
+}
+
+// TestUUID_AutoGenerateFailed tests that generated UUIDs are
+// cleared on models not inserted
+func TestUUID_AutoGenerateFailed(t *testing.T) {
+	docs, _ := newUUIDDocTable()
+	eng := &failingEngine{name: "Eve"}
+	orm, err := bind[uuidDoc, uuidDocOptional](eng, docs)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	ctx := context.Background()
+	doc := &uuidDoc{Title: "Eve"}
+	if _, err := orm.Insert(ctx, doc); err == nil {
+		t.Fatal("Expected Insert to fail")
+	}
+	if doc.DocUuid != "" {
+		t.Errorf("Expected the UUID cleared after a failed Insert, got %q", doc.DocUuid)
+	}
+	title := "Eve"
+	if _, err := orm.InsertOrUpdate(ctx, doc, &uuidDocOptional{Title: &title}); err == nil {
+		t.Fatal("Expected InsertOrUpdate to fail")
+	}
+	if doc.DocUuid != "" {
+		t.Errorf("Expected the UUID cleared after a failed InsertOrUpdate, got %q", doc.DocUuid)
+	}
+	models := []*uuidDoc{{Title: "Frank"}, {DocUuid: "given", Title: "Eve"}}
+	if err := orm.InsertMany(ctx, models); err == nil {
+		t.Fatal("Expected InsertMany to fail")
+	}
+	if models[0].DocUuid != "" || models[1].DocUuid != "given" {
+		t.Errorf("Expected only the generated UUID cleared, got %q and %q", models[0].DocUuid, models[1].DocUuid)
+	}
+
+	// nil pointers are restored
+	ptrORM, err := bind[ptrUUIDDoc, uuidDocOptional](eng, docs)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+	ptrDoc := &ptrUUIDDoc{Title: "Eve"}
+	if _, err := ptrORM.Insert(ctx, ptrDoc); err == nil {
+		t.Fatal("Expected Insert to fail")
+	}
+	if ptrDoc.DocUuid != nil {
+		t.Errorf("Expected the UUID pointer restored to nil, got %q", *ptrDoc.DocUuid)
+	}
+}
+
+// TestUUID_Bytes tests that field.UUID model fields are written in text form
+func TestUUID_Bytes(t *testing.T) {
+	docs, _ := newUUIDDocTable()
+	mockEngine := &MockEngine{}
+	orm, err := bind[binaryUUIDDoc, binaryUUIDDocOptional](mockEngine, docs)
+	if err != nil {
+		t.Fatalf("Failed to create ORM: %v", err)
+	}
+
+	doc := &binaryUUIDDoc{Title: "hello"}
+	if _, err := orm.Insert(context.Background(), doc); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if doc.DocUuid == (field.UUID{}) {
+		t.Fatal("Expected a generated UUID")
+	}
+	if arg := mockEngine.ExecInsertCalls[0].Args[0]; arg != doc.DocUuid.String() {
+		t.Errorf("Expected inserted UUID %s, got %v", doc.DocUuid.String(), arg)
+	}
+
+	u := field.UUID{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+	if err := orm.UpdateByID(context.Background(), 1, &binaryUUIDDocOptional{DocUuid: &u}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if arg := mockEngine.ExecCalls[0].Args[0]; arg != "123e4567-e89b-12d3-a456-426614174000" {
+		t.Errorf("Expected UUID text, got %v", arg)
+	}
+}
+
+// TestUUID_Validate tests that UUID columns reject other model types
+func TestUUID_Validate(t *testing.T) {
+	docs := table.New("docs")
+	docs.Int64("id")
+	docs.UUID("doc_uuid")
+	docs.String("title")
+
+	type intDoc struct {
+		Id      int64
+		DocUuid int64
+		Title   string
+	}
+	type intDocOptional struct {
+		Id      *int64
+		DocUuid *int64
+		Title   *string
+	}
+	if _, err := bind[intDoc, intDocOptional](&MockEngine{}, docs); err == nil {
+		t.Fatal("Expected an error for an int64 UUID field")
+	}
+
+	// plain arrays cannot be scanned, field.UUID must be used
+	type arrayDoc struct {
+		Id      int64
+		DocUuid [16]byte
+		Title   string
+	}
+	type arrayDocOptional struct {
+		Id      *int64
+		DocUuid *[16]byte
+		Title   *string
+	}
+	if _, err := bind[arrayDoc, arrayDocOptional](&MockEngine{}, docs); err == nil {
+		t.Fatal("Expected an error for a [16]byte UUID field")
+	}
+}
+
+// TestUUID_Scan tests scanning field.UUID from text and raw bytes
+func TestUUID_Scan(t *testing.T) {
+	text := "123e4567-e89b-12d3-a456-426614174000"
+	var u field.UUID
+	if err := u.Scan(text); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if u.String() != text {
+		t.Errorf("Expected %s, got %s", text, u)
+	}
+	var raw field.UUID
+	if err := raw.Scan(u[:]); err != nil || raw != u {
+		t.Errorf("Expected raw bytes scanned to %s, got %s %v", u, raw, err)
+	}
+	if err := raw.Scan(nil); err != nil || raw != (field.UUID{}) {
+		t.Errorf("Expected NULL scanned to zero UUID, got %s %v", raw, err)
+	}
+	if err := raw.Scan("not-a-uuid"); err == nil {
+		t.Error("Expected an error for an invalid UUID")
+	}
+}
@@ -7,8 +7,8 @@ import (
 	"time"
 
 	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/orm/column"
 	"github.com/xhd2015/arc-orm/table"
-	"github.com/xhd2015/less-gen/strcase"
 )
 
 // Errors returned by validation
@@ -18,14 +18,14 @@ var (
 	ErrFieldMismatch      = errors.New("field mismatch between model and table")
 	ErrFieldTypeMismatch  = errors.New("field type mismatch between model and table")
 	ErrFieldCountMismatch = errors.New("number of fields in model does not match table")
-	ErrInvalidFieldNaming = errors.New("field name must be strict CamelCase (no consecutive uppercase letters)")
+	ErrInvalidFieldNaming = errors.New("field name does not follow the naming strategy")
 )
 
 // Validate checks if the model type T and optional fields type P
 // match the table definition.
 func (o *ORM[T, P]) Validate() error {
 	// Validate model type
-	if err := validateModelType[T](o.table); err != nil {
+	if err := validateModelType[T](o.table, o.opts.naming); err != nil {
 		return fmt.Errorf("model validation failed: %w", err)
 	}
 
@@ -38,8 +38,8 @@ func (o *ORM[T, P]) Validate() error {
 }
 
 // validateModelType checks if the model type T is a struct and its fields
-// match the table definition.
-func validateModelType[T any](tbl table.Table) error {
+// match the table definition, mapped by the naming strategy.
+func validateModelType[T any](tbl table.Table, naming NamingStrategy) error {
 	// Get the reflect.Type of T
 	modelType := reflect.TypeOf((*T)(nil)).Elem()
 
@@ -79,13 +79,16 @@ func validateModelType[T any](tbl table.Table) error {
 
 	for i := 0; i < modelType.NumField(); i++ {
 		field := modelType.Field(i)
-		if field.IsExported() {
+		if field.IsExported() && !Ignored(field) {
 			// Validate field naming - must be strict CamelCase (no consecutive uppercase)
-			if err := validateFieldNaming(field.Name); err != nil {
-				return err
+			// by default, unless the column is declared explicitly by tag
+			if column.Tag(field.Tag.Get("orm")) == "" {
+				if err := naming.validateFieldName(field.Name); err != nil {
+					return err
+				}
 			}
 
-			fieldName := getFieldName(field)
+			fieldName := naming.ColumnName(field)
 
 			// Special handling for Count field
 			if field.Name == "Count" {
@@ -192,8 +195,8 @@ func validateOptionalType[T, P any]() error {
 	for i := 0; i < optionalType.NumField(); i++ {
 		optField := optionalType.Field(i)
 
-		// Skip unexported fields
-		if !optField.IsExported() {
+		// Skip unexported and ignored fields
+		if !optField.IsExported() || optionalIgnored[T](optField) {
 			continue
 		}
 
@@ -240,11 +243,10 @@ func validateOptionalType[T, P any]() error {
 	return nil
 }
 
-// getFieldName extracts the field name from struct field or tags
-// and converts it to the appropriate case for database fields
+// getFieldName extracts the column name from struct field or tags
+// for comparison with table fields
 func getFieldName(field reflect.StructField) string {
-	// Convert field name to snake_case for comparison with table fields
-	return strcase.CamelToSnake(field.Name)
+	return ColumnName(field)
 }
 
 // hasConsecutiveUppercase checks if a string has two or more consecutive uppercase letters.
@@ -335,6 +337,10 @@ func checkFieldTypeCompatibility(structType reflect.Type, tableField field.Field
 		if structType.Kind() != reflect.String {
 			return fmt.Errorf("expected string for StringField, got %s", structType.String())
 		}
+	case field.UuidField:
+		if structType.Kind() != reflect.String && structType != uuidType {
+			return fmt.Errorf("expected string or field.UUID for UuidField, got %s", structType.String())
+		}
 	case field.TimeField:
 		// Time is a struct, so check against time.Time type name
 		if structType.String() != "time.Time" {
@@ -0,0 +1,81 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/ddl"
+)
+
+// SchemaError reports the differences between the declared table
+// and the live database found by VerifySchema
+type SchemaError struct {
+	Table string
+	// MissingTable is true if the table does not exist in the database
+	MissingTable bool
+	// MissingColumns are declared columns missing from the database
+	MissingColumns []string
+	// Mismatches are declared columns whose live type is not compatible
+	Mismatches []ColumnMismatch
+}
+
+// ColumnMismatch describes a column whose declared type
+// is not compatible with its type in the database
+type ColumnMismatch struct {
+	Column string
+	// Declared is the column type of the declared field, e.g. BIGINT
+	Declared string
+	// Actual is the column type in the database, e.g. varchar(64)
+	Actual string
+}
+
+func (e *SchemaError) Error() string {
+	if e.MissingTable {
+		return fmt.Sprintf("schema of %s: table does not exist", e.Table)
+	}
+	var problems []string
+	if len(e.MissingColumns) > 0 {
+		problems = append(problems, "missing columns: "+strings.Join(e.MissingColumns, ", "))
+	}
+	for _, m := range e.Mismatches {
+		problems = append(problems, fmt.Sprintf("column %s: declared %s, database has %s", m.Column, m.Declared, m.Actual))
+	}
+	return fmt.Sprintf("schema of %s: %s", e.Table, strings.Join(problems, "; "))
+}
+
+// VerifySchema checks against information_schema that every declared
+// column exists in the database with a compatible type, e.g. at startup,
+// catching drift the Go-only validation of Bind cannot see.
+// Extra columns in the database are allowed.
+// It returns a *SchemaError describing all mismatches.
+func (o *ORM[T, P]) VerifySchema(ctx context.Context) error {
+	columns, err := ddl.QueryColumns(ctx, o.getEngine(ctx), o.physicalTable())
+	if err != nil {
+		return err
+	}
+	diff := ddl.Compare(o.table, columns)
+	if diff.Missing {
+		return &SchemaError{Table: o.physicalTable(), MissingTable: true}
+	}
+	if len(diff.Added) == 0 && len(diff.Retyped) == 0 {
+		return nil
+	}
+
+	schemaErr := &SchemaError{Table: o.physicalTable()}
+	for _, f := range diff.Added {
+		schemaErr.MissingColumns = append(schemaErr.MissingColumns, f.Name())
+	}
+	live := make(map[string]*ddl.Column, len(columns))
+	for _, col := range columns {
+		live[col.ColumnName] = col
+	}
+	for _, f := range diff.Retyped {
+		schemaErr.Mismatches = append(schemaErr.Mismatches, ColumnMismatch{
+			Column:   f.Name(),
+			Declared: ddl.ColumnType(f),
+			Actual:   live[f.Name()].ColumnType,
+		})
+	}
+	return schemaErr
+}
@@ -0,0 +1,56 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/xhd2015/arc-orm/ddl"
+)
+
+func TestVerifySchema(t *testing.T) {
+	newORM := func(columns []*ddl.Column) *ORM[TestModelWithTime, TestModelWithTimeOptional] {
+		mockEngine := &MockQueryEngine{QueryFunc: func(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+			*result.(*[]*ddl.Column) = columns
+			return nil
+		}}
+		orm, err := bind[TestModelWithTime, TestModelWithTimeOptional](mockEngine, newTimeTestTable())
+		if err != nil {
+			t.Fatalf("Failed to create ORM: %v", err)
+		}
+		return orm
+	}
+
+	matching := []*ddl.Column{
+		{ColumnName: "id", DataType: "bigint", ColumnType: "bigint"},
+		{ColumnName: "name", DataType: "varchar", ColumnType: "varchar(64)"},
+		{ColumnName: "age", DataType: "int", ColumnType: "int"},
+		{ColumnName: "create_time", DataType: "datetime", ColumnType: "datetime"},
+		{ColumnName: "update_time", DataType: "timestamp", ColumnType: "timestamp"},
+		{ColumnName: "extra", DataType: "text", ColumnType: "text"},
+	}
+	if err := newORM(matching).VerifySchema(context.Background()); err != nil {
+		t.Errorf("Expected matching schema, got %v", err)
+	}
+
+	drifted := []*ddl.Column{
+		{ColumnName: "id", DataType: "bigint", ColumnType: "bigint"},
+		{ColumnName: "name", DataType: "varchar", ColumnType: "varchar(64)"},
+		{ColumnName: "age", DataType: "varchar", ColumnType: "varchar(8)"},
+		{ColumnName: "create_time", DataType: "datetime", ColumnType: "datetime"},
+	}
+	err := newORM(drifted).VerifySchema(context.Background())
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("Expected *SchemaError, got %v", err)
+	}
+	expected := "schema of test_table: missing columns: update_time; column age: declared BIGINT, database has varchar(8)"
+	if err.Error() != expected {
+		t.Errorf("Expected error:\n%s\ngot:\n%s", expected, err.Error())
+	}
+
+	err = newORM(nil).VerifySchema(context.Background())
+	if !errors.As(err, &schemaErr) || !schemaErr.MissingTable {
+		t.Errorf("Expected missing table, got %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+// Package ormtest provides helpers for integration tests of ORMs,
+// like loading fixture rows and truncating tables
+package ormtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/xhd2015/arc-orm/orm"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFixtures reads the rows of a YAML or JSON file, a list of
+// objects keyed by column name, validates the columns against the table
+// of o and inserts each row with o.Insert. The inserted models are
+// returned in file order, with their ids filled.
+// Example:
+//
+//	# testdata/users.yaml
+//	- id: 1
+//	  name: Alice
+//	  create_time: 2024-01-02T03:04:05Z
+//	- name: Bob
+func LoadFixtures[T any, P any](ctx context.Context, o *orm.ORM[T, P], file string) ([]*T, error) {
+	rows, err := readRows(file)
+	if err != nil {
+		return nil, err
+	}
+	models := make([]*T, 0, len(rows))
+	for i, row := range rows {
+		model, err := toModel[T](o, row)
+		if err != nil {
+			return nil, fmt.Errorf("%s: row %d: %w", file, i, err)
+		}
+		if _, err := o.Insert(ctx, model); err != nil {
+			return nil, fmt.Errorf("%s: row %d: %w", file, i, err)
+		}
+		models = append(models, model)
+	}
+	return models, nil
+}
+
+// Truncate empties the table of o with TRUNCATE TABLE,
+// resetting AUTO_INCREMENT, e.g. before loading fixtures,
+// see orm.ORM.Truncate
+func Truncate[T any, P any](ctx context.Context, o *orm.ORM[T, P]) error {
+	if err := o.Truncate(ctx); err != nil {
+		return fmt.Errorf("truncate %s: %w", o.Table().Name(), err)
+	}
+	return nil
+}
+
+// readRows parses the rows of a .yaml, .yml or .json file
+func readRows(file string) ([]map[string]interface{}, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]interface{}
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		err = dec.Decode(&rows)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &rows)
+	default:
+		return nil, fmt.Errorf("unsupported fixture file %s, requires .yaml, .yml or .json", file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", file, err)
+	}
+	return rows, nil
+}
+
+// toModel creates the model of a row, every column of the
+// row must be declared in the table and mapped by the model
+func toModel[T any, P any](o *orm.ORM[T, P], row map[string]interface{}) (*T, error) {
+	declared := make(map[string]bool, len(o.Table().Fields()))
+	for _, f := range o.Table().Fields() {
+		declared[f.Name()] = true
+	}
+	model := new(T)
+	v := reflect.ValueOf(model).Elem()
+	fields := make(map[string]int, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		if f := v.Type().Field(i); f.IsExported() && !orm.Ignored(f) {
+			fields[o.Naming().ColumnName(f)] = i
+		}
+	}
+	for column, value := range row {
+		if !declared[column] {
+			return nil, fmt.Errorf("column %s not found in table %s", column, o.Table().Name())
+		}
+		index, ok := fields[column]
+		if !ok {
+			return nil, fmt.Errorf("column %s has no field in model %s", column, v.Type().Name())
+		}
+		if err := setValue(v.Field(index), value); err != nil {
+			return nil, fmt.Errorf("column %s: %w", column, err)
+		}
+	}
+	return model, nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// timeLayouts are the accepted layouts of time strings
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"}
+
+// setValue sets the field to a value parsed from the fixture file
+func setValue(fv reflect.Value, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	if fv.Kind() == reflect.Ptr {
+		elem := reflect.New(fv.Type().Elem())
+		if err := setValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		fv.Set(elem)
+		return nil
+	}
+	if fv.Type() == timeType {
+		switch v := value.(type) {
+		case time.Time:
+			fv.Set(reflect.ValueOf(v))
+			return nil
+		case string:
+			for _, layout := range timeLayouts {
+				if t, err := time.Parse(layout, v); err == nil {
+					fv.Set(reflect.ValueOf(t))
+					return nil
+				}
+			}
+			return fmt.Errorf("invalid time %q", v)
+		}
+		return fmt.Errorf("cannot use %T as time", value)
+	}
+	if n, ok := value.(json.Number); ok {
+		switch fv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i, err := n.Int64()
+			if err != nil {
+				return err
+			}
+			value = i
+		default:
+			f, err := n.Float64()
+			if err != nil {
+				return err
+			}
+			value = f
+		}
+	}
+	rv := reflect.ValueOf(value)
+	if kindClass(rv.Kind()) != kindClass(fv.Kind()) || !rv.Type().ConvertibleTo(fv.Type()) {
+		return fmt.Errorf("cannot use %T as %s", value, fv.Type())
+	}
+	fv.Set(rv.Convert(fv.Type()))
+	return nil
+}
+
+// kindClass groups the kinds converted into each other
+func kindClass(kind reflect.Kind) string {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	}
+	return kind.String()
+}
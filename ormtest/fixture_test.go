@@ -0,0 +1,111 @@
+package ormtest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/engine"
+	"github.com/xhd2015/arc-orm/orm"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+type user struct {
+	Id         int64
+	Name       string
+	Age        int64
+	Nickname   *string
+	CreateTime time.Time
+}
+
+type userOptional struct {
+	Id         *int64
+	Name       *string
+	Age        *int64
+	Nickname   *string
+	CreateTime *time.Time
+}
+
+// recordEngine records executed statements, inserts get id 100
+type recordEngine struct {
+	execs []string
+}
+
+func (e *recordEngine) Query(ctx context.Context, sql string, args []interface{}, result interface{}) error {
+	return nil
+}
+
+func (e *recordEngine) Exec(ctx context.Context, sql string, args []interface{}) error {
+	e.execs = append(e.execs, sql)
+	return nil
+}
+
+func (e *recordEngine) ExecInsert(ctx context.Context, sql string, args []interface{}) (int64, error) {
+	e.execs = append(e.execs, sql)
+	return 100, nil
+}
+
+func (e *recordEngine) GetEngine() engine.Engine {
+	return e
+}
+
+func newUserORM(eng *recordEngine) *orm.ORM[user, userOptional] {
+	users := table.New("users")
+	users.Int64("id")
+	users.String("name")
+	users.Int64("age")
+	users.String("nickname")
+	users.Time("create_time")
+	return orm.Bind[user, userOptional](eng, users)
+}
+
+func TestLoadFixtures(t *testing.T) {
+	for _, file := range []string{"testdata/users.yaml", "testdata/users.json"} {
+		t.Run(file, func(t *testing.T) {
+			eng := &recordEngine{}
+			users, err := LoadFixtures(context.Background(), newUserORM(eng), file)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(users) != 2 {
+				t.Fatalf("Expected 2 users, got %d", len(users))
+			}
+			alice, bob := users[0], users[1]
+			if alice.Id != 100 || alice.Name != "Alice" || alice.Age != 30 || !alice.CreateTime.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)) {
+				t.Errorf("Unexpected alice: %+v", alice)
+			}
+			if bob.Id != 7 || bob.Nickname == nil || *bob.Nickname != "bobby" {
+				t.Errorf("Unexpected bob: %+v", bob)
+			}
+			if len(eng.execs) != 2 {
+				t.Errorf("Expected 2 inserts, got %v", eng.execs)
+			}
+		})
+	}
+}
+
+func TestLoadFixtures_UnknownColumn(t *testing.T) {
+	_, err := LoadFixtures(context.Background(), newUserORM(&recordEngine{}), "testdata/unknown_column.yaml")
+	if err == nil || !strings.Contains(err.Error(), "column email not found in table users") {
+		t.Errorf("Expected unknown column error, got %v", err)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	eng := &recordEngine{}
+	if err := Truncate(context.Background(), newUserORM(eng)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(eng.execs) != 1 || eng.execs[0] != "TRUNCATE TABLE `users`" {
+		t.Errorf("Unexpected statements: %v", eng.execs)
+	}
+
+	eng = &recordEngine{}
+	if err := Truncate(context.Background(), newUserORM(eng).WithTable("users_2025")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(eng.execs) != 1 || eng.execs[0] != "TRUNCATE TABLE `users_2025`" {
+		t.Errorf("Unexpected statements: %v", eng.execs)
+	}
+}
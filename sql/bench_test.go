@@ -0,0 +1,48 @@
+package sql
+
+import "testing"
+
+func BenchmarkSelectSQL(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, err := Select(UserID, UserName, UserEmail, UserAge).
+			From(userTable.Name()).
+			Join(postTable.Name(), PostUserID.EqField(UserID)).
+			Where(UserAge.Gt(18), UserName.Like("J%")).
+			OrderBy(UserID.Desc()).
+			Limit(10).
+			Offset(20).
+			SQL()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkInsertSQL(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, err := InsertInto(userTable.Name()).
+			Set(UserName, String("John Doe")).
+			Set(UserEmail, String("john@example.com")).
+			Set(UserAge, Int64(30)).
+			SQL()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUpdateSQL(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _, err := Update(userTable.Name()).
+			Set(UserName, String("John Doe")).
+			Set(UserAge, UserAge.Increment(1)).
+			Where(UserID.Eq(1)).
+			SQL()
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
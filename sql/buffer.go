@@ -0,0 +1,45 @@
+package sql
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+)
+
+// maxPooledBufferSize keeps unusually large buffers out of the pool
+const maxPooledBufferSize = 64 << 10
+
+// bufferPool reuses the buffers SQL statements are generated into,
+// so hot paths only allocate the final string
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return bytes.NewBuffer(make([]byte, 0, 256))
+	},
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// newParams pre-sizes the parameter slice, nil if no parameters are expected
+func newParams(n int) []interface{} {
+	if n == 0 {
+		return nil
+	}
+	return make([]interface{}, 0, n)
+}
+
+// writeInt writes n without the allocations of fmt
+func writeInt(buf *bytes.Buffer, n int) {
+	var tmp [20]byte
+	buf.Write(strconv.AppendInt(tmp[:0], int64(n), 10))
+}
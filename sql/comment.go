@@ -0,0 +1,43 @@
+package sql
+
+import (
+	"bytes"
+	"strings"
+)
+
+// AppendComment appends comment to the query as a trailing
+// /* comment */, sanitized so it cannot end the comment early.
+// An empty comment leaves the query unchanged.
+func AppendComment(query string, comment string) string {
+	comment = SanitizeComment(comment)
+	if comment == "" {
+		return query
+	}
+	return query + " /* " + comment + " */"
+}
+
+// SanitizeComment makes comment safe to embed in a SQL comment:
+// comment delimiters are broken up and control characters,
+// including newlines, are replaced by spaces
+func SanitizeComment(comment string) string {
+	comment = strings.Map(func(r rune) rune {
+		if r < ' ' || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, comment)
+	comment = strings.ReplaceAll(comment, "*/", "* /")
+	comment = strings.ReplaceAll(comment, "/*", "/ *")
+	return strings.TrimSpace(comment)
+}
+
+// writeComment writes the trailing comment of a statement, if any
+func writeComment(buf *bytes.Buffer, comment string) {
+	comment = SanitizeComment(comment)
+	if comment == "" {
+		return
+	}
+	buf.WriteString(" /* ")
+	buf.WriteString(comment)
+	buf.WriteString(" */")
+}
@@ -0,0 +1,29 @@
+package sql
+
+import "testing"
+
+func TestComment(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder interface {
+			SQL() (string, []interface{}, error)
+		}
+		expected string
+	}{
+		{"select", Select(UserID).From(userTable.Name()).Comment("svc=checkout route=/pay"), "SELECT `users`.`id` FROM `users` /* svc=checkout route=/pay */"},
+		{"update", Update(userTable.Name()).Set(UserAge, Int64(1)).Comment("a */ DROP TABLE users; /*"), "UPDATE `users` SET `age`=? /* a * / DROP TABLE users; / * */"},
+		{"insert", InsertInto(userTable.Name()).Set(UserAge, Int64(1)).Comment("line1\nline2"), "INSERT INTO `users` SET `age`=? /* line1 line2 */"},
+		{"delete", DeleteFrom(userTable.Name()).Where(UserID.Eq(1)).Comment(" "), "DELETE FROM `users` WHERE `users`.`id` = ?"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlStr, _, err := tt.builder.SQL()
+			if err != nil {
+				t.Fatalf("Failed to generate SQL: %v", err)
+			}
+			if sqlStr != tt.expected {
+				t.Errorf("Expected SQL: %s, got: %s", tt.expected, sqlStr)
+			}
+		})
+	}
+}
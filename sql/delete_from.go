@@ -3,24 +3,73 @@ package sql
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sql/expr"
 )
 
 // DeleteFrom creates a new DeleteBuilder for the given table
 func DeleteFrom(tableName string) *DeleteBuilder {
 	return &DeleteBuilder{
 		tableName: tableName,
+		err:       checkTable(nil, tableName),
 	}
 }
 
 // DeleteBuilder builds DELETE queries
 type DeleteBuilder struct {
 	tableName  string
+	alias      string
+	joins      []join
 	conditions []field.Expr
+	orderBys   []expr.Expr
 	limit      int
 	hasLimit   bool
+	partitions []string
+	comment    string
+	// err is the first invalid table or alias set, returned by SQL
+	err error
+
+	requireWhere   bool
+	allowFullTable bool
+}
+
+// Join adds a join clause, making a multi-table DELETE (MySQL)
+// that deletes rows of the table only
+func (b *DeleteBuilder) Join(tableName string, condition field.Expr) *DeleteBuilder {
+	b.err = checkTable(b.err, tableName)
+	b.joins = append(b.joins, join{
+		tableName: tableName,
+		condition: condition,
+		joinType:  "JOIN",
+	})
+	return b
+}
+
+// LeftJoin adds a left join clause, see Join
+func (b *DeleteBuilder) LeftJoin(tableName string, condition field.Expr) *DeleteBuilder {
+	b.err = checkTable(b.err, tableName)
+	b.joins = append(b.joins, join{
+		tableName: tableName,
+		condition: condition,
+		joinType:  "LEFT JOIN",
+	})
+	return b
+}
+
+// As sets the alias of the table to delete from, see SelectBuilder.As.
+// Aliased deletes use the multi-table form DELETE `alias` FROM `t` AS `alias`,
+// which works on all MySQL versions but does not support ORDER BY or LIMIT.
+func (b *DeleteBuilder) As(alias string) *DeleteBuilder {
+	b.alias = alias
+	b.err = checkAlias(b.err, alias)
+	return b
+}
+
+// Partition restricts the delete to the given partitions, see SelectBuilder.Partition
+func (b *DeleteBuilder) Partition(partitions ...string) *DeleteBuilder {
+	b.partitions = append(b.partitions, partitions...)
+	return b
 }
 
 // Where adds conditions to the DELETE query
@@ -29,6 +78,12 @@ func (b *DeleteBuilder) Where(conditions ...field.Expr) *DeleteBuilder {
 	return b
 }
 
+// OrderBy sets the order rows are deleted in, e.g. to delete the oldest N rows with Limit
+func (b *DeleteBuilder) OrderBy(orderFields ...expr.Expr) *DeleteBuilder {
+	b.orderBys = append(b.orderBys, orderFields...)
+	return b
+}
+
 // Limit sets the maximum number of rows to delete
 func (b *DeleteBuilder) Limit(limit int) *DeleteBuilder {
 	b.limit = limit
@@ -36,44 +91,103 @@ func (b *DeleteBuilder) Limit(limit int) *DeleteBuilder {
 	return b
 }
 
+// Comment sets a comment appended to the generated SQL, e.g. "svc=checkout route=/pay",
+// so slow queries can be attributed to their call sites. See SanitizeComment.
+func (b *DeleteBuilder) Comment(comment string) *DeleteBuilder {
+	b.comment = comment
+	return b
+}
+
+// Clone returns a copy of the builder, see SelectBuilder.Clone
+func (b *DeleteBuilder) Clone() *DeleteBuilder {
+	c := *b
+	c.joins = cloneSlice(b.joins)
+	c.partitions = cloneSlice(b.partitions)
+	c.conditions = cloneSlice(b.conditions)
+	c.orderBys = cloneSlice(b.orderBys)
+	return &c
+}
+
 // SQL generates the SQL string and parameters for the DELETE statement
 func (b *DeleteBuilder) SQL() (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
 	if b.tableName == "" {
 		return "", nil, errors.New("table name is required")
 	}
 
-	var sqlBuilder strings.Builder
-	var params []interface{}
+	sqlBuilder := getBuffer()
+	defer putBuffer(sqlBuilder)
+	params := newParams(len(b.conditions))
 
 	// Build DELETE clause
-	sqlBuilder.WriteString("DELETE FROM `")
-	sqlBuilder.WriteString(b.tableName)
-	sqlBuilder.WriteString("`")
+	var err error
+	if len(b.joins) > 0 || b.alias != "" {
+		if b.hasLimit || len(b.orderBys) > 0 {
+			return "", nil, errors.New("multi-table DELETE does not support ORDER BY or LIMIT")
+		}
+		target := b.tableName
+		if b.alias != "" {
+			target = aliasName(b.alias)
+		}
+		sqlBuilder.WriteString("DELETE ")
+		sqlBuilder.WriteString(field.QuoteTable(target))
+		sqlBuilder.WriteString(" FROM ")
+		sqlBuilder.WriteString(field.QuoteTable(b.tableName))
+		if err := writePartitions(sqlBuilder, b.partitions); err != nil {
+			return "", nil, err
+		}
+		writeAlias(sqlBuilder, b.alias)
+
+		params, err = writeJoins(sqlBuilder, params, b.joins)
+		if err != nil {
+			return "", nil, err
+		}
+	} else {
+		sqlBuilder.WriteString("DELETE FROM ")
+		sqlBuilder.WriteString(field.QuoteTable(b.tableName))
+		if err := writePartitions(sqlBuilder, b.partitions); err != nil {
+			return "", nil, err
+		}
+	}
 
 	// Build WHERE clause
-	if len(b.conditions) > 0 {
-		sqlBuilder.WriteString(" WHERE ")
-		for i, condition := range b.conditions {
+	whereStart := sqlBuilder.Len()
+	params, err = writeConditions(sqlBuilder, params, " WHERE ", b.conditions)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build where condition: %w", err)
+	}
+	if b.requireWhere && !b.allowFullTable && sqlBuilder.Len() == whereStart {
+		return "", nil, fmt.Errorf("DELETE FROM %s: %w", b.tableName, ErrFullTable)
+	}
+
+	// Build ORDER BY clause
+	if len(b.orderBys) > 0 {
+		sqlBuilder.WriteString(" ORDER BY ")
+		for i, orderBy := range b.orderBys {
 			if i > 0 {
-				sqlBuilder.WriteString(" AND ")
+				sqlBuilder.WriteString(", ")
 			}
-
-			condSQL, condParams, err := condition.ToSQL()
+			sql, orderByParams, err := orderBy.ToSQL()
 			if err != nil {
-				return "", nil, fmt.Errorf("failed to build where condition: %w", err)
-			}
-			if condSQL == "" {
-				continue
+				return "", nil, fmt.Errorf("failed to build order by condition: %w", err)
 			}
-			sqlBuilder.WriteString(condSQL)
-			params = append(params, condParams...)
+			sqlBuilder.WriteString(sql)
+			params = append(params, orderByParams...)
 		}
 	}
 
 	// Add LIMIT clause if specified
 	if b.hasLimit {
-		sqlBuilder.WriteString(fmt.Sprintf(" LIMIT %d", b.limit))
+		sqlBuilder.WriteString(" LIMIT ")
+		writeInt(sqlBuilder, b.limit)
+	}
+
+	if err := checkParams("DELETE", params); err != nil {
+		return "", nil, err
 	}
 
-	return sqlBuilder.String(), params, nil
+	writeComment(sqlBuilder, b.comment)
+	return qualifyByAliases(sqlBuilder.String(), b.alias, b.joins), params, nil
 }
@@ -98,3 +98,47 @@ func TestDeleteFromAllRows(t *testing.T) {
 		t.Errorf("Expected 0 params, got %d", len(params))
 	}
 }
+
+func TestDeleteFromWithOrderByLimit(t *testing.T) {
+	// Test deleting the oldest N rows
+	query := DeleteFrom(userTable.Name()).
+		Where(UserAge.Lt(18)).
+		OrderBy(UserID.Asc()).
+		Limit(10)
+
+	sqlStr, params, err := query.SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+
+	expectedSQL := "DELETE FROM `users` WHERE `users`.`age` < ? ORDER BY `users`.`id` ASC LIMIT 10"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 1 {
+		t.Errorf("Expected 1 param, got %d", len(params))
+	}
+}
+
+func TestDeleteFromJoin(t *testing.T) {
+	query := DeleteFrom(postTable.Name()).
+		Join(userTable.Name(), PostUserID.EqField(UserID)).
+		Where(UserAge.Lt(18))
+
+	sqlStr, params, err := query.SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "DELETE `posts` FROM `posts` JOIN `users` ON `posts`.`user_id` = `users`.`id` WHERE `users`.`age` < ?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 1 {
+		t.Errorf("Expected 1 param, got %d", len(params))
+	}
+
+	_, _, err = query.Limit(10).SQL()
+	if err == nil {
+		t.Errorf("Expected error for multi-table DELETE with LIMIT")
+	}
+}
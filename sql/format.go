@@ -0,0 +1,174 @@
+package sql
+
+import "strings"
+
+// clauseKeywords start a new line when formatting, longer
+// keywords sharing a prefix must come first
+var clauseKeywords = []string{
+	"SELECT",
+	"FROM",
+	"LEFT JOIN",
+	"RIGHT JOIN",
+	"INNER JOIN",
+	"CROSS JOIN",
+	"JOIN",
+	"WHERE",
+	"GROUP BY",
+	"HAVING",
+	"ORDER BY",
+	"LIMIT",
+	"OFFSET",
+	"SET",
+	"ON DUPLICATE KEY UPDATE",
+	"UNION ALL",
+	"UNION",
+}
+
+const formatIndent = "  "
+
+// Format formats a generated query into multiple lines for logs and
+// snapshot tests: clauses start on their own lines, top-level AND/OR
+// conditions are indented under their clause, and subqueries are indented
+// one level deeper. Quoted identifiers and strings are kept as is.
+// Example:
+//
+//	SELECT `users`.`id`, `users`.`name`
+//	FROM `users`
+//	WHERE `users`.`age` > ?
+//	  AND `users`.`name` LIKE ?
+//	LIMIT 10
+func Format(query string) string {
+	var b strings.Builder
+	// subqueries[i] tells whether the i-th open paren starts a subquery
+	var subqueries []bool
+	level := 0
+	inBetween := false
+
+	breakable := func() bool {
+		return len(subqueries) == 0 || subqueries[len(subqueries)-1]
+	}
+	newLine := func(extra string) {
+		b.WriteString("\n")
+		b.WriteString(strings.Repeat(formatIndent, level))
+		b.WriteString(extra)
+	}
+
+	query = strings.TrimSpace(query)
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch c {
+		case '`', '\'', '"':
+			j := skipQuoted(query, i)
+			b.WriteString(query[i:j])
+			i = j
+			continue
+		case '(':
+			j := skipSpaces(query, i+1)
+			sub := matchKeyword(query, j, "SELECT")
+			subqueries = append(subqueries, sub)
+			b.WriteByte('(')
+			i++
+			if sub {
+				level++
+				newLine("")
+				i = j
+			}
+			continue
+		case ')':
+			if len(subqueries) > 0 {
+				sub := subqueries[len(subqueries)-1]
+				subqueries = subqueries[:len(subqueries)-1]
+				if sub {
+					level--
+					newLine("")
+				}
+			}
+			b.WriteByte(')')
+			i++
+			continue
+		case ' ':
+			if !breakable() {
+				break
+			}
+			j := i + 1
+			if kw := matchClause(query, j); kw != "" {
+				newLine(query[j : j+len(kw)])
+				i = j + len(kw)
+				inBetween = false
+				continue
+			}
+			if matchKeyword(query, j, "BETWEEN") {
+				inBetween = true
+				break
+			}
+			op := ""
+			if matchKeyword(query, j, "AND") {
+				op = "AND"
+			} else if matchKeyword(query, j, "OR") {
+				op = "OR"
+			}
+			if op == "" || op == "AND" && inBetween {
+				// keep BETWEEN x AND y on one line
+				inBetween = inBetween && op == ""
+				break
+			}
+			newLine(formatIndent + query[j:j+len(op)])
+			i = j + len(op)
+			continue
+		}
+		b.WriteByte(c)
+		i++
+	}
+	return b.String()
+}
+
+// matchClause returns the clause keyword at position i, or empty
+func matchClause(s string, i int) string {
+	for _, kw := range clauseKeywords {
+		if matchKeyword(s, i, kw) {
+			return kw
+		}
+	}
+	return ""
+}
+
+// matchKeyword reports whether the whole word kw appears at position i, ignoring case
+func matchKeyword(s string, i int, kw string) bool {
+	end := i + len(kw)
+	if end > len(s) || !strings.EqualFold(s[i:end], kw) {
+		return false
+	}
+	return end == len(s) || !isIdentChar(s[end])
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func skipSpaces(s string, i int) int {
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	return i
+}
+
+// skipQuoted returns the position after the quoted text starting at i,
+// quotes are escaped by doubling or by backslash
+func skipQuoted(s string, i int) int {
+	quote := s[i]
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			if quote != '`' {
+				j++
+			}
+		case quote:
+			if j+1 < len(s) && s[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return len(s)
+}
@@ -0,0 +1,55 @@
+package sql
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{
+			name:  "select",
+			query: "SELECT `users`.`id` FROM `users` LEFT JOIN `posts` ON `posts`.`user_id` = `users`.`id` WHERE `users`.`age` > ? AND (`users`.`name` = ? OR `users`.`name` = ?) ORDER BY `users`.`id` DESC LIMIT 10",
+			expected: "SELECT `users`.`id`\n" +
+				"FROM `users`\n" +
+				"LEFT JOIN `posts` ON `posts`.`user_id` = `users`.`id`\n" +
+				"WHERE `users`.`age` > ?\n" +
+				"  AND (`users`.`name` = ? OR `users`.`name` = ?)\n" +
+				"ORDER BY `users`.`id` DESC\n" +
+				"LIMIT 10",
+		},
+		{
+			name:  "between and quoted keywords",
+			query: "SELECT `from` FROM `users` WHERE `users`.`age` BETWEEN ? AND ? AND `users`.`name` = 'a WHERE b'",
+			expected: "SELECT `from`\n" +
+				"FROM `users`\n" +
+				"WHERE `users`.`age` BETWEEN ? AND ?\n" +
+				"  AND `users`.`name` = 'a WHERE b'",
+		},
+		{
+			name:  "subquery",
+			query: "SELECT `users`.`id` FROM `users` WHERE `users`.`id` IN (SELECT `posts`.`user_id` FROM `posts` WHERE `posts`.`id` IN (?, ?))",
+			expected: "SELECT `users`.`id`\n" +
+				"FROM `users`\n" +
+				"WHERE `users`.`id` IN (\n" +
+				"  SELECT `posts`.`user_id`\n" +
+				"  FROM `posts`\n" +
+				"  WHERE `posts`.`id` IN (?, ?)\n" +
+				")",
+		},
+		{
+			name:     "update",
+			query:    "UPDATE `users` SET `name`=? WHERE `users`.`id` = ?",
+			expected: "UPDATE `users`\nSET `name`=?\nWHERE `users`.`id` = ?",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Format(tt.query)
+			if got != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, got)
+			}
+		})
+	}
+}
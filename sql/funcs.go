@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/xhd2015/arc-orm/field"
 	"github.com/xhd2015/arc-orm/sql/expr"
 )
 
@@ -24,6 +25,7 @@ func Rand() rand {
 type sqlFunc struct {
 	name string
 	args []expr.Expr
+	err  error
 }
 
 // Func creates an arbitrary SQL function call.
@@ -32,11 +34,29 @@ func Func(name string, args ...expr.Expr) *sqlFunc {
 	return &sqlFunc{
 		name: name,
 		args: args,
+		err:  validateFuncName(name),
 	}
 }
 
+// validateFuncName checks that a function name, rendered unquoted, is a
+// letter or underscore followed by letters, digits or underscores
+func validateFuncName(name string) error {
+	valid := name != ""
+	for i := 0; i < len(name) && valid; i++ {
+		c := name[i]
+		valid = c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || i > 0 && c >= '0' && c <= '9'
+	}
+	if !valid {
+		return fmt.Errorf("function name: %w: %q", field.ErrInvalidIdentifier, name)
+	}
+	return nil
+}
+
 // ToSQL implements expr.Expr for SQL function calls
 func (f *sqlFunc) ToSQL() (string, []interface{}, error) {
+	if f.err != nil {
+		return "", nil, f.err
+	}
 	var sqlParts []string
 	var params []interface{}
 
@@ -54,7 +74,7 @@ func (f *sqlFunc) ToSQL() (string, []interface{}, error) {
 
 // As returns an aliased version of this function
 func (f *sqlFunc) As(alias string) *aliasedExpr {
-	return &aliasedExpr{expr: f, alias: alias}
+	return newAliasedExpr(f, alias)
 }
 
 // Desc returns a descending order specification for this function
@@ -71,10 +91,20 @@ func (f *sqlFunc) Asc() OrderField {
 type aliasedExpr struct {
 	expr  expr.Expr
 	alias string
+	err   error
+}
+
+// newAliasedExpr aliases the expression, an invalid
+// alias is reported by ToSQL, see field.ValidateIdentifier
+func newAliasedExpr(e expr.Expr, alias string) *aliasedExpr {
+	return &aliasedExpr{expr: e, alias: alias, err: field.ValidateIdentifier(alias)}
 }
 
 // ToSQL implements expr.Expr for aliased expressions
 func (a *aliasedExpr) ToSQL() (string, []interface{}, error) {
+	if a.err != nil {
+		return "", nil, a.err
+	}
 	sql, params, err := a.expr.ToSQL()
 	if err != nil {
 		return "", nil, err
@@ -203,3 +233,92 @@ func JsonSearch(json expr.Expr, oneOrAll expr.Expr, searchStr expr.Expr) *sqlFun
 func Date(f expr.Expr) *sqlFunc {
 	return Func("DATE", f)
 }
+
+// Date and time functions
+
+// IntervalUnit is the unit of DATE_ADD/DATE_SUB intervals and TIMESTAMPDIFF
+type IntervalUnit string
+
+const (
+	UnitSecond  IntervalUnit = "SECOND"
+	UnitMinute  IntervalUnit = "MINUTE"
+	UnitHour    IntervalUnit = "HOUR"
+	UnitDay     IntervalUnit = "DAY"
+	UnitWeek    IntervalUnit = "WEEK"
+	UnitMonth   IntervalUnit = "MONTH"
+	UnitQuarter IntervalUnit = "QUARTER"
+	UnitYear    IntervalUnit = "YEAR"
+)
+
+// ToSQL implements expr.Expr, returning the unit keyword
+func (u IntervalUnit) ToSQL() (string, []interface{}, error) {
+	switch u {
+	case UnitSecond, UnitMinute, UnitHour, UnitDay, UnitWeek, UnitMonth, UnitQuarter, UnitYear:
+		return string(u), nil, nil
+	}
+	return "", nil, fmt.Errorf("invalid interval unit: %q", string(u))
+}
+
+// interval is an INTERVAL n unit expression
+type interval struct {
+	n    int64
+	unit IntervalUnit
+}
+
+func (i interval) ToSQL() (string, []interface{}, error) {
+	unit, _, err := i.unit.ToSQL()
+	if err != nil {
+		return "", nil, err
+	}
+	return "INTERVAL ? " + unit, []interface{}{i.n}, nil
+}
+
+// DateFormat creates a DATE_FORMAT SQL function call.
+// Example: DateFormat(createTime, "%Y-%m") generates DATE_FORMAT(`table`.`create_time`, ?)
+func DateFormat(t expr.Expr, format string) *sqlFunc {
+	return Func("DATE_FORMAT", t, String(format))
+}
+
+// DateAdd creates a DATE_ADD SQL function call.
+// Example: DateAdd(createTime, 7, sql.UnitDay) generates DATE_ADD(`table`.`create_time`, INTERVAL ? DAY)
+func DateAdd(t expr.Expr, n int64, unit IntervalUnit) *sqlFunc {
+	return Func("DATE_ADD", t, interval{n: n, unit: unit})
+}
+
+// DateSub creates a DATE_SUB SQL function call.
+// Example: DateSub(sql.Now(), 1, sql.UnitHour) generates DATE_SUB(NOW(), INTERVAL ? HOUR)
+func DateSub(t expr.Expr, n int64, unit IntervalUnit) *sqlFunc {
+	return Func("DATE_SUB", t, interval{n: n, unit: unit})
+}
+
+// TimestampDiff creates a TIMESTAMPDIFF SQL function call returning end - start in unit.
+// Example: TimestampDiff(sql.UnitDay, createTime, updateTime) generates TIMESTAMPDIFF(DAY, `t`.`create_time`, `t`.`update_time`)
+func TimestampDiff(unit IntervalUnit, start expr.Expr, end expr.Expr) *sqlFunc {
+	return Func("TIMESTAMPDIFF", unit, start, end)
+}
+
+// Now creates a NOW() SQL function call
+func Now() *sqlFunc {
+	return Func("NOW")
+}
+
+// UtcTimestamp creates a UTC_TIMESTAMP() SQL function call
+func UtcTimestamp() *sqlFunc {
+	return Func("UTC_TIMESTAMP")
+}
+
+// Year creates a YEAR SQL function call.
+// Example: Year(createTime) generates YEAR(`table`.`create_time`)
+func Year(t expr.Expr) *sqlFunc {
+	return Func("YEAR", t)
+}
+
+// Month creates a MONTH SQL function call
+func Month(t expr.Expr) *sqlFunc {
+	return Func("MONTH", t)
+}
+
+// Day creates a DAY SQL function call
+func Day(t expr.Expr) *sqlFunc {
+	return Func("DAY", t)
+}
@@ -292,6 +292,51 @@ func TestDateFunc(t *testing.T) {
 	})
 }
 
+func TestDateTimeFuncs(t *testing.T) {
+	testTable := table.New("tasks")
+	createdAt := testTable.Time("created_at")
+	doneAt := testTable.Time("done_at")
+
+	tests := []struct {
+		name         string
+		funcCall     *sqlFunc
+		expectedSQL  string
+		expectedArgs []interface{}
+	}{
+		{"DATE_FORMAT", DateFormat(createdAt, "%Y-%m"), "DATE_FORMAT(`tasks`.`created_at`, ?)", []interface{}{"%Y-%m"}},
+		{"DATE_ADD", DateAdd(createdAt, 7, UnitDay), "DATE_ADD(`tasks`.`created_at`, INTERVAL ? DAY)", []interface{}{int64(7)}},
+		{"DATE_SUB of NOW", DateSub(Now(), 1, UnitHour), "DATE_SUB(NOW(), INTERVAL ? HOUR)", []interface{}{int64(1)}},
+		{"TIMESTAMPDIFF", TimestampDiff(UnitSecond, createdAt, doneAt), "TIMESTAMPDIFF(SECOND, `tasks`.`created_at`, `tasks`.`done_at`)", nil},
+		{"UTC_TIMESTAMP", UtcTimestamp(), "UTC_TIMESTAMP()", nil},
+		{"YEAR", Year(createdAt), "YEAR(`tasks`.`created_at`)", nil},
+		{"MONTH", Month(createdAt), "MONTH(`tasks`.`created_at`)", nil},
+		{"DAY", Day(createdAt), "DAY(`tasks`.`created_at`)", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.funcCall.ToSQL()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sql != tt.expectedSQL {
+				t.Errorf("SQL mismatch:\n  got:  %s\n  want: %s", sql, tt.expectedSQL)
+			}
+			if len(args) != len(tt.expectedArgs) {
+				t.Fatalf("args mismatch: got %v, want %v", args, tt.expectedArgs)
+			}
+			for i, arg := range tt.expectedArgs {
+				if args[i] != arg {
+					t.Errorf("arg[%d] mismatch: got %v, want %v", i, args[i], arg)
+				}
+			}
+		})
+	}
+
+	if _, _, err := DateAdd(createdAt, 1, IntervalUnit("DAY); DROP")).ToSQL(); err == nil {
+		t.Error("expected an error for an invalid interval unit")
+	}
+}
+
 func TestFuncAs(t *testing.T) {
 	// Create test table
 	testTable := table.New("tasks")
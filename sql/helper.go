@@ -1,5 +1,95 @@
 package sql
 
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sql/expr"
+)
+
 func Ptr[T any](v T) *T {
 	return &v
 }
+
+// cloneSlice copies s so that appending to either copy does not affect the other
+func cloneSlice[E any](s []E) []E {
+	if s == nil {
+		return nil
+	}
+	return append(make([]E, 0, len(s)), s...)
+}
+
+// sortedFields returns the fields of values sorted by column name,
+// giving SetMap a stable column order
+func sortedFields(values map[field.Field]expr.Expr) []field.Field {
+	fields := make([]field.Field, 0, len(values))
+	for f := range values {
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name() < fields[j].Name()
+	})
+	return fields
+}
+
+// writeAlias writes the AS clause of a table alias, if any,
+// checked by checkAlias when it was set. A qualified alias like
+// analytics.events is written by its unqualified name, see qualifyByAliases
+func writeAlias(buf *bytes.Buffer, alias string) {
+	if alias == "" {
+		return
+	}
+	buf.WriteString(" AS `")
+	buf.WriteString(aliasName(alias))
+	buf.WriteString("`")
+}
+
+// aliasName returns the unqualified name of a table alias
+func aliasName(alias string) string {
+	return alias[strings.LastIndex(alias, ".")+1:]
+}
+
+// qualifyByAliases rewrites the field references of the tables aliased
+// by qualified names to their unqualified alias, e.g. `analytics`.`events`.`id`
+// to `events`.`id` for a table aliased as analytics.events, as MySQL only
+// resolves aliased tables by their alias
+func qualifyByAliases(query string, alias string, joins []join) string {
+	aliases := []string{alias}
+	for _, join := range joins {
+		aliases = append(aliases, join.alias)
+	}
+	for _, alias := range aliases {
+		if !strings.Contains(alias, ".") {
+			continue
+		}
+		query = strings.ReplaceAll(query, field.QuoteTable(alias)+".`", "`"+aliasName(alias)+"`.`")
+	}
+	return query
+}
+
+// checkTable validates a table name when it is set on a builder,
+// keeping err, the first error of the builder, returned by SQL.
+// Empty names are reported by SQL as missing.
+func checkTable(err error, tableName string) error {
+	if err != nil || tableName == "" {
+		return err
+	}
+	if err := field.ValidateTable(tableName); err != nil {
+		return fmt.Errorf("invalid table: %w", err)
+	}
+	return nil
+}
+
+// checkAlias validates a table alias when it is set on a builder, see checkTable
+func checkAlias(err error, alias string) error {
+	if err != nil || alias == "" {
+		return err
+	}
+	if err := field.ValidateTable(alias); err != nil {
+		return fmt.Errorf("invalid table alias: %w", err)
+	}
+	return nil
+}
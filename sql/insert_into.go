@@ -3,7 +3,6 @@ package sql
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/xhd2015/arc-orm/field"
 	"github.com/xhd2015/arc-orm/sql/expr"
@@ -13,14 +12,21 @@ import (
 func InsertInto(tableName string) *InsertIntoBuilder {
 	return &InsertIntoBuilder{
 		tableName: tableName,
+		err:       checkTable(nil, tableName),
 	}
 }
 
 // InsertIntoBuilder builds INSERT INTO queries
 type InsertIntoBuilder struct {
-	tableName string
-	updates   []updateExpr
-	err       error
+	tableName  string
+	ignore     bool
+	updates    []updateExpr
+	onConflict []updateExpr
+	columns    []field.Field
+	rows       [][]updateExpr
+	fromSelect *SelectBuilder
+	comment    string
+	err        error
 }
 
 // Set adds a column-value pair for insertion
@@ -29,6 +35,10 @@ func (b *InsertIntoBuilder) Set(f field.Field, value expr.Expr) *InsertIntoBuild
 	if b.err != nil {
 		return b // Skip if already errored
 	}
+	if err := field.ValidateIdentifier(f.Name()); err != nil {
+		b.err = fmt.Errorf("SET field: %w", err)
+		return b
+	}
 	exprSQL, exprParams, err := value.ToSQL()
 	if err != nil {
 		b.err = fmt.Errorf("SET field '%s': %w", f.Name(), err)
@@ -42,6 +52,133 @@ func (b *InsertIntoBuilder) Set(f field.Field, value expr.Expr) *InsertIntoBuild
 	return b
 }
 
+// SetIf adds the column-value pair only when cond is true,
+// like Optional for WHERE, keeping optional column writes chainable
+func (b *InsertIntoBuilder) SetIf(cond bool, f field.Field, value expr.Expr) *InsertIntoBuilder {
+	if !cond {
+		return b
+	}
+	return b.Set(f, value)
+}
+
+// SetMap adds a column-value pair for each entry of values,
+// in the order of column names, for columns computed dynamically
+func (b *InsertIntoBuilder) SetMap(values map[field.Field]expr.Expr) *InsertIntoBuilder {
+	for _, f := range sortedFields(values) {
+		b.Set(f, values[f])
+	}
+	return b
+}
+
+// Values references the value a column would have been inserted with,
+// for use in OnDuplicateKeyUpdate, e.g.
+//
+//	OnDuplicateKeyUpdate(Count, sql.Values(Count))
+//
+// generates ON DUPLICATE KEY UPDATE `count`=VALUES(`count`)
+func Values(f field.Field) expr.Expr {
+	return valuesExpr{field: f}
+}
+
+// valuesExpr is the VALUES(col) expression
+type valuesExpr struct {
+	field field.Field
+}
+
+func (v valuesExpr) ToSQL() (string, []interface{}, error) {
+	if err := field.ValidateIdentifier(v.field.Name()); err != nil {
+		return "", nil, err
+	}
+	return "VALUES(`" + v.field.Name() + "`)", nil, nil
+}
+
+// Comment sets a comment appended to the generated SQL, e.g. "svc=checkout route=/pay",
+// so slow queries can be attributed to their call sites. See SanitizeComment.
+func (b *InsertIntoBuilder) Comment(comment string) *InsertIntoBuilder {
+	b.comment = comment
+	return b
+}
+
+// Columns sets the columns filled by FromSelect, in the order of the selected fields,
+// or by Row, in the order of the values
+func (b *InsertIntoBuilder) Columns(columns ...field.Field) *InsertIntoBuilder {
+	for _, column := range columns {
+		if err := field.ValidateIdentifier(column.Name()); err != nil && b.err == nil {
+			b.err = fmt.Errorf("column: %w", err)
+		}
+	}
+	b.columns = append(b.columns, columns...)
+	return b
+}
+
+// Row adds a row of values for Columns, inserting multiple rows in one
+// statement, i.e. INSERT INTO t (columns...) VALUES (...), (...)
+// Example:
+//
+//	sql.InsertInto("users").
+//	    Columns(UserName, UserAge).
+//	    Row(sql.String("Alice"), sql.Int64(30)).
+//	    Row(sql.String("Bob"), sql.Int64(25))
+func (b *InsertIntoBuilder) Row(values ...expr.Expr) *InsertIntoBuilder {
+	if b.err != nil {
+		return b // Skip if already errored
+	}
+	row := make([]updateExpr, len(values))
+	for i, value := range values {
+		exprSQL, exprParams, err := value.ToSQL()
+		if err != nil {
+			b.err = fmt.Errorf("VALUES row %d: %w", len(b.rows), err)
+			return b
+		}
+		row[i] = updateExpr{expr: exprSQL, params: exprParams}
+	}
+	b.rows = append(b.rows, row)
+	return b
+}
+
+// FromSelect inserts the rows returned by the select query,
+// i.e. INSERT INTO t (columns...) SELECT ...
+// Example:
+//
+//	sql.InsertInto("archived_users").
+//	    Columns(ArchivedID, ArchivedName).
+//	    FromSelect(sql.Select(UserID, UserName).From("users").Where(UserAge.Gt(60)))
+func (b *InsertIntoBuilder) FromSelect(selectBuilder *SelectBuilder) *InsertIntoBuilder {
+	b.fromSelect = selectBuilder
+	return b
+}
+
+// Ignore emits INSERT IGNORE, skipping rows that conflict
+// with existing ones on a unique key instead of failing
+func (b *InsertIntoBuilder) Ignore() *InsertIntoBuilder {
+	b.ignore = true
+	return b
+}
+
+// OnDuplicateKeyUpdate adds a column-value pair assigned instead of inserting
+// when the row conflicts with an existing one on a unique key
+// Value must implement expr.Expr
+func (b *InsertIntoBuilder) OnDuplicateKeyUpdate(f field.Field, value expr.Expr) *InsertIntoBuilder {
+	if b.err != nil {
+		return b // Skip if already errored
+	}
+	if err := field.ValidateIdentifier(f.Name()); err != nil {
+		b.err = fmt.Errorf("ON DUPLICATE KEY UPDATE field: %w", err)
+		return b
+	}
+	exprSQL, exprParams, err := value.ToSQL()
+	if err != nil {
+		b.err = fmt.Errorf("ON DUPLICATE KEY UPDATE field '%s': %w", f.Name(), err)
+		return b
+	}
+	b.onConflict = append(b.onConflict, updateExpr{
+		field:  f,
+		expr:   exprSQL,
+		params: exprParams,
+	})
+	return b
+}
+
 // SQL generates the SQL string and parameters
 func (b *InsertIntoBuilder) SQL() (string, []interface{}, error) {
 	// Check for staged errors first
@@ -51,17 +188,99 @@ func (b *InsertIntoBuilder) SQL() (string, []interface{}, error) {
 	if b.tableName == "" {
 		return "", nil, errors.New("table name is required")
 	}
-	if len(b.updates) == 0 {
+	if b.fromSelect != nil {
+		if len(b.updates) > 0 {
+			return "", nil, errors.New("cannot combine Set with FromSelect")
+		}
+		if len(b.rows) > 0 {
+			return "", nil, errors.New("cannot combine Row with FromSelect")
+		}
+		if len(b.columns) == 0 {
+			return "", nil, errors.New("no columns specified")
+		}
+	} else if len(b.rows) > 0 {
+		if len(b.updates) > 0 {
+			return "", nil, errors.New("cannot combine Set with Row")
+		}
+		if len(b.columns) == 0 {
+			return "", nil, errors.New("no columns specified")
+		}
+		for i, row := range b.rows {
+			if len(row) != len(b.columns) {
+				return "", nil, fmt.Errorf("row %d has %d values, expected %d", i, len(row), len(b.columns))
+			}
+		}
+	} else if len(b.updates) == 0 {
 		return "", nil, errors.New("no columns specified")
 	}
 
-	var sqlBuilder strings.Builder
-	var params []interface{}
+	sqlBuilder := getBuffer()
+	defer putBuffer(sqlBuilder)
+	var numParams int
+	for _, update := range b.updates {
+		numParams += len(update.params)
+	}
+	for _, update := range b.onConflict {
+		numParams += len(update.params)
+	}
+	for _, row := range b.rows {
+		for _, value := range row {
+			numParams += len(value.params)
+		}
+	}
+	params := newParams(numParams)
 
 	// Build INSERT INTO clause
-	sqlBuilder.WriteString("INSERT INTO `")
-	sqlBuilder.WriteString(b.tableName)
-	sqlBuilder.WriteString("` SET ")
+	if b.ignore {
+		sqlBuilder.WriteString("INSERT IGNORE INTO ")
+	} else {
+		sqlBuilder.WriteString("INSERT INTO ")
+	}
+	sqlBuilder.WriteString(field.QuoteTable(b.tableName))
+
+	if b.fromSelect != nil || len(b.rows) > 0 {
+		// Build column list
+		sqlBuilder.WriteString(" (")
+		for i, column := range b.columns {
+			if i > 0 {
+				sqlBuilder.WriteString(", ")
+			}
+			sqlBuilder.WriteString("`")
+			sqlBuilder.WriteString(column.Name())
+			sqlBuilder.WriteString("`")
+		}
+		sqlBuilder.WriteString(")")
+	}
+
+	if len(b.rows) > 0 {
+		// Build VALUES clause
+		sqlBuilder.WriteString(" VALUES ")
+		for i, row := range b.rows {
+			if i > 0 {
+				sqlBuilder.WriteString(", ")
+			}
+			sqlBuilder.WriteString("(")
+			for j, value := range row {
+				if j > 0 {
+					sqlBuilder.WriteString(", ")
+				}
+				sqlBuilder.WriteString(value.expr)
+				params = append(params, value.params...)
+			}
+			sqlBuilder.WriteString(")")
+		}
+	} else if b.fromSelect != nil {
+		// Build SELECT clause
+		sqlBuilder.WriteString(" ")
+		selectSQL, selectParams, err := b.fromSelect.SQL()
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to build select: %w", err)
+		}
+		sqlBuilder.WriteString(selectSQL)
+		params = append(params, selectParams...)
+	} else {
+		sqlBuilder.WriteString(" SET ")
+	}
 
 	// Build SET clause
 	for i, update := range b.updates {
@@ -75,5 +294,24 @@ func (b *InsertIntoBuilder) SQL() (string, []interface{}, error) {
 		params = append(params, update.params...)
 	}
 
+	// Build ON DUPLICATE KEY UPDATE clause
+	for i, update := range b.onConflict {
+		if i == 0 {
+			sqlBuilder.WriteString(" ON DUPLICATE KEY UPDATE ")
+		} else {
+			sqlBuilder.WriteString(", ")
+		}
+		sqlBuilder.WriteString("`")
+		sqlBuilder.WriteString(update.field.Name())
+		sqlBuilder.WriteString("`=")
+		sqlBuilder.WriteString(update.expr)
+		params = append(params, update.params...)
+	}
+
+	if err := checkParams("INSERT", params); err != nil {
+		return "", nil, err
+	}
+
+	writeComment(sqlBuilder, b.comment)
 	return sqlBuilder.String(), params, nil
 }
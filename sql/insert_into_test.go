@@ -3,6 +3,9 @@ package sql
 import (
 	"testing"
 	"time"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sql/expr"
 )
 
 func TestInsertIntoBasic(t *testing.T) {
@@ -67,3 +70,137 @@ func TestInsertIntoWithTimeExpression(t *testing.T) {
 		t.Errorf("Expected third param to be time %v, got %v", now, params[2])
 	}
 }
+
+func TestInsertIntoOnDuplicateKeyUpdate(t *testing.T) {
+	query := InsertInto(userTable.Name()).
+		Set(UserName, String("John Doe")).
+		Set(UserAge, Int64(30)).
+		OnDuplicateKeyUpdate(UserAge, Int64(31))
+
+	sqlStr, params, err := query.SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+
+	expectedSQL := "INSERT INTO `users` SET `name`=?, `age`=? ON DUPLICATE KEY UPDATE `age`=?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 3 {
+		t.Fatalf("Expected 3 params, got %d", len(params))
+	}
+	if v, ok := params[2].(int64); !ok || v != 31 {
+		t.Errorf("Expected third param to be int64(31), got %T %v", params[2], params[2])
+	}
+}
+
+func TestInsertIgnore(t *testing.T) {
+	sqlStr, _, err := InsertInto(userTable.Name()).
+		Ignore().
+		Set(UserName, String("John Doe")).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "INSERT IGNORE INTO `users` SET `name`=?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+}
+
+func TestInsertIntoFromSelect(t *testing.T) {
+	sqlStr, params, err := InsertInto(postTable.Name()).
+		Columns(PostUserID, PostTitle).
+		FromSelect(Select(UserID, UserName).From(userTable.Name()).Where(UserAge.Gt(18))).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "INSERT INTO `posts` (`user_id`, `title`) SELECT `users`.`id`, `users`.`name` FROM `users` WHERE `users`.`age` > ?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 1 || params[0] != int64(18) {
+		t.Errorf("Expected params [18], got %v", params)
+	}
+}
+
+func TestInsertIntoSetMap(t *testing.T) {
+	sqlStr, params, err := InsertInto(userTable.Name()).
+		SetMap(map[field.Field]expr.Expr{
+			UserName:  String("John Doe"),
+			UserAge:   Int64(30),
+			UserEmail: String("john@example.com"),
+		}).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "INSERT INTO `users` SET `age`=?, `email`=?, `name`=?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 3 || params[0] != int64(30) || params[1] != "john@example.com" || params[2] != "John Doe" {
+		t.Errorf("Unexpected params: %v", params)
+	}
+}
+
+func TestInsertIntoOnDuplicateKeyUpdateValues(t *testing.T) {
+	sqlStr, params, err := InsertInto(userTable.Name()).
+		Set(UserName, String("John Doe")).
+		Set(UserAge, Int64(30)).
+		OnDuplicateKeyUpdate(UserAge, Values(UserAge)).
+		OnDuplicateKeyUpdate(UserName, Concat(UserName, Values(UserName))).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "INSERT INTO `users` SET `name`=?, `age`=? ON DUPLICATE KEY UPDATE `age`=VALUES(`age`), `name`=CONCAT(`users`.`name`, VALUES(`name`))"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 2 {
+		t.Errorf("Expected 2 params, got %v", params)
+	}
+}
+
+func TestInsertIntoSetIf(t *testing.T) {
+	sqlStr, _, err := InsertInto(userTable.Name()).
+		Set(UserName, String("John Doe")).
+		SetIf(false, UserEmail, String("")).
+		SetIf(true, UserAge, Int64(30)).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "INSERT INTO `users` SET `name`=?, `age`=?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+}
+
+func TestInsertIntoRows(t *testing.T) {
+	sqlStr, params, err := InsertInto(userTable.Name()).
+		Columns(UserName, UserAge).
+		Row(String("Alice"), Int64(30)).
+		Row(String("Bob"), Int64(25)).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "INSERT INTO `users` (`name`, `age`) VALUES (?, ?), (?, ?)"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 4 || params[0] != "Alice" || params[3] != int64(25) {
+		t.Errorf("Expected params [Alice 30 Bob 25], got %v", params)
+	}
+
+	_, _, err = InsertInto(userTable.Name()).
+		Columns(UserName, UserAge).
+		Row(String("Alice")).
+		SQL()
+	if err == nil || err.Error() != "row 0 has 1 values, expected 2" {
+		t.Errorf("Expected row length error, got %v", err)
+	}
+}
@@ -10,6 +10,11 @@ func (s String) ToSQL() (string, []interface{}, error) {
 	return "?", []interface{}{string(s)}, nil
 }
 
+// As returns this string literal with an alias, for SELECT
+func (s String) As(alias string) *aliasedExpr {
+	return newAliasedExpr(s, alias)
+}
+
 // Int64 is an int64 literal expression for use in SQL statements
 type Int64 int64
 
@@ -18,6 +23,12 @@ func (i Int64) ToSQL() (string, []interface{}, error) {
 	return "?", []interface{}{int64(i)}, nil
 }
 
+// As returns this int64 literal with an alias, for SELECT
+func (i Int64) As(alias string) *aliasedExpr {
+	return newAliasedExpr(i, alias)
+}
+
+// Int32 is an int32 literal expression for use in SQL statements
 type Int32 int32
 
 // ToSQL implements field.Expr for int32 literals
@@ -25,6 +36,11 @@ func (i Int32) ToSQL() (string, []interface{}, error) {
 	return "?", []interface{}{int32(i)}, nil
 }
 
+// As returns this int32 literal with an alias, for SELECT
+func (i Int32) As(alias string) *aliasedExpr {
+	return newAliasedExpr(i, alias)
+}
+
 // Float64 is a float64 literal expression for use in SQL statements
 type Float64 float64
 
@@ -33,6 +49,11 @@ func (f Float64) ToSQL() (string, []interface{}, error) {
 	return "?", []interface{}{float64(f)}, nil
 }
 
+// As returns this float64 literal with an alias, for SELECT
+func (f Float64) As(alias string) *aliasedExpr {
+	return newAliasedExpr(f, alias)
+}
+
 // Bool is a boolean literal expression for use in SQL statements
 type Bool bool
 
@@ -41,6 +62,11 @@ func (b Bool) ToSQL() (string, []interface{}, error) {
 	return "?", []interface{}{bool(b)}, nil
 }
 
+// As returns this boolean literal with an alias, for SELECT
+func (b Bool) As(alias string) *aliasedExpr {
+	return newAliasedExpr(b, alias)
+}
+
 // Time is a time.Time literal expression for use in SQL statements
 type Time time.Time
 
@@ -48,3 +74,8 @@ type Time time.Time
 func (t Time) ToSQL() (string, []interface{}, error) {
 	return "?", []interface{}{time.Time(t)}, nil
 }
+
+// As returns this time literal with an alias, for SELECT
+func (t Time) As(alias string) *aliasedExpr {
+	return newAliasedExpr(t, alias)
+}
@@ -176,3 +176,19 @@ func TestLiteralsInInsert(t *testing.T) {
 		t.Errorf("args[1] mismatch: got %v", args[1])
 	}
 }
+
+func TestSelectLiteralsAndExpressions(t *testing.T) {
+	query := Select(Int64(1).As("flag"), String("x").As("tag"), UserAge.Mul(UserID).As("total")).
+		From(userTable.Name())
+	sqlStr, params, err := query.SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expected := "SELECT ? AS `flag`, ? AS `tag`, `users`.`age` * `users`.`id` AS `total` FROM `users`"
+	if sqlStr != expected {
+		t.Errorf("Expected SQL: %s, got: %s", expected, sqlStr)
+	}
+	if len(params) != 2 || params[0] != int64(1) || params[1] != "x" {
+		t.Errorf("Expected params [1 x], got %v", params)
+	}
+}
@@ -14,31 +14,13 @@ func And(conditions ...expr.Expr) expr.Expr {
 	return field.And(conditions...)
 }
 
-type not struct {
-	conditions []Expr
-}
-
-func Not(conditions ...expr.Expr) *not {
-	return &not{
-		conditions: conditions,
-	}
-}
-
-func (n *not) ToSQL() (string, []interface{}, error) {
-	if len(n.conditions) == 0 {
-		return "", nil, nil
-	}
-
-	var cond Expr
-	if len(n.conditions) > 1 {
-		cond = And(n.conditions...)
-	} else {
-		cond = n.conditions[0]
+// Not negates the conditions joined by AND as NOT (...)
+func Not(conditions ...expr.Expr) expr.Expr {
+	if len(conditions) == 1 {
+		return field.Not(conditions[0])
 	}
-
-	condSQL, condParams, err := cond.ToSQL()
-	if err != nil {
-		return "", nil, err
+	if len(conditions) == 0 {
+		return field.Not(nil)
 	}
-	return "NOT (" + condSQL + ")", condParams, nil
+	return field.Not(And(conditions...))
 }
@@ -0,0 +1,24 @@
+package sql
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxParams is the maximum number of placeholders
+// of a MySQL prepared statement
+const MaxParams = 65535
+
+// ErrTooManyParams is returned by the builders generating
+// a statement with more than MaxParams placeholders
+var ErrTooManyParams = errors.New("too many placeholders")
+
+// checkParams fails with ErrTooManyParams if the
+// statement has more than MaxParams placeholders
+func checkParams(statement string, params []interface{}) error {
+	if len(params) <= MaxParams {
+		return nil
+	}
+	return fmt.Errorf("%w: %s has %d placeholders, MySQL allows at most %d, split large IN lists into multiple statements",
+		ErrTooManyParams, statement, len(params), MaxParams)
+}
@@ -0,0 +1,29 @@
+package sql
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// writePartitions writes the PARTITION (...) clause following
+// the table name, if any partitions are selected
+func writePartitions(buf *bytes.Buffer, partitions []string) error {
+	if len(partitions) == 0 {
+		return nil
+	}
+	buf.WriteString(" PARTITION (")
+	for i, p := range partitions {
+		if p == "" || strings.Contains(p, "`") {
+			return fmt.Errorf("invalid partition name: %q", p)
+		}
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString("`")
+		buf.WriteString(p)
+		buf.WriteString("`")
+	}
+	buf.WriteString(")")
+	return nil
+}
@@ -0,0 +1,33 @@
+package sql
+
+import "testing"
+
+func TestPartition(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder interface {
+			SQL() (string, []interface{}, error)
+		}
+		expected string
+	}{
+		{"select", Select(UserID).From(userTable.Name()).Partition("p2024", "p2025").Where(UserAge.Gt(18)), "SELECT `users`.`id` FROM `users` PARTITION (`p2024`, `p2025`) WHERE `users`.`age` > ?"},
+		{"update", Update(userTable.Name()).Partition("p2024").Set(UserAge, Int64(1)).Where(UserID.Eq(1)), "UPDATE `users` PARTITION (`p2024`) SET `age`=? WHERE `users`.`id` = ?"},
+		{"delete", DeleteFrom(userTable.Name()).Partition("p2024").Where(UserID.Eq(1)), "DELETE FROM `users` PARTITION (`p2024`) WHERE `users`.`id` = ?"},
+		{"multi-table delete", DeleteFrom(userTable.Name()).Partition("p2024").Join(postTable.Name(), UserID.EqField(PostUserID)), "DELETE `users` FROM `users` PARTITION (`p2024`) JOIN `posts` ON `users`.`id` = `posts`.`user_id`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlStr, _, err := tt.builder.SQL()
+			if err != nil {
+				t.Fatalf("Failed to generate SQL: %v", err)
+			}
+			if sqlStr != tt.expected {
+				t.Errorf("Expected SQL: %s, got: %s", tt.expected, sqlStr)
+			}
+		})
+	}
+
+	if _, _, err := Select(UserID).From(userTable.Name()).Partition("p`").SQL(); err == nil {
+		t.Errorf("Expected error for invalid partition name")
+	}
+}
@@ -0,0 +1,36 @@
+package sql
+
+import "errors"
+
+// ErrFullTable is returned by the UPDATE and DELETE builders with
+// RequireWhere when the statement has no WHERE condition
+var ErrFullTable = errors.New("no WHERE condition, the statement would affect the whole table, see AllowFullTable")
+
+// RequireWhere makes SQL fail with ErrFullTable if the statement has no
+// WHERE condition, e.g. all its conditions are Optional with false,
+// a guard against updating the whole table by a typo
+func (b *UpdateBuilder) RequireWhere() *UpdateBuilder {
+	b.requireWhere = true
+	return b
+}
+
+// AllowFullTable overrides RequireWhere for a statement
+// intended to update every row of the table
+func (b *UpdateBuilder) AllowFullTable() *UpdateBuilder {
+	b.allowFullTable = true
+	return b
+}
+
+// RequireWhere makes SQL fail with ErrFullTable if the statement
+// has no WHERE condition, see UpdateBuilder.RequireWhere
+func (b *DeleteBuilder) RequireWhere() *DeleteBuilder {
+	b.requireWhere = true
+	return b
+}
+
+// AllowFullTable overrides RequireWhere for a statement
+// intended to delete every row of the table
+func (b *DeleteBuilder) AllowFullTable() *DeleteBuilder {
+	b.allowFullTable = true
+	return b
+}
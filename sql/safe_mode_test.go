@@ -0,0 +1,38 @@
+package sql
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRequireWhere(t *testing.T) {
+	_, _, err := Update(userTable.Name()).
+		Set(UserAge, Int64(18)).
+		Where(Optional(false, UserName.Eq("John"))).
+		RequireWhere().
+		SQL()
+	if !errors.Is(err, ErrFullTable) {
+		t.Errorf("Expected ErrFullTable, got %v", err)
+	}
+
+	_, _, err = DeleteFrom(userTable.Name()).RequireWhere().SQL()
+	if !errors.Is(err, ErrFullTable) {
+		t.Errorf("Expected ErrFullTable, got %v", err)
+	}
+
+	sqlStr, _, err := DeleteFrom(userTable.Name()).RequireWhere().Where(UserAge.Gt(60)).SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	if expectedSQL := "DELETE FROM `users` WHERE `users`.`age` > ?"; sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+
+	sqlStr, _, err = Update(userTable.Name()).Set(UserAge, Int64(18)).RequireWhere().AllowFullTable().SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	if expectedSQL := "UPDATE `users` SET `age`=?"; sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+}
@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"strings"
@@ -24,10 +25,20 @@ func Count(f field.Field) AggregateFunc {
 	}
 }
 
+// CountDistinct creates a COUNT(DISTINCT field) expression
+func CountDistinct(f field.Field) AggregateFunc {
+	return AggregateFunc{
+		name:     "COUNT",
+		field:    f,
+		distinct: true,
+	}
+}
+
 // AggregateFunc represents an aggregate function like COUNT, SUM, etc.
 type AggregateFunc struct {
-	name  string
-	field field.Field
+	name     string
+	field    field.Field
+	distinct bool
 }
 
 // OrderField represents a field with ordering direction
@@ -56,11 +67,17 @@ func (a AggregateFunc) ToSQL() (string, []interface{}, error) {
 	if err != nil {
 		return "", nil, err
 	}
+	if a.distinct {
+		sql = "DISTINCT " + sql
+	}
 	return a.name + "(" + sql + ")", params, nil
 }
 
 // Name implements the Field interface
 func (a AggregateFunc) Name() string {
+	if a.distinct {
+		return a.name + "(DISTINCT " + a.field.Name() + ")"
+	}
 	return a.name + "(" + a.field.Name() + ")"
 }
 
@@ -84,6 +101,33 @@ func (a AggregateFunc) Desc() OrderField {
 	return OrderField{Field: a, Desc: true}
 }
 
+// AliasRef references a column alias of the select list by name,
+// e.g. for ORDER BY on an aggregate selected with As
+type AliasRef string
+
+// Alias creates a reference to the select alias with the given name
+func Alias(name string) AliasRef {
+	return AliasRef(name)
+}
+
+// ToSQL returns the quoted alias
+func (a AliasRef) ToSQL() (string, []interface{}, error) {
+	if a == "" || strings.ContainsAny(string(a), "`") {
+		return "", nil, fmt.Errorf("invalid alias: %q", string(a))
+	}
+	return "`" + string(a) + "`", nil, nil
+}
+
+// Asc returns an ascending order specification for this alias
+func (a AliasRef) Asc() OrderField {
+	return OrderField{Field: a, Desc: false}
+}
+
+// Desc returns a descending order specification for this alias
+func (a AliasRef) Desc() OrderField {
+	return OrderField{Field: a, Desc: true}
+}
+
 // Max creates a MAX expression
 func Max(f field.Field) AggregateFunc {
 	return AggregateFunc{
@@ -92,20 +136,72 @@ func Max(f field.Field) AggregateFunc {
 	}
 }
 
-// Gt creates a greater than condition
-func (a AggregateFunc) Gt(value int64) field.Expr {
-	return &havingCondition{
-		expr:  a,
-		op:    ">",
-		value: value,
+// Min creates a MIN expression
+func Min(f field.Field) AggregateFunc {
+	return AggregateFunc{
+		name:  "MIN",
+		field: f,
+	}
+}
+
+// Sum creates a SUM expression
+func Sum(f field.Field) AggregateFunc {
+	return AggregateFunc{
+		name:  "SUM",
+		field: f,
 	}
 }
 
-// Lt creates a less than condition
-func (a AggregateFunc) Lt(value int64) field.Expr {
+// Avg creates an AVG expression
+func Avg(f field.Field) AggregateFunc {
+	return AggregateFunc{
+		name:  "AVG",
+		field: f,
+	}
+}
+
+// Gt creates a greater than condition for HAVING, value can be
+// a number like int64 or float64, or an expression such as another aggregate
+func (a AggregateFunc) Gt(value interface{}) field.Expr {
+	return a.having(">", value)
+}
+
+// Gte creates a greater than or equal to condition for HAVING
+func (a AggregateFunc) Gte(value interface{}) field.Expr {
+	return a.having(">=", value)
+}
+
+// Lt creates a less than condition for HAVING
+func (a AggregateFunc) Lt(value interface{}) field.Expr {
+	return a.having("<", value)
+}
+
+// Lte creates a less than or equal to condition for HAVING
+func (a AggregateFunc) Lte(value interface{}) field.Expr {
+	return a.having("<=", value)
+}
+
+// Eq creates an equality condition for HAVING
+func (a AggregateFunc) Eq(value interface{}) field.Expr {
+	return a.having("=", value)
+}
+
+// Neq creates a not equal condition for HAVING
+func (a AggregateFunc) Neq(value interface{}) field.Expr {
+	return a.having("!=", value)
+}
+
+func (a AggregateFunc) having(op string, value interface{}) field.Expr {
+	// keep integer params as int64, as when Gt and Lt took int64
+	switch v := value.(type) {
+	case int:
+		value = int64(v)
+	case int32:
+		value = int64(v)
+	}
 	return &havingCondition{
 		expr:  a,
-		op:    "<",
+		op:    op,
 		value: value,
 	}
 }
@@ -122,13 +218,22 @@ func (c *havingCondition) ToSQL() (string, []interface{}, error) {
 	if err != nil {
 		return "", nil, err
 	}
+	if other, ok := c.value.(expr.Expr); ok {
+		otherSQL, otherParams, err := other.ToSQL()
+		if err != nil {
+			return "", nil, err
+		}
+		return sql + " " + c.op + " " + otherSQL, append(params, otherParams...), nil
+	}
 	return sql + " " + c.op + " ?", append(params, c.value), nil
 }
 
 // SelectBuilder builds SELECT queries
 type SelectBuilder struct {
+	hints         []string
 	fields        []Expr
 	tableName     string
+	alias         string
 	joins         []join
 	conditions    []field.Expr
 	excludeFields []field.Field
@@ -139,10 +244,15 @@ type SelectBuilder struct {
 	offset        int
 	hasLimit      bool
 	hasOffset     bool
+	partitions    []string
+	comment       string
+	// err is the first invalid table or alias set, returned by SQL
+	err error
 }
 
 type join struct {
 	tableName string
+	alias     string
 	condition field.Expr
 	joinType  string
 }
@@ -151,9 +261,44 @@ type orderBy struct {
 	field expr.Expr
 }
 
+// Hint adds MySQL optimizer hints, emitted right after SELECT, e.g.
+//
+//	Hint("MAX_EXECUTION_TIME(1000)", "SET_VAR(sort_buffer_size = 16M)")
+//
+// generates SELECT /*+ MAX_EXECUTION_TIME(1000) SET_VAR(sort_buffer_size = 16M) */ ...
+func (b *SelectBuilder) Hint(hints ...string) *SelectBuilder {
+	b.hints = append(b.hints, hints...)
+	return b
+}
+
+// Fields replaces the selected fields
+func (b *SelectBuilder) Fields(fields ...Expr) *SelectBuilder {
+	b.fields = fields
+	return b
+}
+
 // From specifies the table to select from
 func (b *SelectBuilder) From(tableName string) *SelectBuilder {
 	b.tableName = tableName
+	b.err = checkTable(b.err, tableName)
+	return b
+}
+
+// As sets the alias of the table to select from, e.g. the logical name
+// of a physical table: From("event_202501").As("events") generates
+// FROM `event_202501` AS `events`, so qualified fields refer to the alias.
+// A schema-qualified alias like analytics.events aliases the table as
+// `events`, and the fields of analytics.events are qualified by `events`.
+func (b *SelectBuilder) As(alias string) *SelectBuilder {
+	b.alias = alias
+	b.err = checkAlias(b.err, alias)
+	return b
+}
+
+// Partition restricts the query to the given partitions
+// of a partitioned table, i.e. FROM t PARTITION (p2024, p2025)
+func (b *SelectBuilder) Partition(partitions ...string) *SelectBuilder {
+	b.partitions = append(b.partitions, partitions...)
 	return b
 }
 
@@ -171,6 +316,7 @@ func (b *SelectBuilder) Exclude(fields ...field.Field) *SelectBuilder {
 
 // Join adds a join clause to the query
 func (b *SelectBuilder) Join(tableName string, condition field.Expr) *SelectBuilder {
+	b.err = checkTable(b.err, tableName)
 	b.joins = append(b.joins, join{
 		tableName: tableName,
 		condition: condition,
@@ -181,8 +327,33 @@ func (b *SelectBuilder) Join(tableName string, condition field.Expr) *SelectBuil
 
 // LeftJoin adds a left join clause to the query
 func (b *SelectBuilder) LeftJoin(tableName string, condition field.Expr) *SelectBuilder {
+	b.err = checkTable(b.err, tableName)
+	b.joins = append(b.joins, join{
+		tableName: tableName,
+		condition: condition,
+		joinType:  "LEFT JOIN",
+	})
+	return b
+}
+
+// JoinAs adds a join clause on the table aliased as alias, see As
+func (b *SelectBuilder) JoinAs(tableName string, alias string, condition field.Expr) *SelectBuilder {
+	b.err = checkAlias(checkTable(b.err, tableName), alias)
+	b.joins = append(b.joins, join{
+		tableName: tableName,
+		alias:     alias,
+		condition: condition,
+		joinType:  "JOIN",
+	})
+	return b
+}
+
+// LeftJoinAs adds a left join clause on the table aliased as alias, see As
+func (b *SelectBuilder) LeftJoinAs(tableName string, alias string, condition field.Expr) *SelectBuilder {
+	b.err = checkAlias(checkTable(b.err, tableName), alias)
 	b.joins = append(b.joins, join{
 		tableName: tableName,
+		alias:     alias,
 		condition: condition,
 		joinType:  "LEFT JOIN",
 	})
@@ -196,6 +367,17 @@ func (b *SelectBuilder) GroupBy(exprs ...expr.Expr) *SelectBuilder {
 	return b
 }
 
+// WhereSQL returns the WHERE conditions joined by AND,
+// without the WHERE keyword, or "" if there are none
+func (b *SelectBuilder) WhereSQL() (string, []interface{}, error) {
+	var sqlBuilder bytes.Buffer
+	params, err := writeConditions(&sqlBuilder, nil, "", b.conditions)
+	if err != nil {
+		return "", nil, err
+	}
+	return qualifyByAliases(sqlBuilder.String(), b.alias, b.joins), params, nil
+}
+
 // Having adds HAVING conditions to the query
 func (b *SelectBuilder) Having(conditions ...field.Expr) *SelectBuilder {
 	b.havings = append(b.havings, conditions...)
@@ -210,6 +392,12 @@ func (b *SelectBuilder) OrderBy(orderFields ...expr.Expr) *SelectBuilder {
 	return b
 }
 
+// OrderByAlias adds an ORDER BY on a select alias, e.g. one
+// given to an aggregate with As
+func (b *SelectBuilder) OrderByAlias(alias string, desc bool) *SelectBuilder {
+	return b.OrderBy(OrderField{Field: Alias(alias), Desc: desc})
+}
+
 // Limit sets the LIMIT value
 func (b *SelectBuilder) Limit(limit int) *SelectBuilder {
 	b.limit = limit
@@ -217,6 +405,11 @@ func (b *SelectBuilder) Limit(limit int) *SelectBuilder {
 	return b
 }
 
+// HasLimit reports whether a LIMIT is set
+func (b *SelectBuilder) HasLimit() bool {
+	return b.hasLimit
+}
+
 // Offset sets the OFFSET value
 func (b *SelectBuilder) Offset(offset int) *SelectBuilder {
 	b.offset = offset
@@ -224,17 +417,54 @@ func (b *SelectBuilder) Offset(offset int) *SelectBuilder {
 	return b
 }
 
+// Comment sets a comment appended to the generated SQL, e.g. "svc=checkout route=/pay",
+// so slow queries can be attributed to their call sites. See SanitizeComment.
+func (b *SelectBuilder) Comment(comment string) *SelectBuilder {
+	b.comment = comment
+	return b
+}
+
+// Clone returns a copy of the builder, so that a base query
+// can be forked without the variants affecting each other
+func (b *SelectBuilder) Clone() *SelectBuilder {
+	c := *b
+	c.hints = cloneSlice(b.hints)
+	c.partitions = cloneSlice(b.partitions)
+	c.fields = cloneSlice(b.fields)
+	c.joins = cloneSlice(b.joins)
+	c.conditions = cloneSlice(b.conditions)
+	c.excludeFields = cloneSlice(b.excludeFields)
+	c.groupBys = cloneSlice(b.groupBys)
+	c.havings = cloneSlice(b.havings)
+	c.orderBys = cloneSlice(b.orderBys)
+	return &c
+}
+
 // SQL generates the SQL string and parameters
 func (b *SelectBuilder) SQL() (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
 	if b.tableName == "" {
 		return "", nil, errors.New("from table is required")
 	}
 
-	var sqlBuilder strings.Builder
-	var params []interface{}
+	sqlBuilder := getBuffer()
+	defer putBuffer(sqlBuilder)
+	params := newParams(len(b.conditions) + len(b.havings))
 
 	// Build SELECT clause
 	sqlBuilder.WriteString("SELECT ")
+	if len(b.hints) > 0 {
+		for _, hint := range b.hints {
+			if strings.Contains(hint, "*/") {
+				return "", nil, fmt.Errorf("invalid hint: %q", hint)
+			}
+		}
+		sqlBuilder.WriteString("/*+ ")
+		sqlBuilder.WriteString(strings.Join(b.hints, " "))
+		sqlBuilder.WriteString(" */ ")
+	}
 
 	if len(b.fields) == 0 && len(b.excludeFields) > 0 {
 		return "", nil, errors.New("exclude fields without selected fields")
@@ -268,47 +498,23 @@ func (b *SelectBuilder) SQL() (string, []interface{}, error) {
 	}
 
 	// Build FROM clause
-	sqlBuilder.WriteString(" FROM `")
-	sqlBuilder.WriteString(b.tableName)
-	sqlBuilder.WriteString("`")
+	sqlBuilder.WriteString(" FROM ")
+	sqlBuilder.WriteString(field.QuoteTable(b.tableName))
+	if err := writePartitions(sqlBuilder, b.partitions); err != nil {
+		return "", nil, err
+	}
+	writeAlias(sqlBuilder, b.alias)
 
 	// Build JOIN clauses
-	for _, join := range b.joins {
-		sqlBuilder.WriteString(" ")
-		sqlBuilder.WriteString(join.joinType)
-		sqlBuilder.WriteString(" `")
-		sqlBuilder.WriteString(join.tableName)
-		sqlBuilder.WriteString("` ON ")
-
-		joinSQL, joinParams, err := join.condition.ToSQL()
-		if err != nil {
-			return "", nil, fmt.Errorf("failed to build join condition: %w", err)
-		}
-		if joinSQL == "" {
-			continue
-		}
-		sqlBuilder.WriteString(joinSQL)
-		params = append(params, joinParams...)
+	params, err := writeJoins(sqlBuilder, params, b.joins)
+	if err != nil {
+		return "", nil, err
 	}
 
 	// Build WHERE clause
-	if len(b.conditions) > 0 {
-		whereClauses := make([]string, 0, len(b.conditions))
-		for _, condition := range b.conditions {
-			condSQL, condParams, err := condition.ToSQL()
-			if err != nil {
-				return "", nil, fmt.Errorf("failed to build where condition: %w", err)
-			}
-			if condSQL == "" {
-				continue
-			}
-			whereClauses = append(whereClauses, condSQL)
-			params = append(params, condParams...)
-		}
-		if len(whereClauses) > 0 {
-			sqlBuilder.WriteString(" WHERE ")
-			sqlBuilder.WriteString(strings.Join(whereClauses, " AND "))
-		}
+	params, err = writeConditions(sqlBuilder, params, " WHERE ", b.conditions)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build where condition: %w", err)
 	}
 
 	// Build GROUP BY clause
@@ -328,23 +534,9 @@ func (b *SelectBuilder) SQL() (string, []interface{}, error) {
 	}
 
 	// Build HAVING clause
-	if len(b.havings) > 0 {
-		havingClauses := make([]string, 0, len(b.havings))
-		for _, condition := range b.havings {
-			condSQL, condParams, err := condition.ToSQL()
-			if err != nil {
-				return "", nil, fmt.Errorf("failed to build having condition: %w", err)
-			}
-			if condSQL == "" {
-				continue
-			}
-			havingClauses = append(havingClauses, condSQL)
-			params = append(params, condParams...)
-		}
-		if len(havingClauses) > 0 {
-			sqlBuilder.WriteString(" HAVING ")
-			sqlBuilder.WriteString(strings.Join(havingClauses, " AND "))
-		}
+	params, err = writeConditions(sqlBuilder, params, " HAVING ", b.havings)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build having condition: %w", err)
 	}
 
 	// Build ORDER BY clause
@@ -366,14 +558,71 @@ func (b *SelectBuilder) SQL() (string, []interface{}, error) {
 	// Add LIMIT and OFFSET
 	if b.hasLimit && b.hasOffset {
 		// short form
-		sqlBuilder.WriteString(fmt.Sprintf(" LIMIT %d,%d", b.offset, b.limit))
+		sqlBuilder.WriteString(" LIMIT ")
+		writeInt(sqlBuilder, b.offset)
+		sqlBuilder.WriteByte(',')
+		writeInt(sqlBuilder, b.limit)
 	} else if b.hasLimit {
-		sqlBuilder.WriteString(fmt.Sprintf(" LIMIT %d", b.limit))
+		sqlBuilder.WriteString(" LIMIT ")
+		writeInt(sqlBuilder, b.limit)
 	} else if b.hasOffset {
-		sqlBuilder.WriteString(fmt.Sprintf(" OFFSET %d", b.offset))
+		sqlBuilder.WriteString(" OFFSET ")
+		writeInt(sqlBuilder, b.offset)
 	}
 
-	return sqlBuilder.String(), params, nil
+	if err := checkParams("SELECT", params); err != nil {
+		return "", nil, err
+	}
+
+	writeComment(sqlBuilder, b.comment)
+	return qualifyByAliases(sqlBuilder.String(), b.alias, b.joins), params, nil
+}
+
+// writeJoins writes the JOIN clauses, appending their params
+func writeJoins(sqlBuilder *bytes.Buffer, params []interface{}, joins []join) ([]interface{}, error) {
+	for _, join := range joins {
+		sqlBuilder.WriteString(" ")
+		sqlBuilder.WriteString(join.joinType)
+		sqlBuilder.WriteString(" ")
+		sqlBuilder.WriteString(field.QuoteTable(join.tableName))
+		writeAlias(sqlBuilder, join.alias)
+		sqlBuilder.WriteString(" ON ")
+
+		joinSQL, joinParams, err := join.condition.ToSQL()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build join condition: %w", err)
+		}
+		if joinSQL == "" {
+			continue
+		}
+		sqlBuilder.WriteString(joinSQL)
+		params = append(params, joinParams...)
+	}
+	return params, nil
+}
+
+// writeConditions writes the non-empty conditions joined by AND after
+// the keyword, appending their params
+func writeConditions(sqlBuilder *bytes.Buffer, params []interface{}, keyword string, conditions []field.Expr) ([]interface{}, error) {
+	var n int
+	for _, condition := range conditions {
+		condSQL, condParams, err := condition.ToSQL()
+		if err != nil {
+			return nil, err
+		}
+		if condSQL == "" {
+			continue
+		}
+		if n == 0 {
+			sqlBuilder.WriteString(keyword)
+		} else {
+			sqlBuilder.WriteString(" AND ")
+		}
+		n++
+		sqlBuilder.WriteString(condSQL)
+		params = append(params, condParams...)
+	}
+	return params, nil
 }
 
 func stringsContains(list []string, item string) bool {
@@ -1,10 +1,12 @@
 package sql
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
 	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sqltest"
 	"github.com/xhd2015/arc-orm/table"
 )
 
@@ -34,22 +36,7 @@ func TestTypeBasedSqlBuilder(t *testing.T) {
 		From(userTable.Name()).
 		Where(UserID.Eq(1))
 
-	sqlStr, params, err := query.SQL()
-	if err != nil {
-		t.Fatalf("Failed to generate SQL: %v", err)
-	}
-
-	expectedSQL := "SELECT `users`.`id`, `users`.`name`, `users`.`email` FROM `users` WHERE `users`.`id` = ?"
-	if sqlStr != expectedSQL {
-		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
-	}
-
-	if len(params) != 1 {
-		t.Errorf("Expected 1 param, got %d", len(params))
-	}
-	if v, ok := params[0].(int64); !ok || v != 1 {
-		t.Errorf("Expected param to be int64(1), got %T %v", params[0], params[0])
-	}
+	sqltest.AssertSQL(t, query, "SELECT `users`.`id`, `users`.`name`, `users`.`email` FROM `users` WHERE `users`.`id` = ?", int64(1))
 }
 
 var commentTable = table.New("comments")
@@ -206,19 +193,6 @@ func TestStringOperations(t *testing.T) {
 	}
 }
 
-// Helper function to create a field.OrderField for a column name
-func fieldAsc(name string) field.OrderField {
-	// Create a StringField with empty table (works for aliases in ORDER BY)
-	f := field.StringField{FieldName: name, TableName: ""}
-	return f.Asc()
-}
-
-func fieldDesc(name string) field.OrderField {
-	// Create a StringField with empty table (works for aliases in ORDER BY)
-	f := field.StringField{FieldName: name, TableName: ""}
-	return f.Desc()
-}
-
 func TestAggregatesAndGroupBy(t *testing.T) {
 	// Test GROUP BY and aggregate functions
 	query := Select(UserID, Count(PostID).As("post_count")).
@@ -279,7 +253,7 @@ func TestAggregatesAndGroupBy(t *testing.T) {
 		).
 		GroupBy(UserID, UserName, PostID, PostTitle).
 		Having(Count(CommentID).Gt(2)).
-		OrderBy(fieldDesc("comment_count")).
+		OrderBy(Alias("comment_count").Desc()).
 		Limit(10).
 		Offset(20)
 
@@ -288,7 +262,7 @@ func TestAggregatesAndGroupBy(t *testing.T) {
 		t.Fatalf("Failed to generate SQL: %v", err)
 	}
 
-	expectedComplexSQL := "SELECT `users`.`id`, `users`.`name`, `posts`.`id`, `posts`.`title`, COUNT(`comments`.`id`) AS `comment_count` FROM `users` JOIN `posts` ON `users`.`id` = `posts`.`user_id` LEFT JOIN `comments` ON `posts`.`id` = `comments`.`post_id` WHERE `users`.`id` > ? AND `posts`.`title` LIKE ? GROUP BY `users`.`id`, `users`.`name`, `posts`.`id`, `posts`.`title` HAVING COUNT(`comments`.`id`) > ? ORDER BY ``.`comment_count` DESC LIMIT 20,10"
+	expectedComplexSQL := "SELECT `users`.`id`, `users`.`name`, `posts`.`id`, `posts`.`title`, COUNT(`comments`.`id`) AS `comment_count` FROM `users` JOIN `posts` ON `users`.`id` = `posts`.`user_id` LEFT JOIN `comments` ON `posts`.`id` = `comments`.`post_id` WHERE `users`.`id` > ? AND `posts`.`title` LIKE ? GROUP BY `users`.`id`, `users`.`name`, `posts`.`id`, `posts`.`title` HAVING COUNT(`comments`.`id`) > ? ORDER BY `comment_count` DESC LIMIT 20,10"
 	if sqlStr != expectedComplexSQL {
 		t.Errorf("Expected complex SQL: %s, got: %s", expectedComplexSQL, sqlStr)
 	}
@@ -393,3 +367,461 @@ func TestReadmeExample(t *testing.T) {
 		t.Errorf("Expected param to be int64(18), got %T %v", params[0], params[0])
 	}
 }
+
+func TestAggregateFuncs(t *testing.T) {
+	tests := []struct {
+		agg      AggregateFunc
+		expected string
+	}{
+		{Sum(UserAge), "SUM(`users`.`age`)"},
+		{Avg(UserAge), "AVG(`users`.`age`)"},
+		{Min(UserAge), "MIN(`users`.`age`)"},
+		{Max(UserAge), "MAX(`users`.`age`)"},
+	}
+	for _, tt := range tests {
+		sqlStr, _, err := tt.agg.ToSQL()
+		if err != nil {
+			t.Fatalf("Failed to generate SQL: %v", err)
+		}
+		if sqlStr != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, sqlStr)
+		}
+	}
+}
+
+func TestHavingComparisons(t *testing.T) {
+	tests := []struct {
+		cond     Expr
+		expected string
+		params   []interface{}
+	}{
+		{Avg(UserAge).Gt(20.5), "AVG(`users`.`age`) > ?", []interface{}{20.5}},
+		{Sum(UserAge).Gte(int64(100)), "SUM(`users`.`age`) >= ?", []interface{}{int64(100)}},
+		{Min(UserAge).Lte(18), "MIN(`users`.`age`) <= ?", []interface{}{int64(18)}},
+		{Count(UserID).Eq(1), "COUNT(`users`.`id`) = ?", []interface{}{int64(1)}},
+		{Count(UserID).Neq(0), "COUNT(`users`.`id`) != ?", []interface{}{int64(0)}},
+		{Max(UserAge).Lt(Sum(UserAge)), "MAX(`users`.`age`) < SUM(`users`.`age`)", nil},
+	}
+	for _, tt := range tests {
+		sqlStr, params, err := tt.cond.ToSQL()
+		if err != nil {
+			t.Fatalf("Failed to generate SQL: %v", err)
+		}
+		if sqlStr != tt.expected {
+			t.Errorf("Expected %s, got %s", tt.expected, sqlStr)
+		}
+		if len(params) != len(tt.params) {
+			t.Fatalf("Expected params %v, got %v", tt.params, params)
+		}
+		for i, p := range tt.params {
+			if params[i] != p {
+				t.Errorf("Expected param[%d] %v, got %v", i, p, params[i])
+			}
+		}
+	}
+}
+
+func TestOrderByAlias(t *testing.T) {
+	query := Select(UserID, Count(PostID).As("post_count")).
+		From(userTable.Name()).
+		GroupBy(UserID).
+		OrderByAlias("post_count", true).
+		OrderBy(Alias("id").Asc())
+	sqlStr, _, err := query.SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expected := "SELECT `users`.`id`, COUNT(`posts`.`id`) AS `post_count` FROM `users` GROUP BY `users`.`id` ORDER BY `post_count` DESC, `id` ASC"
+	if sqlStr != expected {
+		t.Errorf("Expected SQL: %s, got: %s", expected, sqlStr)
+	}
+
+	if _, _, err := Select(UserID).From(userTable.Name()).OrderByAlias("a`b", false).SQL(); err == nil {
+		t.Errorf("Expected error for invalid alias")
+	}
+}
+
+func TestSelectHint(t *testing.T) {
+	sqlStr, _, err := Select(UserID).From(userTable.Name()).
+		Hint("MAX_EXECUTION_TIME(1000)", "SET_VAR(sort_buffer_size = 16M)").
+		Where(UserAge.Gt(18)).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expected := "SELECT /*+ MAX_EXECUTION_TIME(1000) SET_VAR(sort_buffer_size = 16M) */ `users`.`id` FROM `users` WHERE `users`.`age` > ?"
+	if sqlStr != expected {
+		t.Errorf("Expected SQL: %s, got: %s", expected, sqlStr)
+	}
+
+	if _, _, err := Select(UserID).From(userTable.Name()).Hint("NO_ICP(t) */ DROP").SQL(); err == nil {
+		t.Errorf("Expected error for hint closing the comment")
+	}
+}
+
+func TestCountDistinct(t *testing.T) {
+	query := Select(CountDistinct(UserAge).As("ages")).From(userTable.Name())
+	sqlStr, _, err := query.SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expected := "SELECT COUNT(DISTINCT `users`.`age`) AS `ages` FROM `users`"
+	if sqlStr != expected {
+		t.Errorf("Expected %s, got %s", expected, sqlStr)
+	}
+}
+
+func TestNot(t *testing.T) {
+	tests := []struct {
+		name     string
+		cond     Expr
+		expected string
+	}{
+		{"single", Not(UserAge.Gt(18)), "NOT (`users`.`age` > ?)"},
+		{"and", Not(UserAge.Gt(18), UserName.Eq("a")), "NOT (`users`.`age` > ? AND `users`.`name` = ?)"},
+		{"or", field.Not(Or(UserAge.Gt(18), UserName.Eq("a"))), "NOT (`users`.`age` > ? OR `users`.`name` = ?)"},
+		{"empty", Not(), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlStr, _, err := tt.cond.ToSQL()
+			if err != nil {
+				t.Fatalf("Failed to generate SQL: %v", err)
+			}
+			if sqlStr != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, sqlStr)
+			}
+		})
+	}
+}
+
+func TestSelectClone(t *testing.T) {
+	base := Select(UserID, UserName).From(userTable.Name()).Where(UserAge.Gt(18))
+
+	adults := base.Clone().OrderBy(UserID.Desc()).Limit(10)
+	named := base.Clone().Where(UserName.Like("J%"))
+
+	tests := []struct {
+		builder     *SelectBuilder
+		expectedSQL string
+	}{
+		{base, "SELECT `users`.`id`, `users`.`name` FROM `users` WHERE `users`.`age` > ?"},
+		{adults, "SELECT `users`.`id`, `users`.`name` FROM `users` WHERE `users`.`age` > ? ORDER BY `users`.`id` DESC LIMIT 10"},
+		{named, "SELECT `users`.`id`, `users`.`name` FROM `users` WHERE `users`.`age` > ? AND `users`.`name` LIKE ?"},
+	}
+	for _, tt := range tests {
+		sqlStr, _, err := tt.builder.SQL()
+		if err != nil {
+			t.Fatalf("Failed to generate SQL: %v", err)
+		}
+		if sqlStr != tt.expectedSQL {
+			t.Errorf("Expected SQL: %s, got: %s", tt.expectedSQL, sqlStr)
+		}
+	}
+}
+
+func TestBoolOperations(t *testing.T) {
+	flags := table.New("flags")
+	active := flags.Bool("active")
+	verified := flags.Bool("verified")
+
+	sqlStr, params, err := Select(active).
+		From(flags.Name()).
+		Where(
+			active.Neq(false),
+			verified.In(true, false),
+			active.EqField(verified),
+			active.NeqField(verified),
+		).
+		OrderBy(active.Desc()).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "SELECT `flags`.`active` FROM `flags` WHERE `flags`.`active` != ? AND `flags`.`verified` IN (?, ?) AND `flags`.`active` = `flags`.`verified` AND `flags`.`active` != `flags`.`verified` ORDER BY `flags`.`active` DESC"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	expectedParams := []interface{}{int32(0), int32(1), int32(0)}
+	if len(params) != len(expectedParams) {
+		t.Fatalf("Expected params %v, got %v", expectedParams, params)
+	}
+	for i, p := range expectedParams {
+		if params[i] != p {
+			t.Errorf("Expected param[%d] %v, got %T %v", i, p, params[i], params[i])
+		}
+	}
+}
+
+func TestInt64Operations(t *testing.T) {
+	sqlStr, params, err := Select(UserID).
+		From(userTable.Name()).
+		Where(
+			UserAge.Between(18, 30),
+			UserID.InOrEmpty(),
+			UserID.BetweenField(PostID, PostUserID),
+			UserAge.IsNotNull(),
+		).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "SELECT `users`.`id` FROM `users` WHERE `users`.`age` BETWEEN ? AND ? AND `users`.`id` BETWEEN `posts`.`id` AND `posts`.`user_id` AND `users`.`age` IS NOT NULL"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 2 || params[0] != int64(18) || params[1] != int64(30) {
+		t.Errorf("Expected params [18 30], got %v", params)
+	}
+}
+
+func TestIntFieldsAsExpr(t *testing.T) {
+	counters := table.New("counters")
+	hits := counters.Int32("hits")
+
+	var cond field.Condition = UserAge.Gt(18)
+	sqlStr, _, err := Select(UserAge, Func("SUM", hits).As("total")).
+		From(userTable.Name()).
+		Where(cond).
+		GroupBy(UserAge, Func("ABS", hits)).
+		OrderBy(UserAge.Desc(), hits).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "SELECT `users`.`age`, SUM(`counters`.`hits`) AS `total` FROM `users` WHERE `users`.`age` > ? GROUP BY `users`.`age`, ABS(`counters`.`hits`) ORDER BY `users`.`age` DESC, `counters`.`hits`"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	orders := table.New("orders")
+	price := orders.Float64("price")
+	quantity := orders.Int64("quantity")
+	discount := orders.Float64("discount")
+	total := orders.Float64("total")
+
+	sqlStr, params, err := Select(price.Mul(quantity).As("amount")).
+		From(orders.Name()).
+		Where(price.Mul(quantity).Sub(discount).Gt(100), price.Add(discount).Lte(total)).
+		OrderBy(price.Mul(quantity).Desc()).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "SELECT `orders`.`price` * `orders`.`quantity` AS `amount` FROM `orders` WHERE (`orders`.`price` * `orders`.`quantity`) - `orders`.`discount` > ? AND `orders`.`price` + `orders`.`discount` <= `orders`.`total` ORDER BY `orders`.`price` * `orders`.`quantity` DESC"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 1 || params[0] != 100 {
+		t.Errorf("Expected params [100], got %v", params)
+	}
+
+	sqlStr, params, err = Update(orders.Name()).
+		Set(total, price.Mul(quantity.Sub(Int64(1)))).
+		Where(quantity.Gt(0)).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL = "UPDATE `orders` SET `total`=`orders`.`price` * (`orders`.`quantity` - ?) WHERE `orders`.`quantity` > ?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 2 {
+		t.Errorf("Expected 2 params, got %v", params)
+	}
+
+	// the field-level helpers keep the params of every operand
+	sqlStr, params, err = Add(UserAge, Int64(1), Int64(2)).ToSQL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sqlStr != "`users`.`age` + ? + ?" || len(params) != 2 {
+		t.Errorf("Unexpected Add: %s %v", sqlStr, params)
+	}
+}
+
+func TestModAndBitwise(t *testing.T) {
+	items := table.New("items")
+	id := items.Int64("id")
+	flags := items.Int32("flags")
+
+	sqlStr, params, err := Select(id).
+		From(items.Name()).
+		Where(id.Mod(Int64(100)).Lt(10), flags.BitAnd(Int64(4)).Neq(0)).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "SELECT `items`.`id` FROM `items` WHERE `items`.`id` % ? < ? AND `items`.`flags` & ? != ?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 4 {
+		t.Errorf("Expected 4 params, got %v", params)
+	}
+
+	sqlStr, _, err = Update(items.Name()).
+		Set(flags, flags.BitOr(Int64(1)).BitXor(Int64(2))).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL = "UPDATE `items` SET `flags`=(`items`.`flags` | ?) ^ ?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+}
+
+func TestStringFunctions(t *testing.T) {
+	sqlStr, params, err := Select(UserName.Upper().As("upper_name"), UserName.Substring(1, 3)).
+		From(userTable.Name()).
+		Where(
+			UserEmail.Lower().Eq("john@example.com"),
+			UserName.Trim().Neq(""),
+			UserName.Length().Gt(3),
+			UserName.Lower().In("a", "b"),
+		).
+		OrderBy(UserName.Length().Desc()).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "SELECT UPPER(`users`.`name`) AS `upper_name`, SUBSTRING(`users`.`name`, ?, ?) FROM `users` WHERE LOWER(`users`.`email`) = ? AND TRIM(`users`.`name`) != ? AND CHAR_LENGTH(`users`.`name`) > ? AND LOWER(`users`.`name`) IN (?, ?) ORDER BY CHAR_LENGTH(`users`.`name`) DESC"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	expectedParams := []interface{}{1, 3, "john@example.com", "", 3, "a", "b"}
+	if len(params) != len(expectedParams) {
+		t.Fatalf("Expected params %v, got %v", expectedParams, params)
+	}
+	for i, p := range expectedParams {
+		if params[i] != p {
+			t.Errorf("Expected param[%d] %v, got %v", i, p, params[i])
+		}
+	}
+}
+
+func TestCollate(t *testing.T) {
+	sqlStr, params, err := Select(UserID).
+		From(userTable.Name()).
+		Where(UserEmail.EqFold("John@Example.com"), UserName.Collate("utf8mb4_bin").In("a", "A")).
+		OrderBy(UserName.Collate("utf8mb4_unicode_ci").Asc()).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "SELECT `users`.`id` FROM `users` WHERE `users`.`email` COLLATE utf8mb4_general_ci = ? AND `users`.`name` COLLATE utf8mb4_bin IN (?, ?) ORDER BY `users`.`name` COLLATE utf8mb4_unicode_ci ASC"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 3 || params[0] != "John@Example.com" {
+		t.Errorf("Unexpected params: %v", params)
+	}
+
+	_, _, err = Select(UserID).From(userTable.Name()).Where(UserName.Collate("bin; DROP").Eq("x")).SQL()
+	if err == nil {
+		t.Error("Expected an error for an invalid collation")
+	}
+}
+
+func TestWhereSQL(t *testing.T) {
+	where, args, err := Select(UserID).From(userTable.Name()).Where(UserName.Eq("Alice"), UserAge.Gt(18)).WhereSQL()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "`users`.`name` = ? AND `users`.`age` > ?"; where != expected {
+		t.Errorf("Expected %s, got %s", expected, where)
+	}
+	if diff := sqltest.DiffArgs([]interface{}{"Alice", 18}, args); diff != "" {
+		t.Error(diff)
+	}
+
+	where, args, err = Select(UserID).From(userTable.Name()).WhereSQL()
+	if err != nil || where != "" || len(args) != 0 {
+		t.Errorf("Expected no conditions, got %q %v %v", where, args, err)
+	}
+}
+
+func TestSelectTooManyParams(t *testing.T) {
+	ids := make([]int64, MaxParams+1)
+	_, _, err := Select(UserID).From(userTable.Name()).Where(UserID.In(ids...)).SQL()
+	if !errors.Is(err, ErrTooManyParams) {
+		t.Fatalf("Expected ErrTooManyParams, got %v", err)
+	}
+	expected := "too many placeholders: SELECT has 65536 placeholders, MySQL allows at most 65535, split large IN lists into multiple statements"
+	if err.Error() != expected {
+		t.Errorf("Expected error %q, got %q", expected, err.Error())
+	}
+	if _, _, err := Select(UserID).From(userTable.Name()).Where(UserID.In(ids[1:]...)).SQL(); err != nil {
+		t.Errorf("Expected MaxParams placeholders to be allowed, got %v", err)
+	}
+}
+
+func TestInvalidIdentifiers(t *testing.T) {
+	evil := table.New("users`; DROP TABLE `users")
+	evilName := evil.String("name")
+	badColumn := userTable.String("name` = 1 OR `1")
+
+	tests := []struct {
+		name    string
+		builder sqltest.Builder
+	}{
+		{"table", Select(UserID).From(evil.Name())},
+		{"field table", Select(evilName).From(userTable.Name())},
+		{"column", Select(UserID).From(userTable.Name()).Where(badColumn.Eq("a"))},
+		{"alias", Select(UserID).From(userTable.Name()).As("u`")},
+		{"column alias", Select(UserName.As("n` FROM `secrets")).From(userTable.Name())},
+		{"join", Select(UserID).From(userTable.Name()).Join("posts` p", UserID.EqField(PostUserID))},
+		{"join alias", Select(UserID).From(userTable.Name()).JoinAs("posts", "a.p.q", UserID.EqField(PostUserID))},
+		{"long table", Select(UserID).From(strings.Repeat("t", 65))},
+		{"nul", Select(UserID).From("users\x00")},
+		{"func", Select(Func("SLEEP(10) OR NOW", UserID)).From(userTable.Name())},
+		{"insert", InsertInto(userTable.Name()).Set(badColumn, String("a"))},
+		{"update", Update(userTable.Name()).Set(badColumn, String("a")).Where(UserID.Eq(1))},
+		{"delete", DeleteFrom("users`;").Where(UserID.Eq(1))},
+		{"delete alias", DeleteFrom(userTable.Name()).As("u`").Where(UserID.Eq(1))},
+		{"update table", Update("users`").Set(UserName, String("a")).Where(UserID.Eq(1))},
+		{"insert table", InsertInto("users`").Set(UserName, String("a"))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.builder.SQL()
+			if !errors.Is(err, field.ErrInvalidIdentifier) {
+				t.Errorf("Expected ErrInvalidIdentifier, got %v", err)
+			}
+		})
+	}
+
+	if _, _, err := Select(UserID).From("analytics.events").SQL(); err != nil {
+		t.Errorf("Expected qualified table to be valid, got %v", err)
+	}
+}
+
+// TestQuotedIdentifiers tests that names valid once quoted, like
+// a leading digit or non-ASCII letters, are accepted
+func TestQuotedIdentifiers(t *testing.T) {
+	codes := table.New("2fa_codes")
+	codeUser := codes.Int64("1st_user")
+	query, _, err := Select(codeUser).From(codes.Name()).As("2fa").Where(codeUser.Eq(1)).SQL()
+	if err != nil {
+		t.Fatalf("Expected 2fa_codes to be valid, got %v", err)
+	}
+	expected := "SELECT `2fa_codes`.`1st_user` FROM `2fa_codes` AS `2fa` WHERE `2fa_codes`.`1st_user` = ?"
+	if query != expected {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expected, query)
+	}
+
+	größe := table.New("größe")
+	wert := größe.String("wert")
+	query, _, err = Update(größe.Name()).Set(wert, String("a")).Where(wert.Eq("b")).SQL()
+	if err != nil {
+		t.Fatalf("Expected a non-ASCII table to be valid, got %v", err)
+	}
+	expected = "UPDATE `größe` SET `wert`=? WHERE `größe`.`wert` = ?"
+	if query != expected {
+		t.Errorf("Expected SQL:\n%s\ngot:\n%s", expected, query)
+	}
+}
@@ -0,0 +1,61 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/xhd2015/arc-orm/sqltest"
+	"github.com/xhd2015/arc-orm/table"
+)
+
+func TestTableAlias(t *testing.T) {
+	query := Select(UserID, PostTitle).From("users_2025").As(userTable.Name()).
+		JoinAs("posts_2025", postTable.Name(), PostUserID.EqField(UserID)).
+		Where(UserAge.Gt(18))
+	sqltest.AssertSQL(t, query, "SELECT `users`.`id`, `posts`.`title` FROM `users_2025` AS `users` JOIN `posts_2025` AS `posts` ON `posts`.`user_id` = `users`.`id` WHERE `users`.`age` > ?", 18)
+
+	query = Select(UserID).From("users_2025").Partition("p1").As(userTable.Name()).LeftJoinAs("posts_2025", postTable.Name(), PostUserID.EqField(UserID))
+	sqltest.AssertSQL(t, query, "SELECT `users`.`id` FROM `users_2025` PARTITION (`p1`) AS `users` LEFT JOIN `posts_2025` AS `posts` ON `posts`.`user_id` = `users`.`id`")
+
+	update := Update("users_2025").As(userTable.Name()).Set(UserAge, Int64(20)).Where(UserID.Eq(1))
+	sqltest.AssertSQL(t, update, "UPDATE `users_2025` AS `users` SET `age`=? WHERE `users`.`id` = ?", 20, 1)
+
+	del := DeleteFrom("users_2025").As(userTable.Name()).Where(UserID.Eq(1))
+	sqltest.AssertSQL(t, del, "DELETE `users` FROM `users_2025` AS `users` WHERE `users`.`id` = ?", 1)
+
+	if _, _, err := DeleteFrom("users_2025").As(userTable.Name()).Limit(1).SQL(); err == nil {
+		t.Errorf("Expected error for LIMIT on aliased DELETE")
+	}
+}
+
+func TestQualifiedTable(t *testing.T) {
+	events := table.NewIn("analytics", "events")
+	eventUserID := events.Int64("user_id")
+	eventCount := events.Int64("count")
+
+	query := Select(UserName, eventCount).From(userTable.Name()).
+		Join(events.Name(), eventUserID.EqField(UserID)).
+		Where(eventCount.Gt(1))
+	sqltest.AssertSQL(t, query, "SELECT `users`.`name`, `analytics`.`events`.`count` FROM `users` JOIN `analytics`.`events` ON `analytics`.`events`.`user_id` = `users`.`id` WHERE `analytics`.`events`.`count` > ?", 1)
+
+	insert := InsertInto(events.Name()).Set(eventUserID, Int64(1))
+	sqltest.AssertSQL(t, insert, "INSERT INTO `analytics`.`events` SET `user_id`=?", 1)
+
+	update := Update(events.Name()).Set(eventCount, eventCount.Increment(1)).Where(eventUserID.Eq(1))
+	sqltest.AssertSQL(t, update, "UPDATE `analytics`.`events` SET `count`=`analytics`.`events`.`count`+? WHERE `analytics`.`events`.`user_id` = ?", 1, 1)
+
+	del := DeleteFrom(events.Name()).Where(eventUserID.Eq(1))
+	sqltest.AssertSQL(t, del, "DELETE FROM `analytics`.`events` WHERE `analytics`.`events`.`user_id` = ?", 1)
+
+	// qualified aliases alias the table by its unqualified name
+	query = Select(eventCount).From("analytics.events_2025").As(events.Name()).Where(eventUserID.Eq(1))
+	sqltest.AssertSQL(t, query, "SELECT `events`.`count` FROM `analytics`.`events_2025` AS `events` WHERE `events`.`user_id` = ?", 1)
+
+	update = Update("analytics.events_2025").As(events.Name()).Set(eventCount, eventCount.Increment(1)).Where(eventUserID.Eq(1))
+	sqltest.AssertSQL(t, update, "UPDATE `analytics`.`events_2025` AS `events` SET `count`=`events`.`count`+? WHERE `events`.`user_id` = ?", 1, 1)
+
+	del = DeleteFrom("analytics.events_2025").As(events.Name()).Where(eventUserID.Eq(1))
+	sqltest.AssertSQL(t, del, "DELETE `events` FROM `analytics`.`events_2025` AS `events` WHERE `events`.`user_id` = ?", 1)
+
+	query = Select(UserName).From(userTable.Name()).JoinAs("analytics.app_events", events.Name(), eventUserID.EqField(UserID))
+	sqltest.AssertSQL(t, query, "SELECT `users`.`name` FROM `users` JOIN `analytics`.`app_events` AS `events` ON `events`.`user_id` = `users`.`id`")
+}
@@ -3,7 +3,6 @@ package sql
 import (
 	"errors"
 	"fmt"
-	"strings"
 
 	"github.com/xhd2015/arc-orm/field"
 	"github.com/xhd2015/arc-orm/sql/expr"
@@ -13,15 +12,23 @@ import (
 func Update(tableName string) *UpdateBuilder {
 	return &UpdateBuilder{
 		tableName: tableName,
+		err:       checkTable(nil, tableName),
 	}
 }
 
 // UpdateBuilder builds UPDATE queries
 type UpdateBuilder struct {
 	tableName  string
+	alias      string
+	joins      []join
 	updates    []updateExpr
 	conditions []expr.Expr
+	partitions []string
+	comment    string
 	err        error
+
+	requireWhere   bool
+	allowFullTable bool
 }
 
 // updateExpr represents an update expression in the SET clause
@@ -37,6 +44,10 @@ func (b *UpdateBuilder) Set(f field.Field, value expr.Expr) *UpdateBuilder {
 	if b.err != nil {
 		return b // Skip if already errored
 	}
+	if err := field.ValidateIdentifier(f.Name()); err != nil {
+		b.err = fmt.Errorf("SET field: %w", err)
+		return b
+	}
 	exprSQL, exprParams, err := value.ToSQL()
 	if err != nil {
 		b.err = fmt.Errorf("SET field '%s': %w", f.Name(), err)
@@ -50,6 +61,60 @@ func (b *UpdateBuilder) Set(f field.Field, value expr.Expr) *UpdateBuilder {
 	return b
 }
 
+// SetIf adds the field=value expression only when cond is true,
+// like Optional for WHERE, keeping optional column writes chainable
+func (b *UpdateBuilder) SetIf(cond bool, f field.Field, value expr.Expr) *UpdateBuilder {
+	if !cond {
+		return b
+	}
+	return b.Set(f, value)
+}
+
+// SetMap adds a field=value expression for each entry of values,
+// in the order of column names, for columns computed dynamically
+func (b *UpdateBuilder) SetMap(values map[field.Field]expr.Expr) *UpdateBuilder {
+	for _, f := range sortedFields(values) {
+		b.Set(f, values[f])
+	}
+	return b
+}
+
+// Join adds a join clause, making a multi-table UPDATE (MySQL),
+// SET columns are then qualified by their tables
+func (b *UpdateBuilder) Join(tableName string, condition field.Expr) *UpdateBuilder {
+	b.err = checkTable(b.err, tableName)
+	b.joins = append(b.joins, join{
+		tableName: tableName,
+		condition: condition,
+		joinType:  "JOIN",
+	})
+	return b
+}
+
+// LeftJoin adds a left join clause, see Join
+func (b *UpdateBuilder) LeftJoin(tableName string, condition field.Expr) *UpdateBuilder {
+	b.err = checkTable(b.err, tableName)
+	b.joins = append(b.joins, join{
+		tableName: tableName,
+		condition: condition,
+		joinType:  "LEFT JOIN",
+	})
+	return b
+}
+
+// As sets the alias of the table to update, see SelectBuilder.As
+func (b *UpdateBuilder) As(alias string) *UpdateBuilder {
+	b.alias = alias
+	b.err = checkAlias(b.err, alias)
+	return b
+}
+
+// Partition restricts the update to the given partitions, see SelectBuilder.Partition
+func (b *UpdateBuilder) Partition(partitions ...string) *UpdateBuilder {
+	b.partitions = append(b.partitions, partitions...)
+	return b
+}
+
 // Where adds conditions to the UPDATE query
 func (b *UpdateBuilder) Where(conditions ...expr.Expr) *UpdateBuilder {
 	if b.err != nil {
@@ -59,6 +124,23 @@ func (b *UpdateBuilder) Where(conditions ...expr.Expr) *UpdateBuilder {
 	return b
 }
 
+// Comment sets a comment appended to the generated SQL, e.g. "svc=checkout route=/pay",
+// so slow queries can be attributed to their call sites. See SanitizeComment.
+func (b *UpdateBuilder) Comment(comment string) *UpdateBuilder {
+	b.comment = comment
+	return b
+}
+
+// Clone returns a copy of the builder, see SelectBuilder.Clone
+func (b *UpdateBuilder) Clone() *UpdateBuilder {
+	c := *b
+	c.joins = cloneSlice(b.joins)
+	c.updates = cloneSlice(b.updates)
+	c.partitions = cloneSlice(b.partitions)
+	c.conditions = cloneSlice(b.conditions)
+	return &c
+}
+
 // SQL generates the SQL string and parameters for the UPDATE statement
 func (b *UpdateBuilder) SQL() (string, []interface{}, error) {
 	// Check for staged errors first
@@ -72,19 +154,46 @@ func (b *UpdateBuilder) SQL() (string, []interface{}, error) {
 		return "", nil, errors.New("at least one SET expression is required")
 	}
 
-	var sqlBuilder strings.Builder
-	var params []interface{}
+	sqlBuilder := getBuffer()
+	defer putBuffer(sqlBuilder)
+	numParams := len(b.conditions)
+	for _, update := range b.updates {
+		numParams += len(update.params)
+	}
+	params := newParams(numParams)
 
 	// Build UPDATE clause
-	sqlBuilder.WriteString("UPDATE `")
-	sqlBuilder.WriteString(b.tableName)
-	sqlBuilder.WriteString("` SET ")
+	sqlBuilder.WriteString("UPDATE ")
+	sqlBuilder.WriteString(field.QuoteTable(b.tableName))
+	if err := writePartitions(sqlBuilder, b.partitions); err != nil {
+		return "", nil, err
+	}
+	writeAlias(sqlBuilder, b.alias)
+
+	// Build JOIN clauses
+	params, err := writeJoins(sqlBuilder, params, b.joins)
+	if err != nil {
+		return "", nil, err
+	}
+	sqlBuilder.WriteString(" SET ")
 
 	// Build SET clause
 	for i, update := range b.updates {
 		if i > 0 {
 			sqlBuilder.WriteString(", ")
 		}
+		if len(b.joins) > 0 {
+			// qualify columns as they may be ambiguous across tables
+			table := update.field.Table()
+			if table == "" {
+				table = b.tableName
+				if b.alias != "" {
+					table = b.alias
+				}
+			}
+			sqlBuilder.WriteString(field.QuoteTable(table))
+			sqlBuilder.WriteString(".")
+		}
 		sqlBuilder.WriteString("`")
 		sqlBuilder.WriteString(update.field.Name())
 		sqlBuilder.WriteString("`")
@@ -93,25 +202,19 @@ func (b *UpdateBuilder) SQL() (string, []interface{}, error) {
 	}
 
 	// Build WHERE clause
-	if len(b.conditions) > 0 {
-		sqlBuilder.WriteString(" WHERE ")
-		for i, condition := range b.conditions {
-			if i > 0 {
-				sqlBuilder.WriteString(" AND ")
-			}
-
-			condSQL, condParams, err := condition.ToSQL()
-			if err != nil {
-				return "", nil, fmt.Errorf("failed to build where condition: %w", err)
-			}
-			if condSQL == "" {
-				continue
-			}
+	whereStart := sqlBuilder.Len()
+	params, err = writeConditions(sqlBuilder, params, " WHERE ", b.conditions)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to build where condition: %w", err)
+	}
+	if b.requireWhere && !b.allowFullTable && sqlBuilder.Len() == whereStart {
+		return "", nil, fmt.Errorf("UPDATE %s: %w", b.tableName, ErrFullTable)
+	}
 
-			sqlBuilder.WriteString(condSQL)
-			params = append(params, condParams...)
-		}
+	if err := checkParams("UPDATE", params); err != nil {
+		return "", nil, err
 	}
 
-	return sqlBuilder.String(), params, nil
+	writeComment(sqlBuilder, b.comment)
+	return qualifyByAliases(sqlBuilder.String(), b.alias, b.joins), params, nil
 }
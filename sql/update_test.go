@@ -2,6 +2,9 @@ package sql
 
 import (
 	"testing"
+
+	"github.com/xhd2015/arc-orm/field"
+	"github.com/xhd2015/arc-orm/sql/expr"
 )
 
 func TestUpdateQueries(t *testing.T) {
@@ -178,3 +181,60 @@ func TestUpdateBuilder_WithFunctions(t *testing.T) {
 		t.Errorf("expected 2 params, got %d", len(params))
 	}
 }
+
+func TestUpdateJoin(t *testing.T) {
+	query := Update(postTable.Name()).
+		Join(userTable.Name(), PostUserID.EqField(UserID)).
+		Set(PostTitle, UserName).
+		Where(UserAge.Gt(18))
+
+	sqlStr, params, err := query.SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "UPDATE `posts` JOIN `users` ON `posts`.`user_id` = `users`.`id` SET `posts`.`title`=`users`.`name` WHERE `users`.`age` > ?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 1 {
+		t.Errorf("Expected 1 param, got %d", len(params))
+	}
+}
+
+func TestUpdateSetMap(t *testing.T) {
+	sqlStr, params, err := Update(userTable.Name()).
+		SetMap(map[field.Field]expr.Expr{
+			UserName: String("John Doe"),
+			UserAge:  UserAge.Increment(1),
+		}).
+		Where(UserID.Eq(1)).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "UPDATE `users` SET `age`=`users`.`age`+?, `name`=? WHERE `users`.`id` = ?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 3 {
+		t.Errorf("Expected 3 params, got %v", params)
+	}
+}
+
+func TestUpdateSetIf(t *testing.T) {
+	sqlStr, params, err := Update(userTable.Name()).
+		SetIf(true, UserName, String("John Doe")).
+		SetIf(false, UserAge, Int64(30)).
+		Where(UserID.Eq(1)).
+		SQL()
+	if err != nil {
+		t.Fatalf("Failed to generate SQL: %v", err)
+	}
+	expectedSQL := "UPDATE `users` SET `name`=? WHERE `users`.`id` = ?"
+	if sqlStr != expectedSQL {
+		t.Errorf("Expected SQL: %s, got: %s", expectedSQL, sqlStr)
+	}
+	if len(params) != 2 {
+		t.Errorf("Expected 2 params, got %v", params)
+	}
+}
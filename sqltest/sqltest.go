@@ -0,0 +1,172 @@
+// Package sqltest provides assertions for the SQL generated by builders,
+// or executed through an engine.Recorder, replacing the usual comparison
+// boilerplate in tests:
+//
+//	sqltest.AssertSQL(t, query, "SELECT `users`.`id` FROM `users` WHERE `users`.`id` = ?", 1)
+//	sqltest.AssertExecuted(t, rec, "DELETE FROM `users` WHERE `users`.`id` = ?", 1)
+package sqltest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/engine"
+)
+
+// Builder is implemented by the builders of the sql and orm packages
+type Builder interface {
+	SQL() (string, []interface{}, error)
+}
+
+// AssertSQL asserts that builder generates the expected SQL and args.
+// Whitespace is normalized before comparing, so the expected SQL may
+// be split across lines. Integer and float args are compared by value,
+// e.g. 1 matches int64(1), and times by instant.
+func AssertSQL(t testing.TB, builder Builder, expected string, args ...interface{}) {
+	t.Helper()
+	sql, gotArgs, err := builder.SQL()
+	if err != nil {
+		t.Fatalf("failed to generate SQL: %v", err)
+		return
+	}
+	if Normalize(sql) != Normalize(expected) {
+		t.Errorf("SQL mismatch:\nexpected: %s\n     got: %s", Normalize(expected), Normalize(sql))
+	}
+	if diff := DiffArgs(args, gotArgs); diff != "" {
+		t.Errorf("args mismatch:\n%s", diff)
+	}
+}
+
+// AssertCount asserts that n statements were recorded by rec
+func AssertCount(t testing.TB, rec *engine.RecordingEngine, n int) {
+	t.Helper()
+	if sqls := rec.SQLs(); len(sqls) != n {
+		t.Errorf("expected %d statements, got %d:\n%s", n, len(sqls), strings.Join(sqls, "\n"))
+	}
+}
+
+// AssertExecuted asserts that a statement with the given SQL and args was
+// recorded by rec, compared like AssertSQL. The args are not checked
+// if none are given.
+func AssertExecuted(t testing.TB, rec *engine.RecordingEngine, sql string, args ...interface{}) {
+	t.Helper()
+	expected := Normalize(sql)
+	var sqls []string
+	for _, stmt := range rec.Statements() {
+		if Normalize(stmt.SQL) != expected {
+			sqls = append(sqls, stmt.SQL)
+			continue
+		}
+		if len(args) == 0 {
+			return
+		}
+		diff := DiffArgs(args, stmt.Args)
+		if diff == "" {
+			return
+		}
+		sqls = append(sqls, stmt.SQL+"\n"+diff)
+	}
+	t.Errorf("expected statement not executed:\n%s\nrecorded:\n%s", expected, strings.Join(sqls, "\n"))
+}
+
+// Normalize collapses whitespace runs outside of quotes into
+// single spaces and trims leading and trailing whitespace
+func Normalize(sql string) string {
+	var b strings.Builder
+	var quote byte
+	space := false
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+		if quote == 0 && isSpace(c) {
+			space = true
+			continue
+		}
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+		b.WriteByte(c)
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '\'' || c == '"' || c == '`'):
+			quote = c
+		}
+	}
+	return b.String()
+}
+
+// DiffArgs describes the differences between the expected and actual
+// args, one line per differing position, or returns "" if they match
+func DiffArgs(expected []interface{}, actual []interface{}) string {
+	var lines []string
+	n := len(expected)
+	if len(actual) > n {
+		n = len(actual)
+	}
+	for i := 0; i < n; i++ {
+		switch {
+		case i >= len(actual):
+			lines = append(lines, fmt.Sprintf("arg[%d]: expected %s, missing", i, describe(expected[i])))
+		case i >= len(expected):
+			lines = append(lines, fmt.Sprintf("arg[%d]: unexpected %s", i, describe(actual[i])))
+		case !argEqual(expected[i], actual[i]):
+			lines = append(lines, fmt.Sprintf("arg[%d]: expected %s, got %s", i, describe(expected[i]), describe(actual[i])))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// argEqual compares args, numbers are compared by value
+// regardless of their exact type, times by instant
+func argEqual(expected interface{}, actual interface{}) bool {
+	if reflect.DeepEqual(expected, actual) {
+		return true
+	}
+	if et, ok := expected.(time.Time); ok {
+		at, ok := actual.(time.Time)
+		return ok && et.Equal(at)
+	}
+	ei, eInt := integer(expected)
+	ai, aInt := integer(actual)
+	if eInt && aInt {
+		return ei == ai
+	}
+	ef, eNum := float(expected)
+	af, aNum := float(actual)
+	return eNum && aNum && ef == af
+}
+
+func integer(v interface{}) (int64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint()), true
+	}
+	return 0, false
+}
+
+func float(v interface{}) (float64, bool) {
+	if i, ok := integer(v); ok {
+		return float64(i), true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+func describe(v interface{}) string {
+	return fmt.Sprintf("%T(%#v)", v, v)
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
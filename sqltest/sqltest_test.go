@@ -0,0 +1,124 @@
+package sqltest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xhd2015/arc-orm/engine"
+)
+
+type staticBuilder struct {
+	sql  string
+	args []interface{}
+	err  error
+}
+
+func (b staticBuilder) SQL() (string, []interface{}, error) {
+	return b.sql, b.args, b.err
+}
+
+// recordT records failures instead of failing the test
+type recordT struct {
+	testing.TB
+	errors []string
+	fatal  bool
+}
+
+func (t *recordT) Helper() {}
+
+func (t *recordT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *recordT) Fatalf(format string, args ...interface{}) {
+	t.fatal = true
+	t.Errorf(format, args...)
+}
+
+func TestAssertSQL(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	builder := staticBuilder{
+		sql:  "SELECT `id` FROM `users`\n  WHERE `age` > ? AND `create_time` < ? AND `name` = 'a  b'",
+		args: []interface{}{int64(18), now},
+	}
+
+	rt := &recordT{}
+	AssertSQL(rt, builder, `
+		SELECT `+"`id`"+` FROM `+"`users`"+`
+		WHERE `+"`age`"+` > ? AND `+"`create_time`"+` < ? AND `+"`name`"+` = 'a  b'
+	`, 18, now.In(time.FixedZone("UTC+8", 8*3600)))
+	if len(rt.errors) != 0 {
+		t.Errorf("Expected no failures, got %q", rt.errors)
+	}
+
+	rt = &recordT{}
+	AssertSQL(rt, builder, "SELECT `id` FROM `users` WHERE `age` > ? AND `create_time` < ? AND `name` = 'a b'", 18)
+	if len(rt.errors) != 2 {
+		t.Fatalf("Expected SQL and args failures, got %q", rt.errors)
+	}
+	if !strings.Contains(rt.errors[0], "SQL mismatch") {
+		t.Errorf("Expected SQL mismatch, got %q", rt.errors[0])
+	}
+	if !strings.Contains(rt.errors[1], "arg[1]: unexpected time.Time") {
+		t.Errorf("Expected unexpected arg[1], got %q", rt.errors[1])
+	}
+
+	rt = &recordT{}
+	AssertSQL(rt, staticBuilder{err: errors.New("bad field")}, "SELECT 1")
+	if !rt.fatal || !strings.Contains(rt.errors[0], "bad field") {
+		t.Errorf("Expected fatal build error, got %q", rt.errors)
+	}
+}
+
+func TestAssertExecuted(t *testing.T) {
+	ctx := context.Background()
+	rec := engine.Recorder(nil)
+	eng := rec.GetEngine()
+	if err := eng.Exec(ctx, "DELETE FROM `users` WHERE `id` = ?", []interface{}{int64(1)}); err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+	if _, err := eng.ExecInsert(ctx, "INSERT INTO `users` SET `name`=?", []interface{}{"Alice"}); err != nil {
+		t.Fatalf("ExecInsert: %v", err)
+	}
+
+	rt := &recordT{}
+	AssertCount(rt, rec, 2)
+	AssertExecuted(rt, rec, "DELETE FROM `users`\n  WHERE `id` = ?", 1)
+	AssertExecuted(rt, rec, "INSERT INTO `users` SET `name`=?")
+	if len(rt.errors) != 0 {
+		t.Errorf("Expected no failures, got %q", rt.errors)
+	}
+
+	rt = &recordT{}
+	AssertCount(rt, rec, 1)
+	AssertExecuted(rt, rec, "DELETE FROM `users` WHERE `id` = ?", 2)
+	AssertExecuted(rt, rec, "UPDATE `users` SET `name`=?")
+	if len(rt.errors) != 3 {
+		t.Fatalf("Expected count, args and SQL failures, got %q", rt.errors)
+	}
+	if !strings.Contains(rt.errors[0], "expected 1 statements, got 2") {
+		t.Errorf("Expected count failure, got %q", rt.errors[0])
+	}
+	if !strings.Contains(rt.errors[1], "arg[0]") {
+		t.Errorf("Expected args failure, got %q", rt.errors[1])
+	}
+	if !strings.Contains(rt.errors[2], "expected statement not executed") {
+		t.Errorf("Expected statement failure, got %q", rt.errors[2])
+	}
+}
+
+func TestDiffArgs(t *testing.T) {
+	diff := DiffArgs([]interface{}{1, "a", 1.5}, []interface{}{int64(1), "b"})
+	expected := "arg[1]: expected string(\"a\"), got string(\"b\")\n" +
+		"arg[2]: expected float64(1.5), missing"
+	if diff != expected {
+		t.Errorf("Expected diff:\n%s\ngot:\n%s", expected, diff)
+	}
+	if diff := DiffArgs([]interface{}{int32(2), 2}, []interface{}{int64(2), 2.0}); diff != "" {
+		t.Errorf("Expected numbers to match by value, got %s", diff)
+	}
+}
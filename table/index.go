@@ -0,0 +1,81 @@
+package table
+
+import (
+	"github.com/xhd2015/arc-orm/field"
+)
+
+// IndexKind is the kind of an index
+type IndexKind int
+
+const (
+	// IndexPlain is a non-unique index
+	IndexPlain IndexKind = iota
+	// IndexUnique is a unique index
+	IndexUnique
+	// IndexPrimary is the primary key
+	IndexPrimary
+)
+
+// PrimaryKeyName is the name of the primary key index
+const PrimaryKeyName = "PRIMARY"
+
+// Index describes an index of a table, used by the DDL
+// generator and by tooling inspecting the table definition
+type Index struct {
+	Name   string
+	Kind   IndexKind
+	Fields []field.Field
+}
+
+// Columns returns the column names of the index, in order
+func (idx Index) Columns() []string {
+	columns := make([]string, len(idx.Fields))
+	for i, f := range idx.Fields {
+		columns[i] = f.Name()
+	}
+	return columns
+}
+
+// Index declares a non-unique index on the fields
+func (t *Table) Index(name string, fields ...field.Field) Index {
+	return t.addIndex(Index{Name: name, Kind: IndexPlain, Fields: fields})
+}
+
+// Unique declares a unique index on the fields
+func (t *Table) Unique(name string, fields ...field.Field) Index {
+	return t.addIndex(Index{Name: name, Kind: IndexUnique, Fields: fields})
+}
+
+// PrimaryKey declares the primary key of the table, replacing
+// a previously declared one. Without it, `id` is the primary key.
+func (t *Table) PrimaryKey(fields ...field.Field) Index {
+	idx := Index{Name: PrimaryKeyName, Kind: IndexPrimary, Fields: fields}
+	for i, existing := range t.indexes {
+		if existing.Kind == IndexPrimary {
+			t.indexes[i] = idx
+			return idx
+		}
+	}
+	return t.addIndex(idx)
+}
+
+func (t *Table) addIndex(idx Index) Index {
+	t.indexes = append(t.indexes, idx)
+	return idx
+}
+
+// Indexes returns the declared indexes, including the
+// primary key, in declaration order
+func (t Table) Indexes() []Index {
+	return t.indexes
+}
+
+// Primary returns the declared primary key
+func (t Table) Primary() (Index, bool) {
+	for _, idx := range t.indexes {
+		if idx.Kind == IndexPrimary {
+			return idx, true
+		}
+	}
+	return Index{}, false
+}
@@ -6,8 +6,9 @@ import (
 
 // Table represents a database table
 type Table struct {
-	name   string
-	fields []field.Field
+	name    string
+	fields  []field.Field
+	indexes []Index
 }
 
 // New creates a new Table
@@ -18,15 +19,24 @@ func New(name string) Table {
 	}
 }
 
-// Name returns the table name
+// NewIn creates a new Table in the given database (schema), for
+// cross-database queries on the same server. The table and its fields
+// render qualified, like `analytics`.`events`.`id`.
+// It is equivalent to New("analytics.events").
+func NewIn(schema string, name string) Table {
+	return New(schema + "." + name)
+}
+
+// Name returns the table name, qualified by its schema if any
 func (t Table) Name() string {
 	return t.name
 }
 
 func (t Table) WithName(name string) Table {
 	return Table{
-		name:   name,
-		fields: t.fields,
+		name:    name,
+		fields:  t.fields,
+		indexes: t.indexes,
 	}
 }
 
@@ -37,50 +47,35 @@ func (t Table) Fields() []field.Field {
 
 // Int64 creates a new Int64Field for this table
 func (t *Table) Int64(name string) field.Int64Field {
-	f := field.Int64Field{
-		FieldName: name,
-		TableName: t.name,
-	}
+	f := field.NewInt64(t.name, name)
 	t.fields = append(t.fields, f)
 	return f
 }
 
 // Int32 creates a new Int32Field for this table
 func (t *Table) Int32(name string) field.Int32Field {
-	f := field.Int32Field{
-		FieldName: name,
-		TableName: t.name,
-	}
+	f := field.NewInt32(t.name, name)
 	t.fields = append(t.fields, f)
 	return f
 }
 
 // Float64 creates a new Float64Field for this table
 func (t *Table) Float64(name string) field.Float64Field {
-	f := field.Float64Field{
-		FieldName: name,
-		TableName: t.name,
-	}
+	f := field.NewFloat64(t.name, name)
 	t.fields = append(t.fields, f)
 	return f
 }
 
 // String creates a new StringField for this table
 func (t *Table) String(name string) field.StringField {
-	f := field.StringField{
-		FieldName: name,
-		TableName: t.name,
-	}
+	f := field.NewString(t.name, name)
 	t.fields = append(t.fields, f)
 	return f
 }
 
 // Time creates a new TimeField for this table
 func (t *Table) Time(name string) field.TimeField {
-	f := field.TimeField{
-		FieldName: name,
-		TableName: t.name,
-	}
+	f := field.NewTime(t.name, name)
 	t.fields = append(t.fields, f)
 	return f
 }
@@ -88,10 +83,14 @@ func (t *Table) Time(name string) field.TimeField {
 // Bool creates a new BoolField for this table
 // In MySQL, boolean values are stored as TINYINT(1) where 0 = false and 1 = true
 func (t *Table) Bool(name string) field.BoolField {
-	f := field.BoolField{
-		FieldName: name,
-		TableName: t.name,
-	}
+	f := field.NewBool(t.name, name)
+	t.fields = append(t.fields, f)
+	return f
+}
+
+// UUID creates a new UuidField for this table
+func (t *Table) UUID(name string) field.UuidField {
+	f := field.NewUUID(t.name, name)
 	t.fields = append(t.fields, f)
 	return f
 }